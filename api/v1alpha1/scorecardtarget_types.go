@@ -0,0 +1,242 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ScorecardTargetSpec defines the organization to export scorecard metrics
+// for. It mirrors the data keys of scorecard ConfigMaps, with types and
+// validation.
+type ScorecardTargetSpec struct {
+	// Organization is the organization, group or user owning the repositories
+	// +kubebuilder:validation:MinLength=1
+	Organization string `json:"organization"`
+
+	// ProviderType is the VCS provider hosting the repositories
+	// +kubebuilder:validation:Enum=github;gitea;gitlab
+	// +kubebuilder:default=github
+	// +optional
+	ProviderType string `json:"providerType,omitempty"`
+
+	// OwnerType is the kind of account owning the repositories
+	// +kubebuilder:validation:Enum=org;user
+	// +optional
+	OwnerType string `json:"ownerType,omitempty"`
+
+	// BaseURLs are the VCS instances to enumerate the organization on, where
+	// "default" refers to the provider's public instance
+	// +optional
+	BaseURLs []string `json:"baseURLs,omitempty"`
+
+	// TokenSecretRef references the Secret holding the VCS token
+	// +optional
+	TokenSecretRef *SecretKeyReference `json:"tokenSecretRef,omitempty"`
+
+	// GitHubApp authenticates with the credentials of a GitHub App instead
+	// of the token, for GitHub only
+	// +optional
+	GitHubApp *GitHubAppReference `json:"gitHubApp,omitempty"`
+
+	// Username is sent with the token as basic auth credentials, for
+	// providers that support it
+	// +optional
+	Username string `json:"username,omitempty"`
+
+	// Source selects where scorecard data comes from
+	// +kubebuilder:validation:Enum=api;local
+	// +optional
+	Source string `json:"source,omitempty"`
+
+	// ScorecardAPIEndpoint is the scorecard API endpoint to fetch data from,
+	// the endpoint of the manager if unset. Requests to it are never
+	// authenticated.
+	// +optional
+	ScorecardAPIEndpoint string `json:"scorecardAPIEndpoint,omitempty"`
+
+	// Branch reports on the head commit of this branch instead of the
+	// default branch. It cannot be combined with Commit.
+	// +optional
+	Branch string `json:"branch,omitempty"`
+
+	// Commit reports on this commit instead of the default branch
+	// +optional
+	Commit string `json:"commit,omitempty"`
+
+	// Filters select the repositories and checks to export
+	// +optional
+	Filters ScorecardTargetFilters `json:"filters,omitempty"`
+
+	// RepositoryInfo exports repository metadata, at the cost of an extra
+	// VCS API call per repository
+	// +optional
+	RepositoryInfo bool `json:"repositoryInfo,omitempty"`
+
+	// GraphQL lists repositories through the GraphQL API, for providers
+	// supporting it
+	// +optional
+	GraphQL bool `json:"graphql,omitempty"`
+
+	// UnavailableValue selects how repositories without scorecard data are
+	// exported
+	// +kubebuilder:validation:Enum=negative_one;nan;absent
+	// +optional
+	UnavailableValue string `json:"unavailableValue,omitempty"`
+
+	// PassThreshold is the lowest score of a passing check, the manager
+	// default if unset
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=10
+	// +optional
+	PassThreshold *int32 `json:"passThreshold,omitempty"`
+
+	// CheckWeights are the weights of the checks making up a custom overall
+	// score, by check name. Weights are positive numbers such as "2" or
+	// "0.5".
+	// +optional
+	CheckWeights map[string]string `json:"checkWeights,omitempty"`
+
+	// RequeueInterval is the interval between reconciles, the manager
+	// default if unset
+	// +optional
+	RequeueInterval *metav1.Duration `json:"requeueInterval,omitempty"`
+}
+
+// ScorecardTargetFilters select the repositories and checks to export
+type ScorecardTargetFilters struct {
+	// Visibility selects repositories by visibility
+	// +kubebuilder:validation:Enum=public;private;all
+	// +optional
+	Visibility string `json:"visibility,omitempty"`
+
+	// Affiliations list the repositories the token has access to through
+	// these relationships instead of those of the organization, for
+	// providers supporting it
+	// +kubebuilder:validation:items:Enum=owner;collaborator;organization_member
+	// +optional
+	Affiliations []string `json:"affiliations,omitempty"`
+
+	// Team lists the repositories of the team of the organization with this
+	// slug instead of those of the whole organization, for GitHub only
+	// +optional
+	Team string `json:"team,omitempty"`
+
+	// SearchQuery lists the repositories of the organization matching this
+	// search query instead of all of them, for providers supporting search
+	// +optional
+	SearchQuery string `json:"searchQuery,omitempty"`
+
+	// IncludeSubgroups lists the repositories of nested groups as well, for
+	// providers supporting them
+	// +optional
+	IncludeSubgroups bool `json:"includeSubgroups,omitempty"`
+
+	// MaxRepositories caps the repositories processed per reconcile, zero
+	// meaning no limit. The manager default applies if unset.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxRepositories *int32 `json:"maxRepositories,omitempty"`
+
+	// MaxRepoAgeDays leaves out repositories that have not been pushed to
+	// for longer than this many days, zero meaning no limit
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxRepoAgeDays int32 `json:"maxRepoAgeDays,omitempty"`
+
+	// Checks restricts the exported checks, all checks if empty
+	// +optional
+	Checks []string `json:"checks,omitempty"`
+}
+
+// GitHubAppReference holds the credentials of a GitHub App installation
+type GitHubAppReference struct {
+	// AppID is the ID of the GitHub App
+	// +kubebuilder:validation:Minimum=1
+	AppID int64 `json:"appID"`
+
+	// InstallationID is the ID of the installation of the App
+	// +kubebuilder:validation:Minimum=1
+	InstallationID int64 `json:"installationID"`
+
+	// PrivateKeySecretRef references the Secret holding the private key of
+	// the App, under the "private-key" key if unset
+	PrivateKeySecretRef SecretKeyReference `json:"privateKeySecretRef"`
+}
+
+// SecretKeyReference references a key of a Secret in the namespace of the
+// ScorecardTarget
+type SecretKeyReference struct {
+	// Name is the name of the Secret
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Key is the key holding the value, "token" for tokens if unset
+	// +optional
+	Key string `json:"key,omitempty"`
+}
+
+// ScorecardTargetStatus is the outcome of the last reconcile
+type ScorecardTargetStatus struct {
+	// ObservedGeneration is the generation of the spec last reconciled
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// LastReconcileTime is the time of the last successful reconcile
+	// +optional
+	LastReconcileTime *metav1.Time `json:"lastReconcileTime,omitempty"`
+
+	// Repositories is the number of repositories exported by the last
+	// successful reconcile
+	// +optional
+	Repositories int `json:"repositories,omitempty"`
+
+	// LastError is the error of the last failed reconcile, cleared once a
+	// reconcile succeeds
+	// +optional
+	LastError string `json:"lastError,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Organization",type=string,JSONPath=`.spec.organization`
+// +kubebuilder:printcolumn:name="Provider",type=string,JSONPath=`.spec.providerType`
+// +kubebuilder:printcolumn:name="Repositories",type=integer,JSONPath=`.status.repositories`
+// +kubebuilder:printcolumn:name="Last Reconcile",type=date,JSONPath=`.status.lastReconcileTime`
+
+// ScorecardTarget exports OpenSSF Scorecard metrics for the repositories of
+// an organization
+type ScorecardTarget struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ScorecardTargetSpec   `json:"spec,omitempty"`
+	Status ScorecardTargetStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ScorecardTargetList contains a list of ScorecardTarget
+type ScorecardTargetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ScorecardTarget `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ScorecardTarget{}, &ScorecardTargetList{})
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/giantswarm/openssf-scorecard-exporter/internal/httpclient"
+	"github.com/giantswarm/openssf-scorecard-exporter/internal/scorecard"
+)
+
+// fetchCommand is the name of the subcommand printing the scorecard of a
+// single repository
+const fetchCommand = "fetch"
+
+// fetchOutput is the JSON representation of the scorecard printed by the
+// fetch subcommand
+type fetchOutput struct {
+	Repository string             `json:"repository"`
+	Commit     string             `json:"commit,omitempty"`
+	Date       time.Time          `json:"date"`
+	Score      float64            `json:"score"`
+	Checks     []fetchCheckOutput `json:"checks"`
+}
+
+// fetchCheckOutput is the JSON representation of a check printed by the
+// fetch subcommand
+type fetchCheckOutput struct {
+	Name   string `json:"name"`
+	Score  int    `json:"score"`
+	Status string `json:"status"`
+	Reason string `json:"reason,omitempty"`
+
+	DocumentationURL string `json:"documentation_url,omitempty"`
+
+	Details []fetchDetailOutput `json:"details,omitempty"`
+}
+
+// fetchDetailOutput is the JSON representation of a check detail printed by
+// the fetch subcommand
+type fetchDetailOutput struct {
+	Level   string `json:"level,omitempty"`
+	Message string `json:"message"`
+}
+
+// runFetch fetches the scorecard of the repository given as argument, such as
+// github.com/org/repo, and prints it to stdout. It returns the exit code.
+func runFetch(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet(fetchCommand, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: %s %s [flags] <host/organization/repository>\n\n", os.Args[0], fetchCommand)
+		fs.PrintDefaults()
+	}
+	token := fs.String("token", os.Getenv("GITHUB_TOKEN"),
+		"VCS token forwarded to the scorecard API. Defaults to the GITHUB_TOKEN environment variable.")
+	commit := fs.String("commit", "", "Fetch the scorecard of this commit instead of the latest one.")
+	apiEndpoint := fs.String("api-endpoint", scorecard.DefaultAPIEndpoint, "The OpenSSF Scorecard API endpoint.")
+	timeout := fs.Duration("timeout", 30*time.Second, "Timeout of the scorecard API request.")
+	output := fs.String("output", "table", "Output format: table or json.")
+	details := fs.Bool("details", false, "Include the findings behind check scores in json output.")
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return 2
+	}
+	if *output != "table" && *output != "json" {
+		fmt.Fprintf(stderr, "invalid output format %q, must be table or json\n", *output)
+		return 2
+	}
+
+	client := scorecard.NewClient(
+		scorecard.WithAPIEndpoint(*apiEndpoint),
+		scorecard.WithTimeout(*timeout),
+		scorecard.WithTransport(httpclient.NewUserAgentTransport(http.DefaultTransport, httpclient.DefaultUserAgent())),
+		scorecard.WithDetails(*details),
+	)
+	var opts []scorecard.FetchOption
+	if *commit != "" {
+		opts = append(opts, scorecard.AtCommit(*commit))
+	}
+	data, err := client.GetScorecardData(context.Background(), fs.Arg(0), *token, opts...)
+	if err != nil {
+		fmt.Fprintf(stderr, "failed to fetch scorecard for %s: %v\n", fs.Arg(0), err)
+		return 1
+	}
+
+	if *output == "json" {
+		err = printScorecardJSON(stdout, data)
+	} else {
+		err = printScorecardTable(stdout, data)
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "failed to print scorecard: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// printScorecardJSON prints scorecard data as indented JSON
+func printScorecardJSON(w io.Writer, data *scorecard.ScorecardData) error {
+	out := fetchOutput{
+		Repository: data.Repository,
+		Commit:     data.Commit,
+		Date:       data.Timestamp,
+		Score:      data.Score,
+		Checks:     make([]fetchCheckOutput, 0, len(data.Checks)),
+	}
+	for _, check := range data.Checks {
+		checkOut := fetchCheckOutput{
+			Name:   check.Name,
+			Score:  check.Score,
+			Status: check.Status,
+			Reason: check.Reason,
+
+			DocumentationURL: check.DocumentationURL,
+		}
+		for _, detail := range check.Details {
+			checkOut.Details = append(checkOut.Details, fetchDetailOutput(detail))
+		}
+		out.Checks = append(out.Checks, checkOut)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(out)
+}
+
+// printScorecardTable prints scorecard data as a summary followed by a table
+// of the checks
+func printScorecardTable(w io.Writer, data *scorecard.ScorecardData) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "Repository:\t%s\n", data.Repository)
+	if data.Commit != "" {
+		fmt.Fprintf(tw, "Commit:\t%s\n", data.Commit)
+	}
+	fmt.Fprintf(tw, "Date:\t%s\n", data.Timestamp.Format(time.RFC3339))
+	fmt.Fprintf(tw, "Score:\t%.1f\n", data.Score)
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(w)
+	tw = tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "CHECK\tSCORE\tSTATUS\tREASON")
+	for _, check := range data.Checks {
+		fmt.Fprintf(tw, "%s\t%d\t%s\t%s\n", check.Name, check.Score, check.Status, check.Reason)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// newScorecardServer starts a scorecard API stub serving a report for
+// github.com/org/repo and 404 for anything else
+func newScorecardServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/projects/github.com/org/repo" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(`{
+			"date": "2025-01-01T00:00:00Z",
+			"repo": {"name": "github.com/org/repo", "commit": "abc123"},
+			"score": 7.5,
+			"checks": [
+				{"name": "Code-Review", "score": 8, "reason": "reviewed"},
+				{"name": "Fuzzing", "score": 0, "reason": "not fuzzed", "details": ["Warn: no fuzzer integrations found"]}
+			]
+		}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestRunFetch_Table(t *testing.T) {
+	server := newScorecardServer(t)
+
+	var stdout, stderr bytes.Buffer
+	if code := runFetch([]string{"--api-endpoint", server.URL, "github.com/org/repo"}, &stdout, &stderr); code != 0 {
+		t.Fatalf("runFetch() = %d, want 0 (stderr: %s)", code, stderr.String())
+	}
+
+	expected := `Repository:  github.com/org/repo
+Commit:      abc123
+Date:        2025-01-01T00:00:00Z
+Score:       7.5
+
+CHECK        SCORE  STATUS  REASON
+Code-Review  8      Pass    reviewed
+Fuzzing      0      Fail    not fuzzed
+`
+	if stdout.String() != expected {
+		t.Errorf("runFetch() output =\n%s\nwant\n%s", stdout.String(), expected)
+	}
+}
+
+func TestRunFetch_JSON(t *testing.T) {
+	server := newScorecardServer(t)
+
+	var stdout, stderr bytes.Buffer
+	args := []string{"--api-endpoint", server.URL, "--output", "json", "github.com/org/repo"}
+	if code := runFetch(args, &stdout, &stderr); code != 0 {
+		t.Fatalf("runFetch() = %d, want 0 (stderr: %s)", code, stderr.String())
+	}
+
+	var out fetchOutput
+	if err := json.Unmarshal(stdout.Bytes(), &out); err != nil {
+		t.Fatalf("runFetch() printed invalid JSON: %v", err)
+	}
+	if out.Repository != "github.com/org/repo" || out.Commit != "abc123" || out.Score != 7.5 {
+		t.Errorf("runFetch() = %+v, unexpected scorecard", out)
+	}
+	expected := fetchCheckOutput{Name: "Fuzzing", Score: 0, Status: "Fail", Reason: "not fuzzed"}
+	if len(out.Checks) != 2 || !reflect.DeepEqual(out.Checks[1], expected) {
+		t.Errorf("runFetch() checks = %+v, unexpected checks", out.Checks)
+	}
+}
+
+func TestRunFetch_Details(t *testing.T) {
+	server := newScorecardServer(t)
+
+	var stdout, stderr bytes.Buffer
+	args := []string{"--api-endpoint", server.URL, "--output", "json", "--details", "github.com/org/repo"}
+	if code := runFetch(args, &stdout, &stderr); code != 0 {
+		t.Fatalf("runFetch() = %d, want 0 (stderr: %s)", code, stderr.String())
+	}
+
+	var out fetchOutput
+	if err := json.Unmarshal(stdout.Bytes(), &out); err != nil {
+		t.Fatalf("runFetch() printed invalid JSON: %v", err)
+	}
+	expected := []fetchDetailOutput{{Level: "Warn", Message: "no fuzzer integrations found"}}
+	if len(out.Checks) != 2 || !reflect.DeepEqual(out.Checks[1].Details, expected) {
+		t.Errorf("runFetch() checks = %+v, want details %+v", out.Checks, expected)
+	}
+}
+
+func TestRunFetch_Errors(t *testing.T) {
+	server := newScorecardServer(t)
+
+	tests := []struct {
+		name         string
+		args         []string
+		expectedCode int
+		expectedErr  string
+	}{
+		{
+			name:         "missing repository",
+			args:         []string{"--api-endpoint", server.URL},
+			expectedCode: 2,
+			expectedErr:  "Usage:",
+		},
+		{
+			name:         "invalid output format",
+			args:         []string{"--api-endpoint", server.URL, "--output", "yaml", "github.com/org/repo"},
+			expectedCode: 2,
+			expectedErr:  `invalid output format "yaml"`,
+		},
+		{
+			name:         "repository without scorecard",
+			args:         []string{"--api-endpoint", server.URL, "github.com/org/missing"},
+			expectedCode: 1,
+			expectedErr:  "failed to fetch scorecard for github.com/org/missing",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if code := runFetch(tt.args, &stdout, &stderr); code != tt.expectedCode {
+				t.Errorf("runFetch() = %d, want %d", code, tt.expectedCode)
+			}
+			if !strings.Contains(stderr.String(), tt.expectedErr) {
+				t.Errorf("runFetch() stderr = %q, want it to contain %q", stderr.String(), tt.expectedErr)
+			}
+			if stdout.Len() != 0 {
+				t.Errorf("runFetch() stdout = %q, want empty", stdout.String())
+			}
+		})
+	}
+}
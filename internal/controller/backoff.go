@@ -0,0 +1,93 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// transientBackoffBase is the requeue delay after the first transient VCS failure
+	transientBackoffBase = 30 * time.Second
+
+	// transientBackoffMax caps the requeue delay after repeated transient VCS failures
+	transientBackoffMax = 10 * time.Minute
+
+	// secondaryRateLimitBackoffBase is the requeue delay after the first
+	// secondary rate limit, unless the API asks to wait longer
+	secondaryRateLimitBackoffBase = 10 * time.Minute
+
+	// secondaryRateLimitBackoffMax caps the requeue delay after repeated
+	// secondary rate limits
+	secondaryRateLimitBackoffMax = 2 * time.Hour
+
+	// authFailureRequeueDelay is the requeue delay after the VCS API rejected
+	// the credentials, which rarely fixes itself quickly. Changes to the
+	// referenced Secrets trigger a reconcile regardless.
+	authFailureRequeueDelay = 30 * time.Minute
+)
+
+// failureBackoff tracks consecutive failures per ConfigMap, so that an
+// unavailable VCS API, or one that keeps rate limiting, is retried with
+// exponentially growing delays instead of controller-runtime's fast error
+// requeues. It is safe for concurrent use.
+type failureBackoff struct {
+	mu sync.Mutex
+
+	// failures counts consecutive transient failures per ConfigMap
+	failures map[types.NamespacedName]int
+}
+
+// next records a transient failure for a ConfigMap and returns the delay
+// before it should be retried
+func (b *failureBackoff) next(configMap types.NamespacedName) time.Duration {
+	return b.nextAfter(configMap, transientBackoffBase, transientBackoffMax)
+}
+
+// nextAfter records a failure for a ConfigMap and returns the delay before it
+// should be retried, starting at base and doubling with every consecutive
+// failure up to limit
+func (b *failureBackoff) nextAfter(configMap types.NamespacedName, base, limit time.Duration) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.failures == nil {
+		b.failures = make(map[types.NamespacedName]int)
+	}
+	attempt := b.failures[configMap]
+	b.failures[configMap] = attempt + 1
+
+	delay := base
+	for range attempt {
+		delay *= 2
+		if delay >= limit {
+			return limit
+		}
+	}
+	return delay
+}
+
+// reset forgets the failures recorded for a ConfigMap
+func (b *failureBackoff) reset(configMap types.NamespacedName) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	delete(b.failures, configMap)
+}
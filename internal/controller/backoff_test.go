@@ -0,0 +1,74 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestFailureBackoff(t *testing.T) {
+	var b failureBackoff
+	key := types.NamespacedName{Namespace: "default", Name: "config"}
+	other := types.NamespacedName{Namespace: "default", Name: "other"}
+
+	expected := []time.Duration{
+		30 * time.Second,
+		time.Minute,
+		2 * time.Minute,
+		4 * time.Minute,
+		8 * time.Minute,
+		10 * time.Minute,
+		10 * time.Minute,
+	}
+	for i, want := range expected {
+		if got := b.next(key); got != want {
+			t.Errorf("next() attempt %d = %v, want %v", i+1, got, want)
+		}
+	}
+
+	// Failures are tracked per ConfigMap
+	if got := b.next(other); got != transientBackoffBase {
+		t.Errorf("next() for other ConfigMap = %v, want %v", got, transientBackoffBase)
+	}
+
+	b.reset(key)
+	if got := b.next(key); got != transientBackoffBase {
+		t.Errorf("next() after reset = %v, want %v", got, transientBackoffBase)
+	}
+}
+
+func TestFailureBackoff_NextAfter(t *testing.T) {
+	var b failureBackoff
+	key := types.NamespacedName{Namespace: "default", Name: "config"}
+
+	expected := []time.Duration{
+		secondaryRateLimitBackoffBase,
+		20 * time.Minute,
+		40 * time.Minute,
+		80 * time.Minute,
+		secondaryRateLimitBackoffMax,
+		secondaryRateLimitBackoffMax,
+	}
+	for i, want := range expected {
+		if got := b.nextAfter(key, secondaryRateLimitBackoffBase, secondaryRateLimitBackoffMax); got != want {
+			t.Errorf("nextAfter() attempt %d = %v, want %v", i+1, got, want)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"slices"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/giantswarm/openssf-scorecard-exporter/internal/scorecard"
+)
+
+// fetchScorecardBatch fetches the scorecard data of repos, the repositories of
+// a VCS instance left in the cycle, in batches of ScorecardBatchSize, keyed by VCS path. It returns nil
+// when batches are disabled, when the source does not serve batches, when
+// each repository reports on its own commit of a branch, or when a batch
+// fails, leaving the repositories to be fetched one by one, each within
+// ScorecardFetchTimeout. With refresh, cached reports are fetched again.
+func (r *ConfigMapReconciler) fetchScorecardBatch(
+	ctx context.Context, source scorecard.Source, instance vcsInstance, repos []string,
+	organization, token string, refresh bool,
+) map[string]*scorecard.ScorecardData {
+	batchSource, ok := source.(scorecard.BatchSource)
+	if !ok || r.ScorecardBatchSize <= 0 || instance.ref.branch != "" || len(repos) == 0 {
+		return nil
+	}
+	logger := log.FromContext(ctx)
+
+	// Without a branch, the fetch options are the same for every repository
+	fetchOpts, err := instance.ref.fetchOptions(ctx, organization, "")
+	if err != nil {
+		return nil
+	}
+	if refresh {
+		fetchOpts = append(fetchOpts, scorecard.Refresh())
+	}
+
+	vcsPaths := make([]string, 0, len(repos))
+	for _, repo := range repos {
+		vcsPaths = append(vcsPaths, instance.provider.GetScorecardURL(organization, repo))
+	}
+
+	results := make(map[string]*scorecard.ScorecardData, len(vcsPaths))
+	for chunk := range slices.Chunk(vcsPaths, r.ScorecardBatchSize) {
+		fetchCtx, cancel := withTimeout(ctx, r.ScorecardFetchTimeout)
+		batch, err := batchSource.GetScorecardDataBatch(fetchCtx, chunk, token, fetchOpts...)
+		cancel()
+		if err != nil {
+			logger.Info("Failed to fetch scorecard data in batches, fetching repositories one by one",
+				"organization", organization, "error", err.Error())
+			return nil
+		}
+		for vcsPath, data := range batch {
+			results[vcsPath] = data
+		}
+	}
+	return results
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/giantswarm/openssf-scorecard-exporter/internal/metrics"
+	"github.com/giantswarm/openssf-scorecard-exporter/internal/scorecard"
+)
+
+// fakeBatchSource is a fakeSource that also serves batches, recording them
+type fakeBatchSource struct {
+	fakeSource
+	batches []string
+	err     error
+}
+
+func (s *fakeBatchSource) GetScorecardDataBatch(
+	ctx context.Context, vcsPaths []string, token string, opts ...scorecard.FetchOption,
+) (map[string]*scorecard.ScorecardData, error) {
+	s.batches = append(s.batches, strings.Join(vcsPaths, ","))
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	// Serve the data of fakeSource without recording single requests
+	var data fakeSource
+	results := map[string]*scorecard.ScorecardData{}
+	for _, vcsPath := range vcsPaths {
+		if result, err := data.GetScorecardData(ctx, vcsPath, token, opts...); err == nil {
+			results[vcsPath] = result
+		}
+	}
+	return results, nil
+}
+
+func TestReconcileScorecardBatch(t *testing.T) {
+	tests := []struct {
+		name            string
+		batchSize       int
+		unbatched       bool
+		err             error
+		expectedBatches []string
+		expectedSingles []string
+	}{
+		{
+			name:            "batches disabled",
+			expectedSingles: []string{"github.com/org/repo|", "github.com/org/missing|"},
+		},
+		{
+			name:            "one batch",
+			batchSize:       10,
+			expectedBatches: []string{"github.com/org/repo,github.com/org/missing"},
+		},
+		{
+			name:            "several batches",
+			batchSize:       1,
+			expectedBatches: []string{"github.com/org/repo", "github.com/org/missing"},
+		},
+		{
+			name:            "source without batches",
+			batchSize:       10,
+			unbatched:       true,
+			expectedSingles: []string{"github.com/org/repo|", "github.com/org/missing|"},
+		},
+		{
+			name:            "failed batch falls back to single requests",
+			batchSize:       10,
+			err:             errors.New("internal error"),
+			expectedBatches: []string{"github.com/org/repo,github.com/org/missing"},
+			expectedSingles: []string{"github.com/org/repo|", "github.com/org/missing|"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestReconciler(t, &fakeProvider{repos: []string{"repo", "missing"}}, newTestConfigMap(nil))
+			source := &fakeBatchSource{err: tt.err}
+			r.ScorecardSource = source
+			if tt.unbatched {
+				r.ScorecardSource = &source.fakeSource
+			}
+			r.ScorecardBatchSize = tt.batchSize
+			registry := prometheus.NewRegistry()
+			r.MetricsCollector = metrics.NewCollector(metrics.WithRegistry(registry))
+
+			if _, err := r.Reconcile(context.Background(), testRequest); err != nil {
+				t.Fatalf("Reconcile() unexpected error: %v", err)
+			}
+
+			if !slices.Equal(source.batches, tt.expectedBatches) {
+				t.Errorf("batches = %v, want %v", source.batches, tt.expectedBatches)
+			}
+			if !slices.Equal(source.requests, tt.expectedSingles) {
+				t.Errorf("single requests = %v, want %v", source.requests, tt.expectedSingles)
+			}
+
+			scores := gaugeValues(t, registry, "openssf_scorecard_overall_score", "repository")
+			if scores["repo"] != 7.5 || scores["missing"] != -1 {
+				t.Errorf("overall scores = %v, want 7.5 for repo and -1 for missing", scores)
+			}
+		})
+	}
+}
+
+func TestReconcileScorecardBatch_Remaining(t *testing.T) {
+	r := newTestReconciler(t, &fakeProvider{repos: []string{"repo", "missing", "other"}}, newTestConfigMap(nil))
+	source := &fakeBatchSource{}
+	r.ScorecardSource = source
+	r.ScorecardBatchSize = 10
+	r.ReconcileBudget = time.Nanosecond
+
+	// Each reconcile exports a single repository, and only asks for the
+	// repositories left in the cycle
+	for range 3 {
+		if _, err := r.Reconcile(context.Background(), testRequest); err != nil {
+			t.Fatalf("Reconcile() unexpected error: %v", err)
+		}
+	}
+
+	expected := []string{
+		"github.com/org/repo,github.com/org/missing,github.com/org/other",
+		"github.com/org/missing,github.com/org/other",
+		"github.com/org/other",
+	}
+	if !slices.Equal(source.batches, expected) {
+		t.Errorf("batches = %v, want %v", source.batches, expected)
+	}
+}
+
+func TestNewEndpointSource(t *testing.T) {
+	if _, ok := newEndpointSource(&fakeSource{}, "https://scorecard.example.com").(scorecard.BatchSource); ok {
+		t.Error("newEndpointSource() of a source without batches serves batches")
+	}
+	if _, ok := newEndpointSource(&fakeBatchSource{}, "https://scorecard.example.com").(scorecard.BatchSource); !ok {
+		t.Error("newEndpointSource() of a batch source does not serve batches")
+	}
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// partialReconcileRequeueDelay is the requeue delay after a reconcile ran out
+// of ReconcileBudget, continuing with the remaining repositories soon without
+// starving the other configs
+const partialReconcileRequeueDelay = 30 * time.Second
+
+// instanceRepository is a repository of the VCS instance at an index of the
+// instances of a config
+type instanceRepository struct {
+	instance int
+	repo     string
+}
+
+// instanceRepositories lists the repositories of all instances in instance
+// order
+func instanceRepositories(instances []vcsInstance) []instanceRepository {
+	var repositories []instanceRepository
+	for i, instance := range instances {
+		for _, repo := range instance.repos {
+			repositories = append(repositories, instanceRepository{instance: i, repo: repo})
+		}
+	}
+	return repositories
+}
+
+// reconcileCycle is a pass over all repositories of a config, which
+// reconciles cut short by ReconcileBudget spread over several reconciles
+type reconcileCycle struct {
+	// offset is the number of repositories exported so far
+	offset int
+
+	// withData and unavailable count the exported repositories with and
+	// without scorecard data
+	withData    int
+	unavailable int
+
+	// scores are the overall scores of the exported repositories
+	scores []float64
+}
+
+// remaining returns the repositories the cycle has yet to export. The
+// repositories may have changed since the cycle started, so resuming is
+// approximate, and a cycle past the end of the repositories starts over.
+func (c *reconcileCycle) remaining(repositories []instanceRepository) []instanceRepository {
+	if c.offset >= len(repositories) {
+		*c = reconcileCycle{}
+	}
+	return repositories[c.offset:]
+}
+
+// reconcileProgress tracks the cycle of each config left unfinished by
+// reconciles that ran out of ReconcileBudget. It is safe for concurrent use.
+type reconcileProgress struct {
+	mu sync.Mutex
+
+	// cycles holds the unfinished cycle per config
+	cycles map[types.NamespacedName]reconcileCycle
+}
+
+// cycle returns the unfinished cycle of a config, an empty one if none
+func (p *reconcileProgress) cycle(config types.NamespacedName) reconcileCycle {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	cycle := p.cycles[config]
+	cycle.scores = slices.Clone(cycle.scores)
+	return cycle
+}
+
+// save records the unfinished cycle of a config for the next reconcile
+func (p *reconcileProgress) save(config types.NamespacedName, cycle reconcileCycle) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.cycles == nil {
+		p.cycles = make(map[types.NamespacedName]reconcileCycle)
+	}
+	p.cycles[config] = cycle
+}
+
+// reset starts the next reconcile of a config with a new cycle
+func (p *reconcileProgress) reset(config types.NamespacedName) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	delete(p.cycles, config)
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"slices"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestInstanceRepositories(t *testing.T) {
+	instances := []vcsInstance{
+		{repos: []string{"a", "b"}},
+		{repos: []string{"c"}},
+	}
+
+	expected := []instanceRepository{{0, "a"}, {0, "b"}, {1, "c"}}
+	if got := instanceRepositories(instances); !slices.Equal(got, expected) {
+		t.Errorf("instanceRepositories() = %v, want %v", got, expected)
+	}
+
+	if got := instanceRepositories(nil); len(got) != 0 {
+		t.Errorf("instanceRepositories() without repositories = %v, want none", got)
+	}
+}
+
+func TestReconcileCycleRemaining(t *testing.T) {
+	repositories := []instanceRepository{{0, "a"}, {0, "b"}, {1, "c"}}
+
+	tests := []struct {
+		name     string
+		cycle    reconcileCycle
+		expected []instanceRepository
+		restart  bool
+	}{
+		{
+			name:     "new cycle",
+			expected: repositories,
+		},
+		{
+			name:     "continued across instances",
+			cycle:    reconcileCycle{offset: 2, withData: 1, unavailable: 1, scores: []float64{5}},
+			expected: []instanceRepository{{1, "c"}},
+		},
+		{
+			name:     "offset past the repositories starts over",
+			cycle:    reconcileCycle{offset: 4, withData: 4, scores: []float64{5, 6, 7, 8}},
+			expected: repositories,
+			restart:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cycle := tt.cycle
+			if got := cycle.remaining(repositories); !slices.Equal(got, tt.expected) {
+				t.Errorf("remaining() = %v, want %v", got, tt.expected)
+			}
+			if restarted := cycle.offset == 0 && cycle.withData == 0 && len(cycle.scores) == 0; tt.restart && !restarted {
+				t.Errorf("cycle after remaining() = %+v, want a new cycle", cycle)
+			}
+		})
+	}
+}
+
+func TestReconcileProgress(t *testing.T) {
+	var p reconcileProgress
+	config := types.NamespacedName{Namespace: "default", Name: "config"}
+	other := types.NamespacedName{Namespace: "default", Name: "other"}
+
+	if cycle := p.cycle(config); cycle.offset != 0 || cycle.scores != nil {
+		t.Fatalf("cycle() = %+v before any progress, want an empty cycle", cycle)
+	}
+
+	p.save(config, reconcileCycle{offset: 2, withData: 1, scores: []float64{7.5}})
+	p.save(other, reconcileCycle{offset: 1})
+	cycle := p.cycle(config)
+	if cycle.offset != 2 || cycle.withData != 1 || !slices.Equal(cycle.scores, []float64{7.5}) {
+		t.Errorf("cycle() = %+v, want the saved cycle", cycle)
+	}
+
+	// Appending to a returned cycle leaves the saved one untouched
+	cycle.scores = append(cycle.scores[:0], 1)
+	if saved := p.cycle(config); saved.scores[0] != 7.5 {
+		t.Errorf("saved scores = %v after modifying a returned cycle, want [7.5]", saved.scores)
+	}
+
+	p.reset(config)
+	if cycle := p.cycle(config); cycle.offset != 0 {
+		t.Errorf("cycle().offset = %d after reset, want 0", cycle.offset)
+	}
+	if cycle := p.cycle(other); cycle.offset != 1 {
+		t.Errorf("cycle().offset of another config = %d, want 1", cycle.offset)
+	}
+}
@@ -0,0 +1,192 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/go-logr/logr"
+
+	"github.com/giantswarm/openssf-scorecard-exporter/internal/scorecard"
+)
+
+// checkFilter selects the scorecard checks exported for a ConfigMap. A nil
+// filter exports all checks. Filtering copies the scorecard data rather than
+// modifying it, as sources may share it between callers.
+type checkFilter map[string]bool
+
+// parseChecks parses the comma-separated allowlist of check names of a
+// ConfigMap. Names are matched case-insensitively. An empty value allows all
+// checks.
+func parseChecks(value string) checkFilter {
+	return newCheckFilter(strings.Split(value, ","))
+}
+
+// newCheckFilter returns the filter of the given check names, nil if there
+// are none
+func newCheckFilter(names []string) checkFilter {
+	var filter checkFilter
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if filter == nil {
+			filter = checkFilter{}
+		}
+		filter[strings.ToLower(name)] = true
+	}
+	return filter
+}
+
+// apply returns scorecard data with only the allowed checks
+func (f checkFilter) apply(data *scorecard.ScorecardData) *scorecard.ScorecardData {
+	if f == nil {
+		return data
+	}
+
+	filtered := *data
+	filtered.Checks = make([]scorecard.Check, 0, len(f))
+	for _, check := range data.Checks {
+		if f[strings.ToLower(check.Name)] {
+			filtered.Checks = append(filtered.Checks, check)
+		}
+	}
+	return &filtered
+}
+
+// exclude returns scorecard data without the checks of the filter, the
+// opposite of apply
+func (f checkFilter) exclude(data *scorecard.ScorecardData) *scorecard.ScorecardData {
+	if f == nil {
+		return data
+	}
+
+	filtered := *data
+	filtered.Checks = make([]scorecard.Check, 0, len(data.Checks))
+	for _, check := range data.Checks {
+		if !f[strings.ToLower(check.Name)] {
+			filtered.Checks = append(filtered.Checks, check)
+		}
+	}
+	return &filtered
+}
+
+// withPassThreshold returns a copy of scorecard data with the status of each
+// check derived from passThreshold instead of scorecard.DefaultPassThreshold
+func withPassThreshold(data *scorecard.ScorecardData, passThreshold int) *scorecard.ScorecardData {
+	if passThreshold == scorecard.DefaultPassThreshold {
+		return data
+	}
+
+	classified := *data
+	classified.Checks = make([]scorecard.Check, len(data.Checks))
+	for i, check := range data.Checks {
+		check.Status = scorecard.CheckStatus(check.Score, passThreshold)
+		classified.Checks[i] = check
+	}
+	return &classified
+}
+
+// logFailingChecks logs the findings behind the checks below the pass
+// threshold at verbose level to aid remediation. The findings are only
+// logged so that they do not add to the cardinality of the metrics, and are
+// only available with scorecard details.
+func logFailingChecks(logger logr.Logger, organization, repository string, data *scorecard.ScorecardData) {
+	logger = logger.V(1)
+	if !logger.Enabled() {
+		return
+	}
+
+	for _, check := range data.Checks {
+		if check.Status != scorecard.CheckStatusFail || len(check.Details) == 0 {
+			continue
+		}
+
+		details := make([]string, 0, len(check.Details))
+		for _, detail := range check.Details {
+			if detail.Level == "" {
+				details = append(details, detail.Message)
+				continue
+			}
+			details = append(details, detail.Level+": "+detail.Message)
+		}
+		logger.Info("Failing scorecard check",
+			"organization", organization,
+			"repository", repository,
+			"check", check.Name,
+			"score", check.Score,
+			"reason", check.Reason,
+			"details", details)
+	}
+}
+
+// checkWeights weighs the scorecard checks of a custom overall score, keyed by
+// lowercased check name. A nil map computes no custom score.
+type checkWeights map[string]float64
+
+// parseCheckWeights parses the comma-separated name=weight pairs of a
+// ConfigMap, e.g. "Code-Review=10,Branch-Protection=7.5". Names are matched
+// case-insensitively and weights must be positive. An empty value computes no
+// custom score.
+func parseCheckWeights(value string) (checkWeights, error) {
+	var weights checkWeights
+	for pair := range strings.SplitSeq(value, ",") {
+		if pair = strings.TrimSpace(pair); pair == "" {
+			continue
+		}
+
+		name, weightValue, ok := strings.Cut(pair, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("%w: invalid %s entry %q, expected check=weight", errInvalidConfig, CheckWeightsKey, pair)
+		}
+		weight, err := strconv.ParseFloat(strings.TrimSpace(weightValue), 64)
+		if err != nil || weight <= 0 || math.IsInf(weight, 0) {
+			return nil, fmt.Errorf("%w: invalid weight %q of check %s, must be a positive number",
+				errInvalidConfig, weightValue, name)
+		}
+
+		if weights == nil {
+			weights = checkWeights{}
+		}
+		weights[strings.ToLower(name)] = weight
+	}
+	return weights, nil
+}
+
+// score returns the mean of the scores of the weighted checks, weighted by
+// their weights. Checks without a score, reported as -1, are left out, and
+// false is returned when none of the weighted checks has a score.
+func (w checkWeights) score(checks []scorecard.Check) (float64, bool) {
+	var sum, total float64
+	for _, check := range checks {
+		weight, ok := w[strings.ToLower(check.Name)]
+		if !ok || check.Score < 0 {
+			continue
+		}
+		sum += weight * float64(check.Score)
+		total += weight
+	}
+	if total == 0 {
+		return 0, false
+	}
+	return sum / total, true
+}
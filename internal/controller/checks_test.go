@@ -0,0 +1,315 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"errors"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr/funcr"
+
+	"github.com/giantswarm/openssf-scorecard-exporter/internal/scorecard"
+)
+
+func TestCheckFilter(t *testing.T) {
+	data := &scorecard.ScorecardData{
+		Score: 7,
+		Checks: []scorecard.Check{
+			{Name: "Branch-Protection", Score: 8},
+			{Name: "Fuzzing", Score: 0},
+			{Name: "Token-Permissions", Score: 10},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		value    string
+		expected []string
+	}{
+		{
+			name:     "all checks by default",
+			expected: []string{"Branch-Protection", "Fuzzing", "Token-Permissions"},
+		},
+		{
+			name:     "allowed checks only",
+			value:    "Branch-Protection, token-permissions,,",
+			expected: []string{"Branch-Protection", "Token-Permissions"},
+		},
+		{
+			name:  "unknown checks ignored",
+			value: "Signed-Releases",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filtered := parseChecks(tt.value).apply(data)
+
+			var names []string
+			for _, check := range filtered.Checks {
+				names = append(names, check.Name)
+			}
+			if !slices.Equal(names, tt.expected) {
+				t.Errorf("filtered checks = %v, want %v", names, tt.expected)
+			}
+			if filtered.Score != data.Score {
+				t.Errorf("filtered score = %v, want %v", filtered.Score, data.Score)
+			}
+			if len(data.Checks) != 3 {
+				t.Errorf("apply() modified the original data: %v", data.Checks)
+			}
+		})
+	}
+}
+
+func TestCheckFilterExclude(t *testing.T) {
+	data := &scorecard.ScorecardData{
+		Score: 7,
+		Checks: []scorecard.Check{
+			{Name: "Branch-Protection", Score: 8},
+			{Name: "CI-Tests", Score: 0},
+			{Name: "Token-Permissions", Score: 10},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		excluded []string
+		expected []string
+	}{
+		{
+			name:     "no exclusions",
+			expected: []string{"Branch-Protection", "CI-Tests", "Token-Permissions"},
+		},
+		{
+			name:     "excluded checks",
+			excluded: []string{" ci-tests", "Token-Permissions", ""},
+			expected: []string{"Branch-Protection"},
+		},
+		{
+			name:     "unknown checks ignored",
+			excluded: []string{"Signed-Releases"},
+			expected: []string{"Branch-Protection", "CI-Tests", "Token-Permissions"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filtered := newCheckFilter(tt.excluded).exclude(data)
+
+			var names []string
+			for _, check := range filtered.Checks {
+				names = append(names, check.Name)
+			}
+			if !slices.Equal(names, tt.expected) {
+				t.Errorf("filtered checks = %v, want %v", names, tt.expected)
+			}
+			if len(data.Checks) != 3 {
+				t.Errorf("exclude() modified the original data: %v", data.Checks)
+			}
+		})
+	}
+}
+
+func TestWithPassThreshold(t *testing.T) {
+	data := &scorecard.ScorecardData{
+		Checks: []scorecard.Check{
+			{Name: "Branch-Protection", Score: 8, Status: "Pass"},
+			{Name: "Fuzzing", Score: 4, Status: "Fail"},
+			{Name: "Token-Permissions", Score: 10, Status: "Pass"},
+			{Name: "Signed-Releases", Score: -1, Status: "Inconclusive"},
+		},
+	}
+
+	tests := []struct {
+		threshold int
+		expected  []string
+	}{
+		{threshold: scorecard.DefaultPassThreshold, expected: []string{"Pass", "Fail", "Pass", "Inconclusive"}},
+		{threshold: 1, expected: []string{"Pass", "Pass", "Pass", "Inconclusive"}},
+		{threshold: 4, expected: []string{"Pass", "Pass", "Pass", "Inconclusive"}},
+		{threshold: 8, expected: []string{"Pass", "Fail", "Pass", "Inconclusive"}},
+		{threshold: 9, expected: []string{"Fail", "Fail", "Pass", "Inconclusive"}},
+		{threshold: 10, expected: []string{"Fail", "Fail", "Pass", "Inconclusive"}},
+	}
+
+	for _, tt := range tests {
+		var statuses []string
+		for _, check := range withPassThreshold(data, tt.threshold).Checks {
+			statuses = append(statuses, check.Status)
+		}
+		if !slices.Equal(statuses, tt.expected) {
+			t.Errorf("withPassThreshold(%d) statuses = %v, want %v", tt.threshold, statuses, tt.expected)
+		}
+	}
+
+	// The shared data is left untouched
+	if data.Checks[0].Status != "Pass" || data.Checks[1].Status != "Fail" {
+		t.Errorf("withPassThreshold() modified its input: %v", data.Checks)
+	}
+}
+
+func TestLogFailingChecks(t *testing.T) {
+	data := &scorecard.ScorecardData{
+		Checks: []scorecard.Check{
+			{
+				Name: "Branch-Protection", Score: 8, Status: "Pass",
+				Details: []scorecard.CheckDetail{{Level: "Warn", Message: "branch protection not enabled for release"}},
+			},
+			{
+				Name: "SAST", Score: 0, Status: "Fail", Reason: "SAST tool is not run on all commits",
+				Details: []scorecard.CheckDetail{
+					{Level: "Warn", Message: "no SAST tool detected"},
+					{Message: "0 commits out of 30 are checked with a SAST tool"},
+				},
+			},
+			{Name: "Fuzzing", Score: 0, Status: "Fail", Reason: "project is not fuzzed"},
+			{
+				Name: "Signed-Releases", Score: -1, Status: "Inconclusive",
+				Details: []scorecard.CheckDetail{{Level: "Debug", Message: "no releases found"}},
+			},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		verbosity int
+		expected  []string
+	}{
+		{name: "default verbosity", verbosity: 0},
+		{
+			name:      "verbose",
+			verbosity: 1,
+			expected: []string{
+				`"msg"="Failing scorecard check" "organization"="org" "repository"="repo" "check"="SAST" "score"=0 ` +
+					`"reason"="SAST tool is not run on all commits" ` +
+					`"details"=["Warn: no SAST tool detected" "0 commits out of 30 are checked with a SAST tool"]`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var lines []string
+			logger := funcr.New(func(prefix, args string) {
+				lines = append(lines, args)
+			}, funcr.Options{Verbosity: tt.verbosity})
+
+			logFailingChecks(logger, "org", "repo", data)
+
+			if len(lines) != len(tt.expected) {
+				t.Fatalf("logFailingChecks() logged %d lines, want %d: %v", len(lines), len(tt.expected), lines)
+			}
+			for i, line := range lines {
+				if !strings.Contains(line, tt.expected[i]) {
+					t.Errorf("logFailingChecks() line %d = %s, want %s", i, line, tt.expected[i])
+				}
+			}
+		})
+	}
+}
+
+func TestParseCheckWeights(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     string
+		expected  checkWeights
+		expectErr bool
+	}{
+		{name: "no custom score by default"},
+		{
+			name:     "weights by lowercased name",
+			value:    "Code-Review=10, Branch-Protection = 7.5,,",
+			expected: checkWeights{"code-review": 10, "branch-protection": 7.5},
+		},
+		{name: "missing weight", value: "Code-Review", expectErr: true},
+		{name: "missing name", value: "=3", expectErr: true},
+		{name: "non-numeric weight", value: "Code-Review=high", expectErr: true},
+		{name: "zero weight", value: "Code-Review=0", expectErr: true},
+		{name: "negative weight", value: "Code-Review=-1", expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			weights, err := parseCheckWeights(tt.value)
+			if (err != nil) != tt.expectErr {
+				t.Fatalf("parseCheckWeights() error = %v, expectErr %v", err, tt.expectErr)
+			}
+			if tt.expectErr {
+				if !errors.Is(err, errInvalidConfig) {
+					t.Errorf("parseCheckWeights() error = %v, want errInvalidConfig", err)
+				}
+				return
+			}
+			if !maps.Equal(weights, tt.expected) {
+				t.Errorf("parseCheckWeights() = %v, want %v", weights, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCheckWeightsScore(t *testing.T) {
+	checks := []scorecard.Check{
+		{Name: "Code-Review", Score: 8},
+		{Name: "Branch-Protection", Score: 4},
+		{Name: "Fuzzing", Score: 0},
+		{Name: "Packaging", Score: -1},
+	}
+
+	tests := []struct {
+		name        string
+		weights     checkWeights
+		expected    float64
+		expectScore bool
+	}{
+		{name: "no weights"},
+		{
+			name:        "weighted mean",
+			weights:     checkWeights{"code-review": 3, "branch-protection": 1},
+			expected:    7,
+			expectScore: true,
+		},
+		{
+			name:        "zero scores count",
+			weights:     checkWeights{"code-review": 1, "fuzzing": 1},
+			expected:    4,
+			expectScore: true,
+		},
+		{
+			name:        "checks without a score and unknown checks left out",
+			weights:     checkWeights{"code-review": 2, "packaging": 5, "signed-releases": 5},
+			expected:    8,
+			expectScore: true,
+		},
+		{
+			name:    "no weighted check with a score",
+			weights: checkWeights{"packaging": 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score, ok := tt.weights.score(checks)
+			if ok != tt.expectScore || score != tt.expected {
+				t.Errorf("score() = %v, %v, want %v, %v", score, ok, tt.expected, tt.expectScore)
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/giantswarm/openssf-scorecard-exporter/internal/scorecard"
+	"github.com/giantswarm/openssf-scorecard-exporter/internal/vcs"
+)
+
+// scorecardConfig holds the settings of a scorecard ConfigMap, with the
+// manager defaults applied
+type scorecardConfig struct {
+	organization     string
+	providerType     vcs.ProviderType
+	ownerType        vcs.OwnerType
+	visibility       vcs.Visibility
+	baseURLs         []string
+	source           scorecard.Source
+	maxRepositories  int
+	maxRepositoryAge time.Duration
+	includeSubgroups bool
+	repositoryInfo   bool
+	graphQL          bool
+	affiliations     []vcs.Affiliation
+	team             string
+	searchQuery      string
+	checks           checkFilter
+	weights          checkWeights
+	unavailableValue UnavailableValue
+	passThreshold    int
+	gitHubApp        gitHubApp
+}
+
+// parseConfig parses the settings of a scorecard ConfigMap, returning all the
+// errors found rather than only the first. The organization itself is not
+// checked, nor are secrets or the VCS read.
+func (r *ConfigMapReconciler) parseConfig(configMap *corev1.ConfigMap) (*scorecardConfig, error) {
+	var errs []error
+	check := func(err error) {
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	config := &scorecardConfig{
+		organization: configMap.Data[OrganizationKey],
+		providerType: vcs.ProviderType(configMap.Data[ProviderTypeKey]),
+		checks:       parseChecks(configMap.Data[ChecksKey]),
+	}
+	if config.providerType == "" {
+		config.providerType = vcs.ProviderTypeGitHub
+	}
+	if supported := r.ProviderFactory.GetSupportedProviders(); !slices.Contains(supported, config.providerType) {
+		check(fmt.Errorf("%w: unsupported %s %q (supported: %v)",
+			errInvalidConfig, ProviderTypeKey, config.providerType, supported))
+	}
+
+	var err error
+	config.ownerType, err = parseOwnerType(configMap)
+	check(err)
+	config.visibility, err = parseVisibility(configMap)
+	check(err)
+	config.baseURLs = parseBaseURLs(configMap.Data[BaseURLKey])
+	for _, baseURL := range config.baseURLs {
+		if err := vcs.ValidateBaseURL(config.providerType, baseURL); err != nil {
+			check(fmt.Errorf("%w: %w", errInvalidConfig, err))
+		}
+	}
+	config.source, err = r.scorecardSource(configMap)
+	check(err)
+	config.maxRepositories, err = r.maxRepositories(configMap)
+	check(err)
+	config.maxRepositoryAge, err = parseMaxRepoAge(configMap)
+	check(err)
+	config.includeSubgroups, err = parseBool(configMap, IncludeSubgroupsKey)
+	check(err)
+	config.repositoryInfo, err = parseBool(configMap, RepositoryInfoKey)
+	check(err)
+
+	config.graphQL, err = parseBool(configMap, GraphQLKey)
+	check(err)
+	config.affiliations, err = parseAffiliations(configMap)
+	if err == nil && config.graphQL && len(config.affiliations) > 0 {
+		err = fmt.Errorf("%w: %s cannot be combined with %s", errInvalidConfig, AffiliationKey, GraphQLKey)
+	}
+	check(err)
+	config.team, err = parseTeam(configMap, config.providerType, config.ownerType, config.graphQL, config.affiliations)
+	check(err)
+	config.searchQuery, err = parseSearchQuery(configMap, config.team, config.graphQL, config.affiliations)
+	check(err)
+
+	config.weights, err = parseCheckWeights(configMap.Data[CheckWeightsKey])
+	check(err)
+	config.unavailableValue, err = r.unavailableValue(configMap)
+	check(err)
+	config.passThreshold, err = r.passThreshold(configMap)
+	check(err)
+
+	config.gitHubApp, err = parseGitHubApp(configMap)
+	check(err)
+	if config.organization != "" {
+		_, err = organizationTokenSecret(configMap)
+		check(err)
+	}
+	if configMap.Data[BranchKey] != "" && configMap.Data[CommitKey] != "" {
+		check(fmt.Errorf("%w: only one of %s and %s may be set", errInvalidConfig, BranchKey, CommitKey))
+	}
+
+	return config, errors.Join(errs...)
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"sync"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// generationKeys are the ConfigMap data keys deciding which repositories a
+// config lists and which series it exports for them. Other settings, such as
+// the token or the pass threshold, only change the values of the series.
+var generationKeys = []string{
+	OrganizationKey, ProviderTypeKey, BaseURLKey, OwnerTypeKey, VisibilityKey, AffiliationKey, TeamKey,
+	SearchQueryKey, IncludeSubgroupsKey, GraphQLKey, MaxRepositoriesKey, MaxRepoAgeDaysKey,
+	ChecksKey, CheckWeightsKey, RepositoryInfoKey, UnavailableValueKey, SourceKey, ScorecardAPIEndpointKey,
+}
+
+// configGenerations tracks the generation of each config, so that the series
+// exported for a config can be reset when its configuration changes and
+// repositories it no longer selects don't keep their last scores. It is safe
+// for concurrent use.
+type configGenerations struct {
+	mu sync.Mutex
+
+	// generations is the generation each config last listed repositories with
+	generations map[types.NamespacedName]string
+}
+
+// changed records the generation a config listed repositories with and returns
+// whether it differs from the one recorded by the previous listing. The first
+// generation recorded for a config is not a change.
+func (g *configGenerations) changed(config types.NamespacedName, generation string) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.generations == nil {
+		g.generations = make(map[types.NamespacedName]string)
+	}
+	previous, ok := g.generations[config]
+	g.generations[config] = generation
+	return ok && previous != generation
+}
+
+// remove forgets the generation of a config
+func (g *configGenerations) remove(config types.NamespacedName) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	delete(g.generations, config)
+}
+
+// configGeneration returns the hash of the values of generationKeys in config
+// data
+func configGeneration(data map[string]string) string {
+	fields := make([]any, 0, len(generationKeys))
+	for _, key := range generationKeys {
+		fields = append(fields, data[key])
+	}
+	return hashFields(fields...)
+}
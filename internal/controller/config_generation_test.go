@@ -0,0 +1,79 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestConfigGenerations(t *testing.T) {
+	var generations configGenerations
+	config := types.NamespacedName{Namespace: "default", Name: "config"}
+	other := types.NamespacedName{Namespace: "default", Name: "other"}
+
+	steps := []struct {
+		name     string
+		config   types.NamespacedName
+		data     map[string]string
+		expected bool
+	}{
+		{name: "first reconcile", config: config, data: map[string]string{"organization": "org"}},
+		{name: "unchanged data", config: config, data: map[string]string{"organization": "org"}},
+		{name: "other config", config: other, data: map[string]string{"organization": "other"}},
+		{
+			name:     "added filter",
+			config:   config,
+			data:     map[string]string{"organization": "org", "visibility": "public"},
+			expected: true,
+		},
+		{
+			name:   "same data in another order",
+			config: config,
+			data:   map[string]string{"visibility": "public", "organization": "org"},
+		},
+		{
+			name:     "changed filter",
+			config:   config,
+			data:     map[string]string{"organization": "org", "visibility": "private"},
+			expected: true,
+		},
+		{
+			name:     "value moved to another key",
+			config:   config,
+			data:     map[string]string{"organization": "org", "team": "private"},
+			expected: true,
+		},
+		{
+			name:   "changed token and pass threshold",
+			config: config,
+			data:   map[string]string{"organization": "org", "team": "private", "tokenSecret": "other", "passThreshold": "5"},
+		},
+	}
+	for _, step := range steps {
+		if got := generations.changed(step.config, configGeneration(step.data)); got != step.expected {
+			t.Errorf("%s: changed() = %v, want %v", step.name, got, step.expected)
+		}
+	}
+
+	// A config created again after its removal starts over
+	generations.remove(config)
+	if generations.changed(config, configGeneration(map[string]string{"organization": "new"})) {
+		t.Error("changed() = true for a removed config, want false")
+	}
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"sync"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// configLocks serializes the reconciles of each config while letting
+// different configs reconcile in parallel. A controller never processes the
+// same request concurrently, but a ConfigMap and a ScorecardTarget of the same
+// name export the same metrics from different controllers. It is safe for
+// concurrent use.
+type configLocks struct {
+	mu sync.Mutex
+
+	// locks holds the lock of each config that is locked or waited for
+	locks map[types.NamespacedName]*configLock
+}
+
+// configLock is the lock of a config, with the number of reconciles holding
+// or waiting for it
+type configLock struct {
+	mu   sync.Mutex
+	refs int
+}
+
+// lock blocks until no other reconcile holds the lock of a config, and
+// returns the function releasing it
+func (l *configLocks) lock(config types.NamespacedName) func() {
+	l.mu.Lock()
+	if l.locks == nil {
+		l.locks = make(map[types.NamespacedName]*configLock)
+	}
+	lock, ok := l.locks[config]
+	if !ok {
+		lock = &configLock{}
+		l.locks[config] = lock
+	}
+	lock.refs++
+	l.mu.Unlock()
+
+	lock.mu.Lock()
+	return func() {
+		lock.mu.Unlock()
+
+		// Forget configs nobody waits for, so that deleted ones do not pile up
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		if lock.refs--; lock.refs == 0 {
+			delete(l.locks, config)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestConfigLocks(t *testing.T) {
+	var locks configLocks
+	a := types.NamespacedName{Namespace: "default", Name: "a"}
+	b := types.NamespacedName{Namespace: "default", Name: "b"}
+
+	unlockA := locks.lock(a)
+
+	// A different config is not blocked
+	locked := make(chan func())
+	go func() { locked <- locks.lock(b) }()
+	select {
+	case unlockB := <-locked:
+		unlockB()
+	case <-time.After(time.Second):
+		t.Fatal("lock(b) blocked while a was locked")
+	}
+
+	// The same config waits until it is unlocked
+	go func() { locked <- locks.lock(a) }()
+	select {
+	case <-locked:
+		t.Fatal("lock(a) acquired while a was locked")
+	case <-time.After(50 * time.Millisecond):
+	}
+	unlockA()
+	select {
+	case unlock := <-locked:
+		unlock()
+	case <-time.After(time.Second):
+		t.Fatal("lock(a) still blocked after unlock")
+	}
+
+	if len(locks.locks) != 0 {
+		t.Errorf("locks = %v, want none once all are released", locks.locks)
+	}
+}
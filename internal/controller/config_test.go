@@ -0,0 +1,65 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/giantswarm/openssf-scorecard-exporter/internal/scorecard"
+	"github.com/giantswarm/openssf-scorecard-exporter/internal/vcs"
+)
+
+func TestParseConfig(t *testing.T) {
+	r := newTestReconciler(t, &fakeProvider{})
+
+	config, err := r.parseConfig(newTestConfigMap(map[string]string{
+		ProviderTypeKey:        "",
+		BaseURLKey:             "default,https://github.mycorp.com",
+		AppIDKey:               "1",
+		InstallationIDKey:      "2",
+		AppPrivateKeySecretKey: "app-key",
+	}))
+	if err != nil {
+		t.Fatalf("parseConfig() unexpected error: %v", err)
+	}
+	if config.providerType != vcs.ProviderTypeGitHub || config.ownerType != vcs.OwnerTypeOrganization ||
+		config.visibility != vcs.VisibilityPublic || config.passThreshold != scorecard.DefaultPassThreshold {
+		t.Errorf("parseConfig() = %+v, want the defaults applied", config)
+	}
+	if expected := []string{"", "https://github.mycorp.com"}; !slices.Equal(config.baseURLs, expected) {
+		t.Errorf("parseConfig() baseURLs = %q, want %q", config.baseURLs, expected)
+	}
+	expectedApp := gitHubApp{appID: 1, installationID: 2, keySecretName: "app-key", keyName: "private-key"}
+	if config.gitHubApp != expectedApp {
+		t.Errorf("parseConfig() gitHubApp = %+v, want %+v", config.gitHubApp, expectedApp)
+	}
+
+	// Every invalid setting is reported, not only the first
+	_, err = r.parseConfig(newTestConfigMap(map[string]string{
+		OwnerTypeKey:      "team",
+		MaxRepoAgeDaysKey: "-1",
+		InstallationIDKey: "2",
+	}))
+	if !errors.Is(err, errInvalidConfig) {
+		t.Fatalf("parseConfig() error = %v, want errInvalidConfig", err)
+	}
+	if errs := err.(interface{ Unwrap() []error }).Unwrap(); len(errs) != 3 {
+		t.Errorf("parseConfig() errors = %v, want 3", errs)
+	}
+}
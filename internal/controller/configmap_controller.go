@@ -18,18 +18,41 @@ package controller
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"maps"
+	"math"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"golang.org/x/time/rate"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	"github.com/giantswarm/openssf-scorecard-exporter/internal/debug"
 	"github.com/giantswarm/openssf-scorecard-exporter/internal/metrics"
+	"github.com/giantswarm/openssf-scorecard-exporter/internal/report"
 	"github.com/giantswarm/openssf-scorecard-exporter/internal/scorecard"
 	"github.com/giantswarm/openssf-scorecard-exporter/internal/utils"
 	"github.com/giantswarm/openssf-scorecard-exporter/internal/vcs"
@@ -39,200 +62,1355 @@ const (
 	// ScorecardLabelKey is the label key that identifies ConfigMaps to reconcile
 	ScorecardLabelKey = "openssf-scorecard.giantswarm.io/enabled"
 
+	// DryRunAnnotation is the ConfigMap annotation that, when "true", limits
+	// reconciliation to discovering repositories without exporting metrics
+	DryRunAnnotation = "openssf-scorecard.giantswarm.io/dry-run"
+
+	// ScanNowAnnotation is the ConfigMap annotation that, when set to a new
+	// value such as a timestamp, reconciles the ConfigMap right away
+	ScanNowAnnotation = "openssf-scorecard.giantswarm.io/scan-now"
+
 	// OrganizationKey is the ConfigMap data key for the organization/group
 	OrganizationKey = "organization"
 
 	// ProviderTypeKey is the ConfigMap data key for the VCS provider type
 	ProviderTypeKey = "providerType"
 
-	// TokenSecretKey is the ConfigMap data key for the VCS token secret reference
+	// OwnerTypeKey is the ConfigMap data key for the kind of account owning
+	// the repositories, "org" or "user"
+	OwnerTypeKey = "ownerType"
+
+	// VisibilityKey is the ConfigMap data key selecting repositories by
+	// visibility: public (default), private or all
+	VisibilityKey = "visibility"
+
+	// TokenSecretKey is the ConfigMap data key for the VCS token secret
+	// reference, either a Secret name or a JSON object mapping organizations
+	// to Secret names, with "*" matching any other organization
 	TokenSecretKey = "tokenSecret"
 
 	// TokenSecretKeyName is the ConfigMap data key for the token secret key name
 	TokenSecretKeyName = "tokenSecretKey"
 
-	// BaseURLKey is the ConfigMap data key for custom VCS API base URL
+	// UsernameKey is the ConfigMap data key for the username sent with the
+	// token as basic auth credentials, for providers that support it
+	UsernameKey = "username"
+
+	// BaseURLKey is the ConfigMap data key for custom VCS API base URLs. It
+	// accepts a comma-separated list to enumerate the organization on several
+	// instances, where DefaultBaseURL refers to the provider's public instance.
 	BaseURLKey = "baseURL"
+
+	// DefaultBaseURL selects the provider's default instance in the base URL list
+	DefaultBaseURL = "default"
+
+	// AppIDKey is the ConfigMap data key for the GitHub App ID
+	AppIDKey = "appID"
+
+	// InstallationIDKey is the ConfigMap data key for the GitHub App installation ID
+	InstallationIDKey = "installationID"
+
+	// AppPrivateKeySecretKey is the ConfigMap data key for the GitHub App private key secret reference
+	AppPrivateKeySecretKey = "appPrivateKeySecret"
+
+	// AppPrivateKeySecretKeyName is the ConfigMap data key for the private key secret key name
+	AppPrivateKeySecretKeyName = "appPrivateKeySecretKey"
+
+	// SourceKey is the ConfigMap data key selecting where scorecard data comes from
+	SourceKey = "source"
+
+	// ScorecardAPIEndpointKey is the ConfigMap data key for the scorecard API
+	// endpoint to fetch data from, the endpoint of the manager if unset
+	ScorecardAPIEndpointKey = "scorecardAPIEndpoint"
+
+	// BranchKey is the ConfigMap data key for the branch to report on
+	BranchKey = "branch"
+
+	// CommitKey is the ConfigMap data key for the commit to report on
+	CommitKey = "commit"
+
+	// MaxRepositoriesKey is the ConfigMap data key for the maximum number of
+	// repositories processed per reconcile
+	MaxRepositoriesKey = "maxRepositories"
+
+	// MaxRepoAgeDaysKey is the ConfigMap data key for the number of days
+	// after their last push that repositories are no longer scanned
+	MaxRepoAgeDaysKey = "maxRepoAgeDays"
+
+	// IncludeSubgroupsKey is the ConfigMap data key that, when "true", also
+	// lists the repositories of nested GitLab subgroups
+	IncludeSubgroupsKey = "includeSubgroups"
+
+	// RepositoryInfoKey is the ConfigMap data key that, when "true", exports
+	// repository metadata at the cost of an extra VCS API call per repository
+	RepositoryInfoKey = "repositoryInfo"
+
+	// GraphQLKey is the ConfigMap data key that, when "true", lists GitHub
+	// repositories through the GraphQL API instead of the REST API
+	GraphQLKey = "graphql"
+
+	// AffiliationKey is the ConfigMap data key for the comma-separated
+	// relationships through which the token has access to the repositories
+	// to list, instead of listing those of the organization directly
+	AffiliationKey = "affiliation"
+
+	// TeamKey is the ConfigMap data key for the slug of the GitHub team whose
+	// repositories to list, instead of those of the whole organization
+	TeamKey = "team"
+
+	// SearchQueryKey is the ConfigMap data key for the search query
+	// discovering the repositories of the organization, instead of listing
+	// all of them
+	SearchQueryKey = "searchQuery"
+
+	// ChecksKey is the ConfigMap data key for the comma-separated names of
+	// the checks to export, all checks if unset
+	ChecksKey = "checks"
+
+	// UnavailableValueKey is the ConfigMap data key selecting how repositories
+	// without scorecard data are exported
+	UnavailableValueKey = "unavailableValue"
+
+	// PassThresholdKey is the ConfigMap data key for the lowest score of a
+	// passing check, between 1 and 10
+	PassThresholdKey = "passThreshold"
+
+	// CheckWeightsKey is the ConfigMap data key for the comma-separated
+	// check=weight pairs of a custom overall score, none if unset
+	CheckWeightsKey = "checkWeights"
+)
+
+// UnavailableValue selects how repositories without scorecard data are exported
+type UnavailableValue string
+
+const (
+	// UnavailableValueNegativeOne exports an overall score of -1
+	UnavailableValueNegativeOne UnavailableValue = "negative_one"
+
+	// UnavailableValueNaN exports an overall score of NaN, which aggregations
+	// such as avg() and min() propagate instead of being skewed by
+	UnavailableValueNaN UnavailableValue = "nan"
+
+	// UnavailableValueAbsent exports no series for the repository
+	UnavailableValueAbsent UnavailableValue = "absent"
+)
+
+// score returns the score exported for unavailable data, -1 or NaN
+func (v UnavailableValue) score() float64 {
+	if v == UnavailableValueNaN {
+		return math.NaN()
+	}
+	return -1
+}
+
+// ParseUnavailableValue parses how repositories without scorecard data are
+// exported, -1 if empty
+func ParseUnavailableValue(value string) (UnavailableValue, error) {
+	switch v := UnavailableValue(value); v {
+	case "":
+		return UnavailableValueNegativeOne, nil
+	case UnavailableValueNegativeOne, UnavailableValueNaN, UnavailableValueAbsent:
+		return v, nil
+	default:
+		return "", fmt.Errorf("unknown unavailable value %q, must be one of %q, %q or %q",
+			value, UnavailableValueNegativeOne, UnavailableValueNaN, UnavailableValueAbsent)
+	}
+}
+
+const (
+	// SourceAPI reads scorecard data from the OpenSSF Scorecard API
+	SourceAPI = "api"
+
+	// SourceLocal computes scorecard data by running the scorecard CLI
+	SourceLocal = "local"
 )
 
 // ConfigMapReconciler reconciles ConfigMap objects for OpenSSF Scorecard
 type ConfigMapReconciler struct {
 	client.Client
 	Scheme           *runtime.Scheme
-	ScorecardClient  *scorecard.Client
+	Recorder         record.EventRecorder
+	ScorecardSource  scorecard.Source
 	MetricsCollector *metrics.Collector
 	ProviderFactory  *vcs.ProviderFactory
 	MaxJitterPercent int
 	RequeueInterval  time.Duration
+
+	// Reports receives the scorecard data exported for each repository to
+	// serve it as JSON (optional)
+	Reports *report.Store
+
+	// DebugState receives the outcome of each reconcile, to be served for
+	// troubleshooting. Nil disables it.
+	DebugState *debug.Store
+
+	// DefaultToken is used when a ConfigMap does not reference a token secret
+	DefaultToken string
+
+	// DefaultTokenFile is a file holding the default token. It is read on every
+	// reconcile so mounted tokens can be rotated, and takes precedence over DefaultToken.
+	DefaultTokenFile string
+
+	// DisallowDefaultScorecardEndpoint refuses ConfigMaps that read from the
+	// API without setting scorecardAPIEndpoint, so that no data is fetched
+	// from the default endpoint of ScorecardSource
+	DisallowDefaultScorecardEndpoint bool
+
+	// LocalScorecardSource computes scorecard data for ConfigMaps with
+	// source "local". ConfigMaps selecting it fail to reconcile when nil.
+	LocalScorecardSource scorecard.Source
+
+	// VCSTransport is the base HTTP transport for VCS provider requests
+	VCSTransport http.RoundTripper
+
+	// VCSProxyURL routes VCS provider requests through this proxy instead of
+	// the one of VCSTransport, empty to keep it
+	VCSProxyURL string
+
+	// VCSRateLimiter paces requests to the GitHub API across all ConfigMaps.
+	// Requests to GitLab and Gitea are not paced by it.
+	VCSRateLimiter *rate.Limiter
+
+	// VCSSearchRateLimiter additionally paces requests to the search APIs of
+	// the VCS across all ConfigMaps
+	VCSSearchRateLimiter *rate.Limiter
+
+	// MaxRepositories caps the repositories processed per reconcile for
+	// ConfigMaps that do not set maxRepositories. Zero means no limit.
+	MaxRepositories int
+
+	// RepositoryListTimeout bounds the time spent listing the repositories of
+	// a VCS instance. Zero means no limit beyond the reconcile context.
+	RepositoryListTimeout time.Duration
+
+	// ScorecardFetchTimeout bounds the time spent fetching the scorecard data
+	// of a repository, or of a batch of repositories. Zero means no limit
+	// beyond the reconcile context.
+	ScorecardFetchTimeout time.Duration
+
+	// ProviderCacheSize is the number of VCS providers kept for reuse between
+	// reconciles, so that their HTTP connections are reused. Zero creates a
+	// new provider on every reconcile.
+	ProviderCacheSize int
+
+	// ScorecardBatchSize fetches the scorecard data of up to this many
+	// repositories per request from sources supporting it. Zero fetches
+	// repositories one by one.
+	ScorecardBatchSize int
+
+	// UnavailableValue selects how repositories without scorecard data are
+	// exported for ConfigMaps that do not set unavailableValue, -1 if empty
+	UnavailableValue UnavailableValue
+
+	// PassThreshold is the lowest score of a passing check for ConfigMaps
+	// that do not set passThreshold, scorecard.DefaultPassThreshold if zero
+	PassThreshold int
+
+	// MaxConcurrentReconciles is the number of configs reconciled in
+	// parallel, one if zero. Each config is still reconciled serially.
+	MaxConcurrentReconciles int
+
+	// UnavailableRequeueInterval replaces RequeueInterval for ConfigMaps with
+	// repositories without scorecard data, so that newly published reports
+	// are picked up sooner. Zero disables it.
+	UnavailableRequeueInterval time.Duration
+
+	// FailOnMissingOrganization fails the reconcile of configs without an
+	// organization with a terminal error, so they show up in the reconcile
+	// error metrics of controller-runtime. They are not retried either way.
+	FailOnMissingOrganization bool
+
+	// DeniedOrganizations are never scanned, regardless of what configs
+	// request. Names are compared case-insensitively.
+	DeniedOrganizations []string
+
+	// ExcludedChecks are never exported, whether the checks of a config
+	// allow them or not. Names are compared case-insensitively.
+	ExcludedChecks []string
+
+	// InitialSyncWindow spreads the first reconcile of the configs existing
+	// on startup randomly over this window, so that they do not all call
+	// the VCS and scorecard APIs at once. Zero disables it.
+	InitialSyncWindow time.Duration
+
+	// ReconcileBudget bounds the time a reconcile spends exporting scorecard
+	// data. Reconciles running out of it export the data fetched so far and
+	// continue with the remaining repositories shortly after. Zero means no
+	// limit.
+	ReconcileBudget time.Duration
+
+	// MetricsPusher pushes the metrics to a Pushgateway at the end of each
+	// reconcile, for deployments that may not be scraped (optional)
+	MetricsPusher *metrics.Pusher
+
+	// ShutdownFlushTimeout bounds the time spent on shutdown waiting for
+	// in-flight reconciles before the final push to MetricsPusher,
+	// DefaultShutdownFlushTimeout if zero
+	ShutdownFlushTimeout time.Duration
+
+	// secrets tracks the Secrets referenced by each ConfigMap
+	secrets secretIndex
+
+	// providers reuses VCS providers between reconciles
+	providers providerCache
+
+	// backoff tracks consecutive transient VCS failures of each ConfigMap
+	backoff failureBackoff
+
+	// secondaryRateLimits tracks consecutive secondary rate limits of each
+	// ConfigMap
+	secondaryRateLimits failureBackoff
+
+	// initialSync staggers the first reconciles after startup
+	initialSync initialSync
+
+	// progress tracks where reconciles that ran out of ReconcileBudget stopped
+	progress reconcileProgress
+
+	// listings tracks where rate limits interrupted repository listings
+	listings listingCursors
+
+	// locks serializes the reconciles of each config
+	locks configLocks
+
+	// inFlight counts the reconciles in progress for the shutdown flush
+	inFlight inFlightReconciles
+
+	// warnedAnonymous holds the configs already warned about anonymous access
+	warnedAnonymous sync.Map
+
+	// tokenProbes tracks the credentials already probed for each config
+	tokenProbes tokenProbes
+
+	// generations tracks the data each config was last reconciled with
+	generations configGenerations
 }
 
-// +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch;update;patch
 // +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch
 // +kubebuilder:rbac:groups=core,resources=configmaps/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
 
 // Reconcile is the main reconciliation loop for ConfigMaps
 func (r *ConfigMapReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
 
+	defer r.inFlight.start()()
+	unlock := r.locks.lock(req.NamespacedName)
+	defer unlock()
+	defer r.pushMetrics(ctx)
+
 	// Fetch the ConfigMap
 	var configMap corev1.ConfigMap
 	if err := r.Get(ctx, req.NamespacedName, &configMap); err != nil {
 		// ConfigMap not found, likely deleted. Remove metrics for this config.
-		r.MetricsCollector.RemoveMetricsForConfig(req.NamespacedName.String())
+		r.forgetConfig(req.NamespacedName)
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
+	// Clean up ConfigMaps that are being deleted or are no longer labeled
+	if !isManaged(&configMap) {
+		return r.finalize(ctx, req.NamespacedName, &configMap)
+	}
+
+	// Make sure metrics are cleaned up when the ConfigMap is deleted
+	if ok, result, err := r.ensureFinalizer(ctx, &configMap); !ok {
+		return result, err
+	}
+
+	// Record referenced Secrets so that changes to them re-trigger reconciliation
+	r.secrets.set(req.NamespacedName, referencedSecrets(&configMap))
+
+	// Spread the first reconciles after startup over InitialSyncWindow, unless
+	// a scan was requested
+	scanNow, requested := scanRequested(&configMap)
+	if requested {
+		logger.Info("Scan requested", "scanNow", scanNow)
+	} else if delay := r.initialSync.delay(req.NamespacedName, r.InitialSyncWindow); delay > 0 {
+		logger.V(1).Info("Postponing initial reconcile", "delay", delay)
+		return ctrl.Result{RequeueAfter: delay}, nil
+	}
+
+	// Reconcile and write the outcome back to the ConfigMap
+	status := &reconcileStatus{scanNow: scanNow, refresh: requested}
+	result, err := r.reconcileConfigMap(ctx, req, &configMap, &configMap, status)
+	if err != nil {
+		status.err = err
+	}
+	r.DebugState.Record(req.NamespacedName.String(), status.repositories, status.partial, status.err)
+	if patchErr := r.patchStatusAnnotations(ctx, &configMap, status); patchErr != nil {
+		logger.Error(patchErr, "Failed to update status annotations")
+	}
+
+	return result, err
+}
+
+// reconcileConfigMap exports scorecard metrics for the organization configured
+// in a ConfigMap, recording the outcome in status and events on object, which
+// is the ConfigMap itself or the resource it was derived from
+func (r *ConfigMapReconciler) reconcileConfigMap(
+	ctx context.Context, req ctrl.Request, configMap *corev1.ConfigMap, object runtime.Object, status *reconcileStatus,
+) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+	started := time.Now()
+
 	logger.Info("Reconciling ConfigMap for OpenSSF Scorecard",
 		"namespace", configMap.Namespace,
 		"name", configMap.Name)
+	r.MetricsCollector.WatchConfig(req.NamespacedName.String())
 
 	// Extract organization from ConfigMap
 	organization, ok := configMap.Data[OrganizationKey]
 	if !ok || organization == "" {
-		logger.Error(fmt.Errorf("missing required field"), "ConfigMap must have 'organization' key in data")
+		err := fmt.Errorf("missing required field %q", OrganizationKey)
+		logger.Error(err, "ConfigMap must have 'organization' key in data")
+		r.recordEvent(object, corev1.EventTypeWarning, EventReasonMissingOrganization,
+			"Skipping reconcile: %v", err)
+		r.MetricsCollector.ConfigError(req.NamespacedName.String(), metrics.ConfigErrorMissingOrganization)
+		if r.FailOnMissingOrganization {
+			// A terminal error is counted as a failed reconcile but not retried
+			return ctrl.Result{}, reconcile.TerminalError(err)
+		}
+		status.err = err
 		return ctrl.Result{}, nil
 	}
 
-	// Extract provider type (defaults to GitHub)
-	providerType := vcs.ProviderType(configMap.Data[ProviderTypeKey])
-	if providerType == "" {
-		providerType = vcs.ProviderTypeGitHub
+	// Skip organizations denied by the manager, whatever the config says
+	if r.organizationDenied(organization) {
+		err := fmt.Errorf("organization %q is denied by the manager", organization)
+		logger.Error(err, "Skipping denied organization")
+		r.recordEvent(object, corev1.EventTypeWarning, EventReasonOrganizationDenied,
+			"Skipping reconcile: %v", err)
+		r.MetricsCollector.ConfigError(req.NamespacedName.String(), metrics.ConfigErrorDeniedOrganization)
+		status.err = err
+		return ctrl.Result{}, nil
 	}
 
-	// Extract optional base URL for custom VCS instances
-	baseURL := configMap.Data[BaseURLKey]
+	// Parse the remaining settings, reporting all invalid ones at once
+	config, err := r.parseConfig(configMap)
+	if err != nil {
+		logger.Error(err, "Invalid configuration")
+		status.err = err
+		return ctrl.Result{}, nil
+	}
+	excludedChecks := newCheckFilter(r.ExcludedChecks)
 
-	// Extract optional VCS token from referenced secret
-	var vcsToken string
-	if tokenSecretName, hasToken := configMap.Data[TokenSecretKey]; hasToken && tokenSecretName != "" {
-		tokenKeyName := configMap.Data[TokenSecretKeyName]
-		if tokenKeyName == "" {
-			tokenKeyName = "token" // default key name
+	// Resolve the VCS token from the referenced secret, falling back to the
+	// manager default for the VCS providers only. Scorecard sources only get
+	// the token of the secret.
+	token, err := r.getVCSToken(ctx, configMap)
+	if err != nil {
+		if apierrors.IsNotFound(err) || errors.Is(err, errTokenKeyNotFound) {
+			r.recordEvent(object, corev1.EventTypeWarning, EventReasonSecretMissing,
+				"Failed to read VCS token: %v", err)
 		}
-
-		var secret corev1.Secret
-		secretKey := client.ObjectKey{
-			Namespace: configMap.Namespace,
-			Name:      tokenSecretName,
+		if errors.Is(err, errTokenKeyNotFound) || errors.Is(err, errInvalidConfig) {
+			status.err = err
+			return ctrl.Result{}, nil
 		}
-
-		if err := r.Get(ctx, secretKey, &secret); err != nil {
-			logger.Error(err, "Failed to fetch VCS token secret", "secret", tokenSecretName)
+		return ctrl.Result{}, err
+	}
+	var defaultToken string
+	if token == "" {
+		if defaultToken, err = r.getDefaultToken(); err != nil {
 			return ctrl.Result{}, err
 		}
+	}
 
-		tokenBytes, ok := secret.Data[tokenKeyName]
-		if !ok {
-			logger.Error(fmt.Errorf("token key not found in secret"),
-				"Failed to find token key",
-				"secret", tokenSecretName,
-				"key", tokenKeyName)
+	vcsConfig := &vcs.Config{
+		Type:              config.providerType,
+		Token:             token,
+		DefaultToken:      defaultToken,
+		Username:          configMap.Data[UsernameKey],
+		Organization:      organization,
+		OwnerType:         config.ownerType,
+		Visibility:        config.visibility,
+		Affiliations:      config.affiliations,
+		Team:              config.team,
+		IncludeSubgroups:  config.includeSubgroups,
+		GraphQL:           config.graphQL,
+		MaxRepositories:   config.maxRepositories,
+		MaxRepositoryAge:  config.maxRepositoryAge,
+		Transport:         r.VCSTransport,
+		ProxyURL:          r.VCSProxyURL,
+		RateLimiter:       r.VCSRateLimiter,
+		SearchRateLimiter: r.VCSSearchRateLimiter,
+	}
+
+	// Extract optional GitHub App credentials, which take precedence over the token
+	if err := r.getGitHubAppCredentials(ctx, configMap.Namespace, config.gitHubApp, vcsConfig); err != nil {
+		if apierrors.IsNotFound(err) {
+			r.recordEvent(object, corev1.EventTypeWarning, EventReasonSecretMissing,
+				"Failed to read GitHub App private key: %v", err)
+		}
+		if errors.Is(err, errInvalidConfig) {
+			logger.Error(err, "Invalid GitHub App configuration")
+			status.err = err
 			return ctrl.Result{}, nil
 		}
-		vcsToken = string(tokenBytes)
+		return ctrl.Result{}, err
 	}
 
-	// Create VCS provider
-	provider, err := r.ProviderFactory.CreateProvider(&vcs.Config{
-		Type:         providerType,
-		Token:        vcsToken,
-		BaseURL:      baseURL,
-		Organization: organization,
-	})
-	if err != nil {
-		logger.Error(err, "Failed to create VCS provider", "providerType", providerType)
-		return ctrl.Result{}, err
+	// Make anonymous access, and its low rate limits, visible
+	authenticated := vcsConfig.AccessToken() != "" || vcsConfig.AppID != 0
+	r.MetricsCollector.SetAuthenticated(req.NamespacedName.String(), authenticated)
+	if authenticated {
+		r.warnedAnonymous.Delete(req.NamespacedName)
+	} else if _, warned := r.warnedAnonymous.LoadOrStore(req.NamespacedName, struct{}{}); !warned {
+		logger.Info("No VCS token configured, using anonymous access with low rate limits",
+			"namespace", configMap.Namespace,
+			"name", configMap.Name)
 	}
 
-	logger.Info("Using VCS provider",
-		"provider", provider.GetProviderType(),
-		"organization", organization)
+	// Discover repositories on each configured VCS instance, counting those
+	// left out by filters for the coverage ratio
+	generation := configGeneration(configMap.Data)
+	var instances []vcsInstance
+	var excluded atomic.Int64
+	for _, baseURL := range config.baseURLs {
+		instanceConfig := *vcsConfig
+		instanceConfig.BaseURL = baseURL
 
-	// Fetch repositories using the VCS provider
-	logger.Info("Fetching repositories", "organization", organization)
-	repos, err := provider.GetRepositories(ctx, organization)
-	if err != nil {
-		// Check if this is a rate limit error
-		if vcs.IsRateLimitError(err) {
-			retryAfter := vcs.GetRetryAfter(err)
-			logger.Info("VCS API rate limit encountered, will retry later",
-				"organization", organization,
-				"provider", provider.GetProviderType(),
-				"retryAfter", retryAfter,
-				"error", err.Error())
+		// Create VCS provider
+		provider, err := r.providers.get(req.NamespacedName, &instanceConfig, r.ProviderCacheSize,
+			r.ProviderFactory.CreateProvider)
+		if err != nil {
+			if vcs.IsTransientError(err) {
+				return r.transientFailure(ctx, req, object, status, err), nil
+			}
+			logger.Error(err, "Failed to create VCS provider", "providerType", config.providerType, "baseURL", baseURL)
+			return ctrl.Result{}, err
+		}
+		tokenInfo := r.probeToken(ctx, req.NamespacedName, object, provider, &instanceConfig, organization)
 
-			// Return with requeue after the rate limit period
-			// This prevents immediate retry and respects the rate limit
-			return ctrl.Result{RequeueAfter: retryAfter}, nil
+		// Extract the optional branch or commit to report on
+		ref, err := parseScorecardRef(configMap, provider)
+		if err != nil {
+			logger.Error(err, "Invalid scorecard ref")
+			status.err = err
+			return ctrl.Result{}, nil
 		}
 
-		// For other errors, log and return error to trigger standard retry
-		logger.Error(err, "Failed to fetch repositories", "organization", organization)
-		return ctrl.Result{}, err
+		// List the repositories of the organization, resuming where a rate
+		// limit interrupted the previous listing, or those matching the
+		// search query
+		listRepositories := provider.GetRepositories
+		if lister, ok := provider.(vcs.ResumableLister); ok {
+			listRepositories = r.resumingLister(req.NamespacedName, baseURL, generation, lister, &excluded)
+		}
+		if config.searchQuery != "" {
+			if listRepositories, err = searchLister(provider, config.ownerType, config.searchQuery); err != nil {
+				logger.Error(err, "Invalid search query")
+				status.err = err
+				return ctrl.Result{}, nil
+			}
+		}
+
+		logger.Info("Using VCS provider",
+			"provider", provider.GetProviderType(),
+			"baseURL", baseURL,
+			"organization", organization)
+
+		// Fetch repositories using the VCS provider, recording those it leaves out
+		logger.Info("Fetching repositories", "organization", organization)
+		excludedBefore := excluded.Load()
+		listCtx, cancel := withTimeout(ctx, r.RepositoryListTimeout)
+		listCtx = vcs.WithExclusionHandler(listCtx, func(repository, reason string) {
+			logger.V(1).Info("Excluding repository", "organization", organization, "repository", repository, "reason", reason)
+			r.MetricsCollector.RepositoryExcluded(req.NamespacedName.String(), organization, reason)
+			excluded.Add(1)
+		})
+		repos, err := listRepositories(listCtx, organization)
+		err = timeoutError(ctx, listCtx, "listing repositories", r.RepositoryListTimeout, err)
+		cancel()
+		if err != nil {
+			// Check if this is a rate limit error
+			if vcs.IsRateLimitError(err) {
+				retryAfter := vcs.GetRetryAfter(err)
+
+				// Secondary rate limits last longer when hit again, so wait
+				// longer with every consecutive one
+				secondary := vcs.IsSecondaryRateLimitError(err)
+				if secondary {
+					retryAfter = max(retryAfter, r.secondaryRateLimits.nextAfter(req.NamespacedName,
+						secondaryRateLimitBackoffBase, secondaryRateLimitBackoffMax))
+				}
+				logger.Info("VCS API rate limit encountered, will retry later",
+					"organization", organization,
+					"provider", provider.GetProviderType(),
+					"secondary", secondary,
+					"retryAfter", retryAfter,
+					"error", err.Error())
+				r.recordEvent(object, corev1.EventTypeWarning, EventReasonRateLimited,
+					"%s API rate limit exceeded, retrying in %v", provider.GetProviderType(), retryAfter)
+
+				// Return with requeue after the rate limit period
+				// This prevents immediate retry and respects the rate limit
+				status.err = err
+				return ctrl.Result{RequeueAfter: retryAfter}, nil
+			}
+
+			// Back off from VCS APIs that are temporarily unavailable
+			if vcs.IsTransientError(err) {
+				return r.transientFailure(ctx, req, object, status, err), nil
+			}
+
+			// A token missing a scope lists no repositories, which must not be
+			// reported as an empty organization
+			if vcs.IsScopeError(err) {
+				logger.Error(err, "VCS token lacks the scope required to list repositories, check the configured token",
+					"organization", organization,
+					"provider", provider.GetProviderType(),
+					"retryAfter", authFailureRequeueDelay)
+				r.recordEvent(object, corev1.EventTypeWarning, EventReasonInsufficientScope,
+					"%v", err)
+				status.err = err
+				return ctrl.Result{RequeueAfter: authFailureRequeueDelay}, nil
+			}
+
+			// Retrying with the same credentials will keep failing, so wait longer
+			if vcs.IsAuthError(err) {
+				logger.Error(err, "VCS API rejected the credentials, check the configured token",
+					"organization", organization,
+					"provider", provider.GetProviderType(),
+					"retryAfter", authFailureRequeueDelay)
+				r.recordEvent(object, corev1.EventTypeWarning, EventReasonAuthenticationFailed,
+					"%s API rejected the credentials, check the configured token: %v", provider.GetProviderType(), err)
+				r.MetricsCollector.VCSAuthFailed(req.NamespacedName.String(), organization)
+				status.err = err
+				return ctrl.Result{RequeueAfter: authFailureRequeueDelay}, nil
+			}
+
+			// For other errors, log and return error to trigger standard retry
+			logger.Error(err, "Failed to fetch repositories", "organization", organization, "baseURL", baseURL)
+			return ctrl.Result{}, err
+		}
+
+		logger.Info("Found repositories", "organization", organization, "baseURL", baseURL, "count", len(repos))
+		if config.searchQuery == "" && config.team == "" {
+			r.checkListing(ctx, object, provider, tokenInfo, organization, len(repos)+int(excluded.Load()-excludedBefore))
+		}
+		instances = append(instances, vcsInstance{provider: provider, ref: ref, repos: repos})
 	}
+	r.backoff.reset(req.NamespacedName)
+	r.secondaryRateLimits.reset(req.NamespacedName)
 
-	logger.Info("Found repositories", "organization", organization, "count", len(repos))
+	// Skip repositories whose normalized label collides with another one, as
+	// their series would overwrite each other
+	for _, collision := range dropLabelCollisions(instances, r.MetricsCollector.RepositoryLabel) {
+		logger.Info("Skipping repository whose label collides with another repository",
+			"organization", organization,
+			"repository", collision.repository,
+			"other", collision.other,
+			"label", collision.label)
+	}
 
-	// Fetch scorecard data for each repository
-	for _, repo := range repos {
+	// Start over once repositories were listed with a changed configuration,
+	// so that repositories it no longer selects don't keep their last scores
+	if r.generations.changed(req.NamespacedName, generation) {
+		logger.Info("Configuration changed, resetting its metrics",
+			"namespace", configMap.Namespace,
+			"name", configMap.Name)
+		r.MetricsCollector.RemoveMetricsForConfig(req.NamespacedName.String())
+		r.Reports.RemoveConfig(req.NamespacedName.String())
+		r.progress.reset(req.NamespacedName)
+	}
+
+	// Protect against runaway API usage for unexpectedly large organizations
+	total := countRepositories(instances)
+	listed := total + int(excluded.Load())
+	if config.maxRepositories > 0 && total > config.maxRepositories {
+		logger.Info("Repository limit reached, skipping the remaining repositories",
+			"organization", organization,
+			"count", total,
+			"maxRepositories", config.maxRepositories)
+		r.MetricsCollector.RepositoriesTruncated(req.NamespacedName.String(), organization)
+		truncateRepositories(instances, config.maxRepositories)
+		total = config.maxRepositories
+	}
+
+	// In dry-run mode, only report what would be scanned
+	if configMap.Annotations[DryRunAnnotation] == "true" {
+		logger.Info("Dry run, skipping scorecard data",
+			"namespace", configMap.Namespace,
+			"name", configMap.Name,
+			"repositories", total)
+		r.MetricsCollector.RemoveMetricsForConfig(req.NamespacedName.String())
+		r.Reports.RemoveConfig(req.NamespacedName.String())
+		r.recordEvent(object, corev1.EventTypeNormal, EventReasonDryRun,
+			"Dry run found %d repositories in %s", total, organization)
+		status.repositories = total
+		return utils.JitterRequeue(r.RequeueInterval, r.MaxJitterPercent, logger), nil
+	}
+
+	r.MetricsCollector.UpdateCoverageRatio(req.NamespacedName.String(), organization, total, listed)
+
+	// Fetch scorecard data for each repository, continuing the cycle of a
+	// reconcile that ran out of its budget
+	cycle := r.progress.cycle(req.NamespacedName)
+	repositories := instanceRepositories(instances)
+	remaining := cycle.remaining(repositories)
+	remainingRepos := make(map[int][]string, len(instances))
+	for _, repository := range remaining {
+		remainingRepos[repository.instance] = append(remainingRepos[repository.instance], repository.repo)
+	}
+	batches := make(map[int]map[string]*scorecard.ScorecardData, len(instances))
+	exported := 0
+	for _, repository := range remaining {
+		// Stop once ReconcileBudget is spent, but export at least one
+		// repository so that every reconcile makes progress
+		if r.ReconcileBudget > 0 && exported > 0 && time.Since(started) >= r.ReconcileBudget {
+			break
+		}
+		exported++
+
+		instance, repo := instances[repository.instance], repository.repo
+		batch, ok := batches[repository.instance]
+		if !ok {
+			batch = r.fetchScorecardBatch(ctx, config.source, instance, remainingRepos[repository.instance],
+				organization, token, status.refresh)
+			batches[repository.instance] = batch
+		}
 		logger.Info("Fetching scorecard data", "repository", repo)
 
 		// Construct the VCS path for the scorecard API
-		vcsPath := provider.GetScorecardURL(organization, repo)
+		vcsPath := instance.provider.GetScorecardURL(organization, repo)
+		host := scorecardHost(vcsPath)
+
+		// Export repository metadata when enabled, at the cost of an extra API
+		// call bounded like the scorecard fetch
+		if config.repositoryInfo {
+			detailsCtx, cancel := withTimeout(ctx, r.ScorecardFetchTimeout)
+			details, err := instance.provider.GetRepositoryDetails(detailsCtx, organization, repo)
+			err = timeoutError(ctx, detailsCtx, "fetching repository details", r.ScorecardFetchTimeout, err)
+			cancel()
+			if err != nil {
+				logger.Error(err, "Failed to fetch repository details",
+					"organization", organization,
+					"repository", repo)
+			} else {
+				r.MetricsCollector.UpdateRepositoryInfo(req.NamespacedName.String(), host, organization, repo, details)
+			}
+		}
 
-		scorecardData, err := r.ScorecardClient.GetScorecardData(ctx, vcsPath, vcsToken)
+		var scorecardData *scorecard.ScorecardData
+		var err error
+		if batch != nil {
+			// Repositories left out of the batch have no scorecard data
+			if scorecardData = batch[vcsPath]; scorecardData == nil {
+				err = fmt.Errorf("%w for %s", scorecard.ErrNotFound, vcsPath)
+			}
+		} else {
+			var fetchOpts []scorecard.FetchOption
+			fetchOpts, err = instance.ref.fetchOptions(ctx, organization, repo)
+			if err == nil {
+				if status.refresh {
+					fetchOpts = append(fetchOpts, scorecard.Refresh())
+				}
+				fetchCtx, cancel := withTimeout(ctx, r.ScorecardFetchTimeout)
+				scorecardData, err = config.source.GetScorecardData(fetchCtx, vcsPath, token, fetchOpts...)
+				err = timeoutError(ctx, fetchCtx, "fetching scorecard data", r.ScorecardFetchTimeout, err)
+				cancel()
+			}
+		}
 		if err != nil {
-			// Check if this is a "not found" error (scorecard data not available yet)
-			if isNotFoundError(err) {
+			// Check if this is a "not found" error (scorecard data not available yet,
+			// or the configured branch does not exist in this repository)
+			if isNotFoundError(err) || errors.Is(err, vcs.ErrNotFound) {
 				logger.Info("Scorecard data not yet available for repository",
 					"organization", organization,
 					"repository", repo,
 					"vcsPath", vcsPath)
+				cycle.unavailable++
+
+				r.Reports.Remove(req.NamespacedName.String(), host, organization, repo)
+				if config.unavailableValue == UnavailableValueAbsent {
+					r.MetricsCollector.RemoveRepositoryMetrics(req.NamespacedName.String(), host, organization, repo)
+					continue
+				}
 
-				// Create scorecard data with -1 score to indicate unavailable data
+				// Create scorecard data with a -1 or NaN score to indicate unavailable data
 				scorecardData = &scorecard.ScorecardData{
-					Score:      -1,
+					Score:      config.unavailableValue.score(),
 					Repository: repo,
 					Timestamp:  time.Now(),
 					Checks:     []scorecard.Check{},
+					Source:     scorecardDataSource(configMap),
 				}
 
-				// Update metrics with -1 score
+				// Update metrics with the unavailable score
 				r.MetricsCollector.UpdateMetrics(
 					req.NamespacedName.String(),
+					host,
 					organization,
 					repo,
 					scorecardData,
 				)
+				r.MetricsCollector.RemoveCustomOverallScore(req.NamespacedName.String(), host, organization, repo)
 
 				// Continue to next repository
 				continue
 			}
 
+			// The scorecard API failed repeatedly, wait for the circuit breaker
+			// to let requests through again instead of retrying right away
+			var circuitErr *scorecard.CircuitOpenError
+			if errors.As(err, &circuitErr) {
+				logger.Info("Scorecard API unavailable, will retry later",
+					"organization", organization,
+					"repository", repo,
+					"retryAfter", circuitErr.RetryAfter)
+				r.recordEvent(object, corev1.EventTypeWarning, EventReasonScorecardFetchFailed,
+					"Scorecard API unavailable, retrying in %v", circuitErr.RetryAfter)
+				status.err = err
+				return ctrl.Result{RequeueAfter: circuitErr.RetryAfter}, nil
+			}
+
+			// The scorecard API rate limit is exhausted, retry once it resets
+			var rateLimitErr *scorecard.RateLimitError
+			if errors.As(err, &rateLimitErr) {
+				retryAfter := rateLimitErr.Delay()
+				logger.Info("Scorecard API rate limit exceeded, will retry later",
+					"organization", organization,
+					"repository", repo,
+					"remaining", rateLimitErr.Remaining,
+					"retryAfter", retryAfter)
+				r.recordEvent(object, corev1.EventTypeWarning, EventReasonRateLimited,
+					"Scorecard API rate limit exceeded, retrying in %v", retryAfter)
+				status.err = err
+				return ctrl.Result{RequeueAfter: retryAfter}, nil
+			}
+
 			// For other errors, log as error and return to retry
 			logger.Error(err, "Failed to fetch scorecard data",
 				"organization", organization,
 				"repository", repo,
 				"vcsPath", vcsPath)
+			r.recordEvent(object, corev1.EventTypeWarning, EventReasonScorecardFetchFailed,
+				"Failed to fetch scorecard data for %s: %v", repo, err)
 			return ctrl.Result{}, err
 		}
 
-		// Update metrics
+		// Update metrics for the allowed checks
+		scorecardData = withPassThreshold(scorecardData, config.passThreshold)
+		filtered := excludedChecks.exclude(config.checks.apply(scorecardData))
 		r.MetricsCollector.UpdateMetrics(
 			req.NamespacedName.String(),
+			host,
 			organization,
 			repo,
-			scorecardData,
+			filtered,
 		)
+		logFailingChecks(logger, organization, repo, filtered)
+		// The custom score weighs all checks, whether they are exported or not
+		if score, ok := config.weights.score(scorecardData.Checks); ok {
+			r.MetricsCollector.UpdateCustomOverallScore(req.NamespacedName.String(), host, organization, repo, score)
+		} else {
+			r.MetricsCollector.RemoveCustomOverallScore(req.NamespacedName.String(), host, organization, repo)
+		}
+		r.Reports.Set(req.NamespacedName.String(), host, organization, repo, scorecardData)
+		cycle.withData++
+		cycle.scores = append(cycle.scores, scorecardData.Score)
+	}
+	status.repositories = total
+
+	// Continue with the remaining repositories soon, publishing the
+	// aggregates of the config once the cycle is complete
+	cycle.offset += exported
+	if cycle.offset < len(repositories) {
+		r.progress.save(req.NamespacedName, cycle)
+		logger.Info("Reconcile budget spent, continuing with the remaining repositories later",
+			"namespace", configMap.Namespace,
+			"name", configMap.Name,
+			"exported", exported,
+			"repositories", total,
+			"budget", r.ReconcileBudget,
+			"retryAfter", partialReconcileRequeueDelay)
+		r.recordEvent(object, corev1.EventTypeNormal, EventReasonPartialReconcile,
+			"Exported scorecard data for %d of %d repositories within the reconcile budget of %v, continuing in %v",
+			exported, total, r.ReconcileBudget, partialReconcileRequeueDelay)
+		r.MetricsCollector.PartialReconcile(req.NamespacedName.String())
+		status.partial = true
+		return ctrl.Result{RequeueAfter: partialReconcileRequeueDelay}, nil
+	}
+	r.progress.reset(req.NamespacedName)
+
+	r.MetricsCollector.UpdateRepositoryCounts(req.NamespacedName.String(), organization, total, cycle.withData, cycle.unavailable)
+	r.MetricsCollector.UpdateScoreDistribution(req.NamespacedName.String(), organization, cycle.scores)
+
+	// Without any scorecard data, the average is unavailable as well
+	switch average, ok := averageScore(cycle.scores); {
+	case ok:
+		r.MetricsCollector.UpdateOrganizationAverageScore(req.NamespacedName.String(), organization, average)
+	case config.unavailableValue == UnavailableValueAbsent:
+		r.MetricsCollector.RemoveOrganizationAverageScore(req.NamespacedName.String(), organization)
+	default:
+		r.MetricsCollector.UpdateOrganizationAverageScore(req.NamespacedName.String(), organization, config.unavailableValue.score())
 	}
+	r.MetricsCollector.ConfigSucceeded(req.NamespacedName.String())
 
 	logger.Info("Successfully reconciled ConfigMap",
 		"namespace", configMap.Namespace,
 		"name", configMap.Name,
-		"provider", provider.GetProviderType(),
-		"repositories", len(repos))
+		"provider", config.providerType,
+		"repositories", total)
+	r.recordEvent(object, corev1.EventTypeNormal, EventReasonReconcileSucceeded,
+		"Exported scorecard data for %d repositories", total)
+
+	return utils.JitterRequeue(r.requeueInterval(cycle.unavailable), r.MaxJitterPercent, logger), nil
+}
+
+// requeueInterval returns the interval until the next reconcile of a ConfigMap
+// with the given number of repositories without scorecard data
+func (r *ConfigMapReconciler) requeueInterval(unavailable int) time.Duration {
+	if unavailable > 0 && r.UnavailableRequeueInterval > 0 && r.UnavailableRequeueInterval < r.RequeueInterval {
+		return r.UnavailableRequeueInterval
+	}
+	return r.RequeueInterval
+}
+
+// transientFailure records a transient VCS failure and returns a requeue with
+// a backoff that grows with consecutive failures of the ConfigMap
+func (r *ConfigMapReconciler) transientFailure(ctx context.Context, req ctrl.Request, object runtime.Object, status *reconcileStatus, err error) ctrl.Result {
+	retryAfter := r.backoff.next(req.NamespacedName)
+	log.FromContext(ctx).Info("VCS API temporarily unavailable, will retry later",
+		"retryAfter", retryAfter,
+		"error", err.Error())
+	r.recordEvent(object, corev1.EventTypeWarning, EventReasonVCSUnavailable,
+		"VCS API temporarily unavailable, retrying in %v: %v", retryAfter, err)
+	status.err = err
+	return ctrl.Result{RequeueAfter: retryAfter}
+}
+
+// errInvalidConfig indicates a ConfigMap setting that cannot be used as-is.
+// Retrying will not help until the ConfigMap or the referenced secrets change.
+var errInvalidConfig = errors.New("invalid configuration")
+
+// errTokenKeyNotFound indicates that the referenced secret lacks the configured token key
+var errTokenKeyNotFound = errors.New("token key not found in secret")
+
+// scorecardSource returns the scorecard source selected by a ConfigMap,
+// defaulting to the public API
+func (r *ConfigMapReconciler) scorecardSource(configMap *corev1.ConfigMap) (scorecard.Source, error) {
+	switch source := configMap.Data[SourceKey]; source {
+	case "", SourceAPI:
+		endpoint, err := parseScorecardAPIEndpoint(configMap)
+		if err != nil {
+			return nil, err
+		}
+		if endpoint == "" {
+			if r.DisallowDefaultScorecardEndpoint {
+				return nil, fmt.Errorf("%w: %s is required, the default scorecard API endpoint is disallowed",
+					errInvalidConfig, ScorecardAPIEndpointKey)
+			}
+			return r.ScorecardSource, nil
+		}
+		return newEndpointSource(r.ScorecardSource, endpoint), nil
+	case SourceLocal:
+		if r.LocalScorecardSource == nil {
+			return nil, fmt.Errorf("%w: %s %q is not enabled", errInvalidConfig, SourceKey, source)
+		}
+		return r.LocalScorecardSource, nil
+	default:
+		return nil, fmt.Errorf("%w: unknown %s %q", errInvalidConfig, SourceKey, source)
+	}
+}
+
+// scorecardDataSource returns the source label of the scorecard data of a
+// ConfigMap, as set by its scorecard source
+func scorecardDataSource(configMap *corev1.ConfigMap) string {
+	if configMap.Data[SourceKey] == SourceLocal {
+		return scorecard.DataSourceLocal
+	}
+	return scorecard.EndpointDataSource(strings.TrimSpace(configMap.Data[ScorecardAPIEndpointKey]))
+}
+
+// parseScorecardAPIEndpoint returns the scorecard API endpoint of a ConfigMap,
+// empty if unset
+func parseScorecardAPIEndpoint(configMap *corev1.ConfigMap) (string, error) {
+	endpoint := strings.TrimSpace(configMap.Data[ScorecardAPIEndpointKey])
+	if endpoint == "" {
+		return "", nil
+	}
+
+	u, err := url.Parse(endpoint)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", fmt.Errorf("%w: %s %q is not an absolute HTTP(S) URL", errInvalidConfig, ScorecardAPIEndpointKey, endpoint)
+	}
+	return endpoint, nil
+}
+
+// endpointSource fetches scorecard data from a specific scorecard API endpoint
+type endpointSource struct {
+	scorecard.Source
+	endpoint string
+}
+
+// endpointBatchSource is an endpointSource of a source that serves batches
+type endpointBatchSource struct {
+	endpointSource
+}
+
+// newEndpointSource returns a source fetching scorecard data from endpoint,
+// which serves batches if source does
+func newEndpointSource(source scorecard.Source, endpoint string) scorecard.Source {
+	s := endpointSource{Source: source, endpoint: endpoint}
+	if _, ok := source.(scorecard.BatchSource); ok {
+		return endpointBatchSource{s}
+	}
+	return s
+}
+
+// GetScorecardData implements scorecard.Source
+func (s endpointSource) GetScorecardData(
+	ctx context.Context, vcsPath, token string, opts ...scorecard.FetchOption,
+) (*scorecard.ScorecardData, error) {
+	opts = append(opts[:len(opts):len(opts)], scorecard.FromEndpoint(s.endpoint))
+	return s.Source.GetScorecardData(ctx, vcsPath, token, opts...)
+}
+
+// GetScorecardDataBatch implements scorecard.BatchSource
+func (s endpointBatchSource) GetScorecardDataBatch(
+	ctx context.Context, vcsPaths []string, token string, opts ...scorecard.FetchOption,
+) (map[string]*scorecard.ScorecardData, error) {
+	opts = append(opts[:len(opts):len(opts)], scorecard.FromEndpoint(s.endpoint))
+	return s.Source.(scorecard.BatchSource).GetScorecardDataBatch(ctx, vcsPaths, token, opts...)
+}
+
+// organizationDenied returns whether organization is in DeniedOrganizations
+func (r *ConfigMapReconciler) organizationDenied(organization string) bool {
+	for _, denied := range r.DeniedOrganizations {
+		if strings.EqualFold(denied, organization) {
+			return true
+		}
+	}
+	return false
+}
+
+// pushMetrics pushes the metrics to MetricsPusher when set. Failures are
+// logged and do not fail the reconcile, as the metrics are still scraped.
+// Reconciles interrupted by shutdown leave it to flushMetricsOnShutdown.
+func (r *ConfigMapReconciler) pushMetrics(ctx context.Context) {
+	if r.MetricsPusher == nil || ctx.Err() != nil {
+		return
+	}
+	if err := r.MetricsPusher.Push(ctx); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to push metrics to the Pushgateway")
+	}
+}
+
+// parseOwnerType returns the kind of account owning the repositories of a
+// ConfigMap, an organization if unset
+func parseOwnerType(configMap *corev1.ConfigMap) (vcs.OwnerType, error) {
+	switch ownerType := vcs.OwnerType(configMap.Data[OwnerTypeKey]); ownerType {
+	case "", vcs.OwnerTypeOrganization:
+		return vcs.OwnerTypeOrganization, nil
+	case vcs.OwnerTypeUser:
+		return ownerType, nil
+	default:
+		return "", fmt.Errorf("%w: unknown %s %q", errInvalidConfig, OwnerTypeKey, ownerType)
+	}
+}
+
+// parseVisibility returns the visibility of the repositories listed for a
+// ConfigMap, public if unset
+func parseVisibility(configMap *corev1.ConfigMap) (vcs.Visibility, error) {
+	switch visibility := vcs.Visibility(configMap.Data[VisibilityKey]); visibility {
+	case "", vcs.VisibilityPublic:
+		return vcs.VisibilityPublic, nil
+	case vcs.VisibilityPrivate, vcs.VisibilityAll:
+		return visibility, nil
+	default:
+		return "", fmt.Errorf("%w: unknown %s %q", errInvalidConfig, VisibilityKey, visibility)
+	}
+}
+
+// parseTeam returns the slug of the team whose repositories to list, empty if
+// unset. Teams are only listed by the GitHub REST API, for organizations.
+func parseTeam(
+	configMap *corev1.ConfigMap, providerType vcs.ProviderType, ownerType vcs.OwnerType,
+	graphQL bool, affiliations []vcs.Affiliation,
+) (string, error) {
+	team := strings.TrimSpace(configMap.Data[TeamKey])
+	switch {
+	case team == "":
+		return "", nil
+	case providerType != vcs.ProviderTypeGitHub:
+		return "", fmt.Errorf("%w: %s is only supported by the %s provider", errInvalidConfig, TeamKey, vcs.ProviderTypeGitHub)
+	case ownerType == vcs.OwnerTypeUser:
+		return "", fmt.Errorf("%w: %s requires the %q owner type", errInvalidConfig, TeamKey, vcs.OwnerTypeOrganization)
+	case graphQL:
+		return "", fmt.Errorf("%w: %s cannot be combined with %s", errInvalidConfig, TeamKey, GraphQLKey)
+	case len(affiliations) > 0:
+		return "", fmt.Errorf("%w: %s cannot be combined with %s", errInvalidConfig, TeamKey, AffiliationKey)
+	}
+	return team, nil
+}
+
+// parseAffiliations returns the relationships through which to list the
+// repositories of a ConfigMap, none if unset
+func parseAffiliations(configMap *corev1.ConfigMap) ([]vcs.Affiliation, error) {
+	var affiliations []vcs.Affiliation
+	for value := range strings.SplitSeq(configMap.Data[AffiliationKey], ",") {
+		switch affiliation := vcs.Affiliation(strings.TrimSpace(value)); affiliation {
+		case "":
+		case vcs.AffiliationOwner, vcs.AffiliationCollaborator, vcs.AffiliationOrganizationMember:
+			if !slices.Contains(affiliations, affiliation) {
+				affiliations = append(affiliations, affiliation)
+			}
+		default:
+			return nil, fmt.Errorf("%w: unknown %s %q", errInvalidConfig, AffiliationKey, affiliation)
+		}
+	}
+	return affiliations, nil
+}
+
+// maxRepositories returns the maximum number of repositories to process for a
+// ConfigMap, falling back to the manager default. Zero means no limit.
+func (r *ConfigMapReconciler) maxRepositories(configMap *corev1.ConfigMap) (int, error) {
+	value, ok := configMap.Data[MaxRepositoriesKey]
+	if !ok || value == "" {
+		return r.MaxRepositories, nil
+	}
+
+	maxRepositories, err := strconv.Atoi(value)
+	if err != nil || maxRepositories < 0 {
+		return 0, fmt.Errorf("%w: invalid %s %q", errInvalidConfig, MaxRepositoriesKey, value)
+	}
+	return maxRepositories, nil
+}
+
+// parseMaxRepoAge returns how long after their last push repositories of a
+// ConfigMap are still scanned. Zero means no limit.
+func parseMaxRepoAge(configMap *corev1.ConfigMap) (time.Duration, error) {
+	value, ok := configMap.Data[MaxRepoAgeDaysKey]
+	if !ok || value == "" {
+		return 0, nil
+	}
+
+	days, err := strconv.Atoi(value)
+	if err != nil || days < 0 {
+		return 0, fmt.Errorf("%w: invalid %s %q", errInvalidConfig, MaxRepoAgeDaysKey, value)
+	}
+	return time.Duration(days) * 24 * time.Hour, nil
+}
+
+// unavailableValue returns how repositories without scorecard data are
+// exported for a ConfigMap, falling back to the manager default
+func (r *ConfigMapReconciler) unavailableValue(configMap *corev1.ConfigMap) (UnavailableValue, error) {
+	value := configMap.Data[UnavailableValueKey]
+	if value == "" {
+		value = string(r.UnavailableValue)
+	}
+
+	unavailableValue, err := ParseUnavailableValue(value)
+	if err != nil {
+		return "", fmt.Errorf("%w: invalid %s: %w", errInvalidConfig, UnavailableValueKey, err)
+	}
+	return unavailableValue, nil
+}
+
+// passThreshold returns the lowest score of a passing check for a ConfigMap,
+// falling back to the manager default
+func (r *ConfigMapReconciler) passThreshold(configMap *corev1.ConfigMap) (int, error) {
+	value, ok := configMap.Data[PassThresholdKey]
+	if !ok || value == "" {
+		if r.PassThreshold == 0 {
+			return scorecard.DefaultPassThreshold, nil
+		}
+		return r.PassThreshold, nil
+	}
+
+	passThreshold, err := strconv.Atoi(value)
+	if err != nil || passThreshold < 1 || passThreshold > 10 {
+		return 0, fmt.Errorf("%w: invalid %s %q, must be between 1 and 10", errInvalidConfig, PassThresholdKey, value)
+	}
+	return passThreshold, nil
+}
+
+// averageScore returns the mean of scores, or false if there are none
+func averageScore(scores []float64) (float64, bool) {
+	if len(scores) == 0 {
+		return 0, false
+	}
+	var sum float64
+	for _, score := range scores {
+		sum += score
+	}
+	return sum / float64(len(scores)), true
+}
+
+// parseBool returns the boolean value of a ConfigMap data key, false if unset
+func parseBool(configMap *corev1.ConfigMap, key string) (bool, error) {
+	value, ok := configMap.Data[key]
+	if !ok || value == "" {
+		return false, nil
+	}
+
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("%w: invalid %s %q", errInvalidConfig, key, value)
+	}
+	return b, nil
+}
+
+// getVCSToken resolves the VCS token of the secret referenced by a ConfigMap,
+// empty if it references none. The manager-level default token is applied by
+// the VCS config instead, see vcs.Config.AccessToken.
+func (r *ConfigMapReconciler) getVCSToken(ctx context.Context, configMap *corev1.ConfigMap) (string, error) {
+	logger := log.FromContext(ctx)
+
+	tokenSecretName, err := organizationTokenSecret(configMap)
+	if err != nil {
+		return "", err
+	}
+	if tokenSecretName == "" {
+		return "", nil
+	}
+
+	tokenKeyName := configMap.Data[TokenSecretKeyName]
+	if tokenKeyName == "" {
+		tokenKeyName = "token" // default key name
+	}
+
+	var secret corev1.Secret
+	secretKey := client.ObjectKey{
+		Namespace: configMap.Namespace,
+		Name:      tokenSecretName,
+	}
+
+	if err := r.Get(ctx, secretKey, &secret); err != nil {
+		logger.Error(err, "Failed to fetch VCS token secret", "secret", tokenSecretName)
+		return "", err
+	}
+
+	tokenBytes, ok := secret.Data[tokenKeyName]
+	if !ok {
+		logger.Error(errTokenKeyNotFound,
+			"Failed to find token key",
+			"secret", tokenSecretName,
+			"key", tokenKeyName)
+		return "", errTokenKeyNotFound
+	}
+	return string(tokenBytes), nil
+}
+
+// parseTokenSecrets parses the value of the tokenSecret key. It returns the
+// organizations mapped to Secret names for a JSON object, and nil for a
+// plain Secret name.
+func parseTokenSecrets(value string) (map[string]string, error) {
+	if !strings.HasPrefix(strings.TrimSpace(value), "{") {
+		return nil, nil
+	}
+
+	var secrets map[string]string
+	if err := json.Unmarshal([]byte(value), &secrets); err != nil {
+		return nil, fmt.Errorf("%w: invalid %s mapping: %v", errInvalidConfig, TokenSecretKey, err)
+	}
+	return secrets, nil
+}
+
+// organizationTokenSecret returns the name of the Secret holding the VCS
+// token of the organization of a ConfigMap, empty if it references none.
+// Mapped organizations are matched case-insensitively, like on the VCS.
+func organizationTokenSecret(configMap *corev1.ConfigMap) (string, error) {
+	value := configMap.Data[TokenSecretKey]
+	secrets, err := parseTokenSecrets(value)
+	if err != nil || secrets == nil {
+		return value, err
+	}
 
-	return utils.JitterRequeue(r.RequeueInterval, r.MaxJitterPercent, logger), nil
+	organization := configMap.Data[OrganizationKey]
+	for mapped, name := range secrets {
+		if strings.EqualFold(mapped, organization) {
+			return name, nil
+		}
+	}
+	if name, ok := secrets["*"]; ok {
+		return name, nil
+	}
+	return "", fmt.Errorf("%w: no token secret mapped for organization %q in %s",
+		errInvalidConfig, organization, TokenSecretKey)
+}
+
+// gitHubApp holds the GitHub App credentials a ConfigMap refers to, with the
+// private key kept in a secret
+type gitHubApp struct {
+	appID          int64
+	installationID int64
+	keySecretName  string
+	keyName        string
+}
+
+// parseGitHubApp returns the GitHub App credentials of a ConfigMap, the zero
+// value if it does not configure app authentication
+func parseGitHubApp(configMap *corev1.ConfigMap) (gitHubApp, error) {
+	appID, installationID := configMap.Data[AppIDKey], configMap.Data[InstallationIDKey]
+	keySecretName := configMap.Data[AppPrivateKeySecretKey]
+	if appID == "" && installationID == "" && keySecretName == "" {
+		return gitHubApp{}, nil
+	}
+	if appID == "" || installationID == "" || keySecretName == "" {
+		return gitHubApp{}, fmt.Errorf("%w: %s, %s and %s must all be set",
+			errInvalidConfig, AppIDKey, InstallationIDKey, AppPrivateKeySecretKey)
+	}
+
+	app := gitHubApp{keySecretName: keySecretName, keyName: configMap.Data[AppPrivateKeySecretKeyName]}
+	if app.keyName == "" {
+		app.keyName = "private-key" // default key name
+	}
+
+	var err error
+	if app.appID, err = strconv.ParseInt(appID, 10, 64); err != nil {
+		return gitHubApp{}, fmt.Errorf("%w: invalid %s %q", errInvalidConfig, AppIDKey, appID)
+	}
+	if app.installationID, err = strconv.ParseInt(installationID, 10, 64); err != nil {
+		return gitHubApp{}, fmt.Errorf("%w: invalid %s %q", errInvalidConfig, InstallationIDKey, installationID)
+	}
+	return app, nil
+}
+
+// getGitHubAppCredentials populates the GitHub App fields of the VCS config when
+// the ConfigMap configures app authentication
+func (r *ConfigMapReconciler) getGitHubAppCredentials(
+	ctx context.Context, namespace string, app gitHubApp, config *vcs.Config,
+) error {
+	if app.keySecretName == "" {
+		return nil
+	}
+
+	var secret corev1.Secret
+	secretKey := client.ObjectKey{
+		Namespace: namespace,
+		Name:      app.keySecretName,
+	}
+	if err := r.Get(ctx, secretKey, &secret); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to fetch GitHub App private key secret", "secret", app.keySecretName)
+		return err
+	}
+
+	privateKey, ok := secret.Data[app.keyName]
+	if !ok {
+		return fmt.Errorf("%w: key %q not found in secret %s", errInvalidConfig, app.keyName, app.keySecretName)
+	}
+	config.AppID = app.appID
+	config.InstallationID = app.installationID
+	config.AppPrivateKey = privateKey
+
+	return nil
+}
+
+// getDefaultToken returns the manager-level default token, if any
+func (r *ConfigMapReconciler) getDefaultToken() (string, error) {
+	if r.DefaultTokenFile == "" {
+		return r.DefaultToken, nil
+	}
+
+	tokenBytes, err := os.ReadFile(r.DefaultTokenFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to read default token file: %w", err)
+	}
+	return strings.TrimSpace(string(tokenBytes)), nil
 }
 
 // isNotFoundError checks if an error indicates that scorecard data was not found
@@ -243,20 +1421,58 @@ func isNotFoundError(err error) bool {
 	return strings.Contains(err.Error(), "scorecard data not found for")
 }
 
+// referencedSecrets returns the Secrets a scorecard ConfigMap depends on
+func referencedSecrets(configMap *corev1.ConfigMap) []types.NamespacedName {
+	var names []string
+	if tokenSecrets, err := parseTokenSecrets(configMap.Data[TokenSecretKey]); tokenSecrets != nil {
+		// Watch every mapped Secret, although only the organization's is read
+		names = slices.Sorted(maps.Values(tokenSecrets))
+	} else if err == nil {
+		names = append(names, configMap.Data[TokenSecretKey])
+	}
+	names = append(names, configMap.Data[AppPrivateKeySecretKey])
+
+	var secrets []types.NamespacedName
+	for _, name := range names {
+		if name != "" {
+			secrets = append(secrets, types.NamespacedName{Namespace: configMap.Namespace, Name: name})
+		}
+	}
+	return secrets
+}
+
+// configMapsForSecret maps a Secret event to reconcile requests for every
+// scorecard ConfigMap that references it
+func (r *ConfigMapReconciler) configMapsForSecret(_ context.Context, secret client.Object) []reconcile.Request {
+	configMaps := r.secrets.configMapsFor(client.ObjectKeyFromObject(secret))
+
+	requests := make([]reconcile.Request, 0, len(configMaps))
+	for _, configMap := range configMaps {
+		requests = append(requests, reconcile.Request{NamespacedName: configMap})
+	}
+	return requests
+}
+
 // SetupWithManager sets up the controller with the Manager
 func (r *ConfigMapReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	// Only watch ConfigMaps with the specific label
+	// Only watch ConfigMaps with the specific label, or that still carry our
+	// finalizer after the label was removed
 	labelPredicate := predicate.NewPredicateFuncs(func(object client.Object) bool {
-		labels := object.GetLabels()
-		if labels == nil {
-			return false
-		}
-		_, hasLabel := labels[ScorecardLabelKey]
-		return hasLabel
+		_, hasLabel := object.GetLabels()[ScorecardLabelKey]
+		return hasLabel || controllerutil.ContainsFinalizer(object, MetricsFinalizer)
 	})
 
+	// Push the final metrics once the reconciles stop on shutdown
+	if r.MetricsPusher != nil {
+		if err := mgr.Add(manager.RunnableFunc(r.flushMetricsOnShutdown)); err != nil {
+			return err
+		}
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&corev1.ConfigMap{}).
-		WithEventFilter(labelPredicate).
+		For(&corev1.ConfigMap{}, builder.WithPredicates(labelPredicate, ignoreStatusAnnotationUpdates())).
+		// Re-reconcile ConfigMaps when a referenced token Secret changes
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.configMapsForSecret)).
+		WithOptions(controller.Options{MaxConcurrentReconciles: r.MaxConcurrentReconciles}).
 		Complete(r)
 }
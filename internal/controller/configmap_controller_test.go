@@ -17,10 +17,187 @@ limitations under the License.
 package controller
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
 	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"golang.org/x/time/rate"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/giantswarm/openssf-scorecard-exporter/internal/debug"
+	"github.com/giantswarm/openssf-scorecard-exporter/internal/httpclient"
+	"github.com/giantswarm/openssf-scorecard-exporter/internal/metrics"
+	"github.com/giantswarm/openssf-scorecard-exporter/internal/report"
+	"github.com/giantswarm/openssf-scorecard-exporter/internal/scorecard"
+	"github.com/giantswarm/openssf-scorecard-exporter/internal/vcs"
 )
 
+// fakeProviderType is the provider type under which fakeProvider is registered
+const fakeProviderType vcs.ProviderType = "fake"
+
+// fakeProvider is a vcs.Provider returning a fixed set of repositories
+type fakeProvider struct {
+	repos []string
+	err   error
+
+	// branches maps branch names to the commit at their head
+	branches map[string]string
+
+	// host is the VCS host in scorecard paths, github.com if empty
+	host string
+
+	// detailRequests counts the calls to GetRepositoryDetails
+	detailRequests int
+
+	// slowDetails makes GetRepositoryDetails wait for its context to end
+	slowDetails bool
+}
+
+func (p *fakeProvider) GetRepositories(_ context.Context, _ string) ([]string, error) {
+	return p.repos, p.err
+}
+
+func (p *fakeProvider) GetRepositoryDetails(ctx context.Context, _, repository string) (*vcs.Repository, error) {
+	p.detailRequests++
+	if p.slowDetails {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	return &vcs.Repository{Name: repository, DefaultBranch: "main"}, nil
+}
+
+func (p *fakeProvider) ResolveCommit(_ context.Context, _, _, branch string) (string, error) {
+	commit, ok := p.branches[branch]
+	if !ok {
+		return "", fmt.Errorf("branch %s %w", branch, vcs.ErrNotFound)
+	}
+	return commit, nil
+}
+
+func (p *fakeProvider) GetProviderType() vcs.ProviderType {
+	return fakeProviderType
+}
+
+func (p *fakeProvider) GetScorecardURL(organization, repository string) string {
+	host := p.host
+	if host == "" {
+		host = "github.com"
+	}
+	return host + "/" + organization + "/" + repository
+}
+
+// fakeSource is a scorecard.Source serving a score of 7.5 for org/repo,
+// "not found" for org/missing and an error for anything else, on any host.
+// It records the paths and tokens it was asked for.
+type fakeSource struct {
+	requests []string
+}
+
+func (s *fakeSource) GetScorecardData(
+	_ context.Context, vcsPath, token string, _ ...scorecard.FetchOption,
+) (*scorecard.ScorecardData, error) {
+	s.requests = append(s.requests, vcsPath+"|"+token)
+
+	_, path, _ := strings.Cut(vcsPath, "/")
+	switch {
+	case path == "org/repo":
+		return &scorecard.ScorecardData{
+			Score:      7.5,
+			Repository: vcsPath,
+			Timestamp:  time.Now(),
+			Checks:     []scorecard.Check{{Name: "Code-Review", Score: 8, Status: "Pass"}},
+		}, nil
+	case strings.HasPrefix(path, "org/missing"):
+		return nil, fmt.Errorf("%w for %s", scorecard.ErrNotFound, vcsPath)
+	case path == "org/down":
+		return nil, &scorecard.CircuitOpenError{RetryAfter: time.Minute}
+	case path == "org/limited":
+		return nil, &scorecard.RateLimitError{StatusCode: http.StatusTooManyRequests, RetryAfter: 2 * time.Minute}
+	default:
+		return nil, errors.New("internal error")
+	}
+}
+
+// newTestReconciler creates a reconciler backed by a fake client, the given
+// fake provider and a fake scorecard source
+func newTestReconciler(t *testing.T, provider *fakeProvider, objects ...client.Object) *ConfigMapReconciler {
+	t.Helper()
+
+	factory := vcs.NewProviderFactory()
+	factory.Register(fakeProviderType, func(*vcs.Config) (vcs.Provider, error) {
+		return provider, nil
+	})
+
+	return &ConfigMapReconciler{
+		Client:           fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(objects...).Build(),
+		Scheme:           scheme.Scheme,
+		Recorder:         record.NewFakeRecorder(100),
+		ScorecardSource:  &fakeSource{},
+		MetricsCollector: metrics.NewCollector(metrics.WithRegistry(prometheus.NewRegistry())),
+		ProviderFactory:  factory,
+		MaxJitterPercent: 10,
+		RequeueInterval:  time.Hour,
+	}
+}
+
+// newTestConfigMap creates a labeled scorecard ConfigMap using the fake provider
+func newTestConfigMap(data map[string]string) *corev1.ConfigMap {
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "scorecard-config",
+			Namespace: "default",
+			Labels:    map[string]string{ScorecardLabelKey: "true"},
+		},
+		Data: map[string]string{
+			OrganizationKey: "org",
+			ProviderTypeKey: string(fakeProviderType),
+		},
+	}
+	for k, v := range data {
+		configMap.Data[k] = v
+	}
+	return configMap
+}
+
+// testRequest is the reconcile request for newTestConfigMap
+var testRequest = ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "scorecard-config"}}
+
+// recordedEvents drains the events recorded by a fake recorder
+func recordedEvents(r *ConfigMapReconciler) []string {
+	recorder := r.Recorder.(*record.FakeRecorder)
+
+	var events []string
+	for {
+		select {
+		case event := <-recorder.Events:
+			events = append(events, event)
+		default:
+			return events
+		}
+	}
+}
+
 func TestIsNotFoundError(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -58,3 +235,2560 @@ func TestIsNotFoundError(t *testing.T) {
 		})
 	}
 }
+
+func TestConfigMapsForSecret(t *testing.T) {
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "scorecard-config",
+			Namespace: "default",
+			Labels:    map[string]string{ScorecardLabelKey: "true"},
+		},
+		Data: map[string]string{
+			OrganizationKey: "giantswarm",
+			TokenSecretKey:  "github-token",
+		},
+	}
+
+	r := &ConfigMapReconciler{
+		Client:           fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(configMap).Build(),
+		Scheme:           scheme.Scheme,
+		MetricsCollector: metrics.NewCollector(metrics.WithRegistry(prometheus.NewRegistry())),
+		ProviderFactory:  vcs.NewProviderFactory(),
+	}
+
+	// The referenced secret does not exist yet, so reconcile fails, but the
+	// reference must still be recorded so that creating the secret re-triggers it.
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "scorecard-config"}}
+	if _, err := r.Reconcile(context.Background(), req); err == nil {
+		t.Fatal("Reconcile() expected error for missing secret")
+	}
+
+	tests := []struct {
+		name     string
+		secret   types.NamespacedName
+		expected int
+	}{
+		{
+			name:     "referenced secret",
+			secret:   types.NamespacedName{Namespace: "default", Name: "github-token"},
+			expected: 1,
+		},
+		{
+			name:     "unrelated secret",
+			secret:   types.NamespacedName{Namespace: "default", Name: "other"},
+			expected: 0,
+		},
+		{
+			name:     "same name in other namespace",
+			secret:   types.NamespacedName{Namespace: "other", Name: "github-token"},
+			expected: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: tt.secret.Namespace, Name: tt.secret.Name}}
+			requests := r.configMapsForSecret(context.Background(), secret)
+			if len(requests) != tt.expected {
+				t.Fatalf("configMapsForSecret() returned %d requests, want %d", len(requests), tt.expected)
+			}
+			if tt.expected > 0 && requests[0].NamespacedName != req.NamespacedName {
+				t.Errorf("configMapsForSecret() = %v, want %v", requests[0].NamespacedName, req.NamespacedName)
+			}
+		})
+	}
+
+	// Dropping the reference stops the secret from mapping to the ConfigMap
+	r.secrets.remove(req.NamespacedName)
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "github-token"}}
+	if requests := r.configMapsForSecret(context.Background(), secret); len(requests) != 0 {
+		t.Errorf("configMapsForSecret() after remove returned %d requests, want 0", len(requests))
+	}
+}
+
+func TestGetVCSToken(t *testing.T) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "github-token", Namespace: "default"},
+		Data:       map[string][]byte{"token": []byte("secret-token")},
+	}
+	otherSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "other-token", Namespace: "default"},
+		Data:       map[string][]byte{"token": []byte("other-secret-token")},
+	}
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(tokenFile, []byte("file-token\n"), 0o600); err != nil {
+		t.Fatalf("failed to write token file: %v", err)
+	}
+
+	tests := []struct {
+		name             string
+		data             map[string]string
+		defaultToken     string
+		defaultTokenFile string
+		expected         string
+		expectedErr      error
+	}{
+		{
+			name:         "secret overrides default token",
+			data:         map[string]string{TokenSecretKey: "github-token"},
+			defaultToken: "env-token",
+			expected:     "secret-token",
+		},
+		{
+			name:         "default token used when secret absent",
+			data:         map[string]string{},
+			defaultToken: "env-token",
+			expected:     "env-token",
+		},
+		{
+			name:             "default token file overrides default token",
+			data:             map[string]string{},
+			defaultToken:     "env-token",
+			defaultTokenFile: tokenFile,
+			expected:         "file-token",
+		},
+		{
+			name:     "anonymous when neither set",
+			data:     map[string]string{},
+			expected: "",
+		},
+		{
+			name:        "token key missing from secret",
+			data:        map[string]string{TokenSecretKey: "github-token", TokenSecretKeyName: "other"},
+			expectedErr: errTokenKeyNotFound,
+		},
+		{
+			name: "secret mapped to the organization",
+			data: map[string]string{
+				OrganizationKey: "Org-B",
+				TokenSecretKey:  `{"org-a": "github-token", "org-b": "other-token"}`,
+			},
+			defaultToken: "env-token",
+			expected:     "other-secret-token",
+		},
+		{
+			name: "wildcard secret for unmapped organizations",
+			data: map[string]string{
+				OrganizationKey: "org-c",
+				TokenSecretKey:  `{"org-a": "other-token", "*": "github-token"}`,
+			},
+			expected: "secret-token",
+		},
+		{
+			name: "organization missing from the mapping",
+			data: map[string]string{
+				OrganizationKey: "org-c",
+				TokenSecretKey:  `{"org-a": "github-token"}`,
+			},
+			defaultToken: "env-token",
+			expectedErr:  errInvalidConfig,
+		},
+		{
+			name:        "invalid mapping",
+			data:        map[string]string{OrganizationKey: "org-a", TokenSecretKey: `{"org-a": }`},
+			expectedErr: errInvalidConfig,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &ConfigMapReconciler{
+				Client:           fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(secret, otherSecret).Build(),
+				DefaultToken:     tt.defaultToken,
+				DefaultTokenFile: tt.defaultTokenFile,
+			}
+			configMap := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "scorecard-config", Namespace: "default"},
+				Data:       tt.data,
+			}
+
+			token, err := r.getVCSToken(context.Background(), configMap)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("getVCSToken() error = %v, want %v", err, tt.expectedErr)
+			}
+			if err != nil {
+				return
+			}
+			defaultToken, err := r.getDefaultToken()
+			if err != nil {
+				t.Fatalf("getDefaultToken() unexpected error: %v", err)
+			}
+			config := &vcs.Config{Token: token, DefaultToken: defaultToken}
+			if got := config.AccessToken(); got != tt.expected {
+				t.Errorf("AccessToken() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestReferencedSecrets(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     map[string]string
+		expected []string
+	}{
+		{
+			name:     "token and private key secrets",
+			data:     map[string]string{TokenSecretKey: "github-token", AppPrivateKeySecretKey: "app-key"},
+			expected: []string{"github-token", "app-key"},
+		},
+		{
+			name:     "every secret of a mapping",
+			data:     map[string]string{TokenSecretKey: `{"org-b": "token-b", "org-a": "token-a"}`},
+			expected: []string{"token-a", "token-b"},
+		},
+		{
+			name:     "invalid mapping",
+			data:     map[string]string{TokenSecretKey: `{"org-a"`},
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configMap := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "scorecard-config", Namespace: "default"},
+				Data:       tt.data,
+			}
+
+			var names []string
+			for _, secret := range referencedSecrets(configMap) {
+				if secret.Namespace != "default" {
+					t.Errorf("referencedSecrets() namespace = %q, want default", secret.Namespace)
+				}
+				names = append(names, secret.Name)
+			}
+			if !slices.Equal(names, tt.expected) {
+				t.Errorf("referencedSecrets() = %v, want %v", names, tt.expected)
+			}
+		})
+	}
+}
+
+func TestScorecardSource(t *testing.T) {
+	apiSource := &fakeSource{}
+	localSource := &fakeSource{}
+
+	tests := []struct {
+		name            string
+		source          string
+		endpoint        string
+		disallowDefault bool
+		local           scorecard.Source
+		expected        scorecard.Source
+		expectedErr     error
+	}{
+		{
+			name:     "defaults to the API",
+			expected: apiSource,
+		},
+		{
+			name:     "explicit endpoint",
+			endpoint: "https://scorecard.example.com",
+			expected: endpointSource{Source: apiSource, endpoint: "https://scorecard.example.com"},
+		},
+		{
+			name:        "invalid endpoint",
+			endpoint:    "scorecard.example.com",
+			expectedErr: errInvalidConfig,
+		},
+		{
+			name:            "default endpoint disallowed",
+			source:          SourceAPI,
+			disallowDefault: true,
+			expectedErr:     errInvalidConfig,
+		},
+		{
+			name:            "explicit endpoint with default disallowed",
+			endpoint:        "https://scorecard.example.com",
+			disallowDefault: true,
+			expected:        endpointSource{Source: apiSource, endpoint: "https://scorecard.example.com"},
+		},
+		{
+			name:            "local with default disallowed",
+			source:          SourceLocal,
+			disallowDefault: true,
+			local:           localSource,
+			expected:        localSource,
+		},
+		{
+			name:     "api",
+			source:   SourceAPI,
+			local:    localSource,
+			expected: apiSource,
+		},
+		{
+			name:     "local",
+			source:   SourceLocal,
+			local:    localSource,
+			expected: localSource,
+		},
+		{
+			name:        "local not enabled",
+			source:      SourceLocal,
+			expectedErr: errInvalidConfig,
+		},
+		{
+			name:        "unknown source",
+			source:      "other",
+			local:       localSource,
+			expectedErr: errInvalidConfig,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &ConfigMapReconciler{
+				ScorecardSource:                  apiSource,
+				DisallowDefaultScorecardEndpoint: tt.disallowDefault,
+				LocalScorecardSource:             tt.local,
+			}
+
+			source, err := r.scorecardSource(newTestConfigMap(map[string]string{
+				SourceKey:               tt.source,
+				ScorecardAPIEndpointKey: tt.endpoint,
+			}))
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("scorecardSource() error = %v, want %v", err, tt.expectedErr)
+			}
+			if err == nil && source != tt.expected {
+				t.Errorf("scorecardSource() = %v, want %v", source, tt.expected)
+			}
+		})
+	}
+}
+
+func TestReconcileEvents(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     map[string]string
+		provider *fakeProvider
+		expected string
+	}{
+		{
+			name:     "missing token secret",
+			data:     map[string]string{TokenSecretKey: "missing"},
+			provider: &fakeProvider{},
+			expected: "Warning " + EventReasonSecretMissing,
+		},
+		{
+			name:     "rate limited",
+			provider: &fakeProvider{err: vcs.NewRateLimitError(fakeProviderType, "rate limit exceeded")},
+			expected: "Warning " + EventReasonRateLimited,
+		},
+		{
+			name:     "scorecard fetch failed",
+			provider: &fakeProvider{repos: []string{"broken"}},
+			expected: "Warning " + EventReasonScorecardFetchFailed,
+		},
+		{
+			name:     "reconcile succeeded",
+			provider: &fakeProvider{repos: []string{"repo", "missing"}},
+			expected: "Normal " + EventReasonReconcileSucceeded + " Exported scorecard data for 2 repositories",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestReconciler(t, tt.provider, newTestConfigMap(tt.data))
+
+			_, _ = r.Reconcile(context.Background(), testRequest)
+
+			events := recordedEvents(r)
+			if len(events) != 1 || !strings.HasPrefix(events[0], tt.expected) {
+				t.Errorf("recorded events = %v, want one starting with %q", events, tt.expected)
+			}
+		})
+	}
+}
+
+func TestReconcileScorecardSource(t *testing.T) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "github-token", Namespace: "default"},
+		Data:       map[string][]byte{"token": []byte("secret-token")},
+	}
+
+	tests := []struct {
+		name            string
+		data            map[string]string
+		defaultToken    string
+		disallowDefault bool
+		expectedAPI     []string
+		expectedLocal   []string
+	}{
+		{
+			name:        "api source",
+			data:        map[string]string{TokenSecretKey: "github-token"},
+			expectedAPI: []string{"github.com/org/repo|secret-token", "github.com/org/missing|secret-token"},
+		},
+		{
+			name:          "local source",
+			data:          map[string]string{TokenSecretKey: "github-token", SourceKey: SourceLocal},
+			expectedLocal: []string{"github.com/org/repo|secret-token", "github.com/org/missing|secret-token"},
+		},
+		{
+			name:         "api source without the default token",
+			defaultToken: "default-token",
+			expectedAPI:  []string{"github.com/org/repo|", "github.com/org/missing|"},
+		},
+		{
+			name:          "local source without the default token",
+			data:          map[string]string{SourceKey: SourceLocal},
+			defaultToken:  "default-token",
+			expectedLocal: []string{"github.com/org/repo|", "github.com/org/missing|"},
+		},
+		{
+			name:            "default endpoint disallowed",
+			data:            map[string]string{TokenSecretKey: "github-token"},
+			disallowDefault: true,
+		},
+		{
+			name: "explicit endpoint with default disallowed",
+			data: map[string]string{
+				TokenSecretKey:          "github-token",
+				ScorecardAPIEndpointKey: "https://scorecard.example.com",
+			},
+			disallowDefault: true,
+			expectedAPI:     []string{"github.com/org/repo|secret-token", "github.com/org/missing|secret-token"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestReconciler(t, &fakeProvider{repos: []string{"repo", "missing"}}, newTestConfigMap(tt.data), secret)
+			localSource := &fakeSource{}
+			r.LocalScorecardSource = localSource
+			r.DisallowDefaultScorecardEndpoint = tt.disallowDefault
+			r.DefaultToken = tt.defaultToken
+
+			if _, err := r.Reconcile(context.Background(), testRequest); err != nil {
+				t.Fatalf("Reconcile() unexpected error: %v", err)
+			}
+
+			if got := r.ScorecardSource.(*fakeSource).requests; !slices.Equal(got, tt.expectedAPI) {
+				t.Errorf("API source requests = %v, want %v", got, tt.expectedAPI)
+			}
+			if !slices.Equal(localSource.requests, tt.expectedLocal) {
+				t.Errorf("local source requests = %v, want %v", localSource.requests, tt.expectedLocal)
+			}
+		})
+	}
+}
+
+func TestReconcileScorecardAPIEndpointAuthorization(t *testing.T) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "github-token", Namespace: "default"},
+		Data:       map[string][]byte{"token": []byte("secret-token")},
+	}
+
+	tests := []struct {
+		name string
+		data map[string]string
+	}{
+		{name: "default token"},
+		{name: "token secret", data: map[string]string{TokenSecretKey: "github-token"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var authorizations []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				authorizations = append(authorizations, r.Header.Get("Authorization"))
+				_, _ = w.Write([]byte(`{"score": 7.5, "repo": {"name": "github.com/org/repo"}}`))
+			}))
+			t.Cleanup(server.Close)
+
+			data := map[string]string{ScorecardAPIEndpointKey: server.URL}
+			maps.Copy(data, tt.data)
+			r := newTestReconciler(t, &fakeProvider{repos: []string{"repo"}}, newTestConfigMap(data), secret)
+			r.ScorecardSource = scorecard.NewClient()
+			r.DefaultToken = "default-token"
+
+			if _, err := r.Reconcile(context.Background(), testRequest); err != nil {
+				t.Fatalf("Reconcile() unexpected error: %v", err)
+			}
+
+			// The endpoint of the config never receives the operator's tokens
+			if !slices.Equal(authorizations, []string{""}) {
+				t.Errorf("Authorization headers = %q, want one empty", authorizations)
+			}
+		})
+	}
+}
+
+func TestReconcileSourceLabel(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     map[string]string
+		expected string
+	}{
+		{
+			name:     "public API",
+			expected: scorecard.DataSourceAPI,
+		},
+		{
+			name:     "mirror",
+			data:     map[string]string{ScorecardAPIEndpointKey: "https://scorecard.example.com"},
+			expected: scorecard.DataSourceMirror,
+		},
+		{
+			name:     "local",
+			data:     map[string]string{SourceKey: SourceLocal},
+			expected: scorecard.DataSourceLocal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestReconciler(t, &fakeProvider{repos: []string{"missing"}}, newTestConfigMap(tt.data))
+			r.LocalScorecardSource = &fakeSource{}
+			registry := prometheus.NewRegistry()
+			r.MetricsCollector = metrics.NewCollector(metrics.WithRegistry(registry))
+
+			if _, err := r.Reconcile(context.Background(), testRequest); err != nil {
+				t.Fatalf("Reconcile() unexpected error: %v", err)
+			}
+
+			// Unavailable data is labeled with the source of the ConfigMap
+			expected := map[string]float64{tt.expected: -1}
+			if scores := gaugeValues(t, registry, "openssf_scorecard_overall_score", "source"); !maps.Equal(scores, expected) {
+				t.Errorf("overall scores by source = %v, want %v", scores, expected)
+			}
+		})
+	}
+}
+
+// gaugeValues returns the values of a gauge keyed by the value of one label
+func gaugeValues(t *testing.T, gatherer prometheus.Gatherer, name, labelName string) map[string]float64 {
+	t.Helper()
+
+	families, err := gatherer.Gather()
+	if err != nil {
+		t.Fatalf("failed to gather metrics: %v", err)
+	}
+
+	values := map[string]float64{}
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == labelName {
+					values[label.GetValue()] = metric.GetGauge().GetValue()
+				}
+			}
+		}
+	}
+	return values
+}
+
+// overallScores returns the exported overall score per repository
+func overallScores(t *testing.T, gatherer prometheus.Gatherer) map[string]float64 {
+	t.Helper()
+	return gaugeValues(t, gatherer, "openssf_scorecard_overall_score", "repository")
+}
+
+func TestReconcile(t *testing.T) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "github-token", Namespace: "default"},
+		Data:       map[string][]byte{"token": []byte("secret-token")},
+	}
+
+	tests := []struct {
+		name           string
+		data           map[string]string
+		provider       *fakeProvider
+		expectErr      bool
+		expectRequeue  time.Duration
+		expectJitter   bool
+		expectLastErr  string
+		expectRepos    string
+		expectedScores map[string]float64
+	}{
+		{
+			name:           "missing organization",
+			data:           map[string]string{OrganizationKey: ""},
+			provider:       &fakeProvider{repos: []string{"repo"}},
+			expectLastErr:  `missing required field "organization"`,
+			expectedScores: map[string]float64{},
+		},
+		{
+			name:           "missing token secret",
+			data:           map[string]string{TokenSecretKey: "missing"},
+			provider:       &fakeProvider{repos: []string{"repo"}},
+			expectErr:      true,
+			expectLastErr:  `secrets "missing" not found`,
+			expectedScores: map[string]float64{},
+		},
+		{
+			name:           "token key not found",
+			data:           map[string]string{TokenSecretKey: "github-token", TokenSecretKeyName: "other"},
+			provider:       &fakeProvider{repos: []string{"repo"}},
+			expectLastErr:  errTokenKeyNotFound.Error(),
+			expectedScores: map[string]float64{},
+		},
+		{
+			name: "rate limit requeues after retry period",
+			provider: &fakeProvider{
+				err: vcs.NewRateLimitError(fakeProviderType, "rate limit exceeded").WithRetryAfter(5 * time.Minute),
+			},
+			expectRequeue:  5 * time.Minute,
+			expectLastErr:  "rate limit exceeded",
+			expectedScores: map[string]float64{},
+		},
+		{
+			name: "transient failure requeues with backoff",
+			provider: &fakeProvider{
+				err: vcs.NewTransientError(fakeProviderType, http.StatusInternalServerError, "internal error"),
+			},
+			expectRequeue:  transientBackoffBase,
+			expectLastErr:  "internal error",
+			expectedScores: map[string]float64{},
+		},
+		{
+			name: "authentication failure requeues after a longer delay",
+			provider: &fakeProvider{
+				err: vcs.NewAuthError(fakeProviderType, http.StatusUnauthorized, "Bad credentials"),
+			},
+			expectRequeue:  authFailureRequeueDelay,
+			expectLastErr:  "Bad credentials",
+			expectedScores: map[string]float64{},
+		},
+		{
+			name: "other failure returns error",
+			provider: &fakeProvider{
+				err: errors.New("unexpected response"),
+			},
+			expectErr:      true,
+			expectLastErr:  "unexpected response",
+			expectedScores: map[string]float64{},
+		},
+		{
+			name:           "open scorecard circuit breaker requeues after cooldown",
+			provider:       &fakeProvider{repos: []string{"repo", "down"}},
+			expectRequeue:  time.Minute,
+			expectLastErr:  "circuit breaker open",
+			expectedScores: map[string]float64{"repo": 7.5},
+		},
+		{
+			name:           "scorecard API rate limit requeues after retry delay",
+			provider:       &fakeProvider{repos: []string{"repo", "limited"}},
+			expectRequeue:  2 * time.Minute,
+			expectLastErr:  "rate limit exceeded",
+			expectedScores: map[string]float64{"repo": 7.5},
+		},
+		{
+			name:           "unavailable scorecard data exported as -1",
+			provider:       &fakeProvider{repos: []string{"missing"}},
+			expectJitter:   true,
+			expectRepos:    "1",
+			expectedScores: map[string]float64{"missing": -1},
+		},
+		{
+			name:           "happy path",
+			data:           map[string]string{TokenSecretKey: "github-token"},
+			provider:       &fakeProvider{repos: []string{"repo", "missing"}},
+			expectJitter:   true,
+			expectRepos:    "2",
+			expectedScores: map[string]float64{"repo": 7.5, "missing": -1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestReconciler(t, tt.provider, newTestConfigMap(tt.data), secret)
+			registry := prometheus.NewRegistry()
+			r.MetricsCollector = metrics.NewCollector(metrics.WithRegistry(registry))
+
+			result, err := r.Reconcile(context.Background(), testRequest)
+			if (err != nil) != tt.expectErr {
+				t.Fatalf("Reconcile() error = %v, expectErr %v", err, tt.expectErr)
+			}
+
+			if tt.expectJitter {
+				if result.RequeueAfter < 54*time.Minute || result.RequeueAfter > 66*time.Minute {
+					t.Errorf("Reconcile() RequeueAfter = %v, want 1h +/- 10%%", result.RequeueAfter)
+				}
+			} else if result.RequeueAfter != tt.expectRequeue {
+				t.Errorf("Reconcile() RequeueAfter = %v, want %v", result.RequeueAfter, tt.expectRequeue)
+			}
+
+			var configMap corev1.ConfigMap
+			if err := r.Get(context.Background(), testRequest.NamespacedName, &configMap); err != nil {
+				t.Fatalf("failed to get ConfigMap: %v", err)
+			}
+			if lastErr := configMap.Annotations[LastErrorAnnotation]; !strings.Contains(lastErr, tt.expectLastErr) ||
+				(tt.expectLastErr == "" && lastErr != "") {
+				t.Errorf("%s = %q, want it to contain %q", LastErrorAnnotation, lastErr, tt.expectLastErr)
+			}
+			if repos := configMap.Annotations[RepositoryCountAnnotation]; repos != tt.expectRepos {
+				t.Errorf("%s = %q, want %q", RepositoryCountAnnotation, repos, tt.expectRepos)
+			}
+
+			if scores := overallScores(t, registry); !maps.Equal(scores, tt.expectedScores) {
+				t.Errorf("overall scores = %v, want %v", scores, tt.expectedScores)
+			}
+		})
+	}
+}
+
+func TestReconcileRef(t *testing.T) {
+	tests := []struct {
+		name           string
+		data           map[string]string
+		expectedQuery  string
+		expectedScores map[string]float64
+		expectLastErr  string
+	}{
+		{
+			name:           "latest report",
+			expectedQuery:  "",
+			expectedScores: map[string]float64{"repo": 7.5},
+		},
+		{
+			name:           "commit",
+			data:           map[string]string{CommitKey: "abc123"},
+			expectedQuery:  "commit=abc123",
+			expectedScores: map[string]float64{"repo": 7.5},
+		},
+		{
+			name:           "branch resolved to commit",
+			data:           map[string]string{BranchKey: "release-1.0"},
+			expectedQuery:  "commit=def456",
+			expectedScores: map[string]float64{"repo": 7.5},
+		},
+		{
+			name:           "missing branch exported as -1",
+			data:           map[string]string{BranchKey: "missing"},
+			expectedScores: map[string]float64{"repo": -1},
+		},
+		{
+			name:           "branch and commit",
+			data:           map[string]string{BranchKey: "release-1.0", CommitKey: "abc123"},
+			expectedScores: map[string]float64{},
+			expectLastErr:  "only one of branch and commit may be set",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var query string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				query = r.URL.RawQuery
+				_, _ = w.Write([]byte(`{"score": 7.5, "repo": {"name": "github.com/org/repo"}}`))
+			}))
+			t.Cleanup(server.Close)
+
+			provider := &fakeProvider{repos: []string{"repo"}, branches: map[string]string{"release-1.0": "def456"}}
+			r := newTestReconciler(t, provider, newTestConfigMap(tt.data))
+			r.ScorecardSource = scorecard.NewClient(scorecard.WithAPIEndpoint(server.URL))
+			registry := prometheus.NewRegistry()
+			r.MetricsCollector = metrics.NewCollector(metrics.WithRegistry(registry))
+
+			if _, err := r.Reconcile(context.Background(), testRequest); err != nil {
+				t.Fatalf("Reconcile() unexpected error: %v", err)
+			}
+
+			if query != tt.expectedQuery {
+				t.Errorf("scorecard API query = %q, want %q", query, tt.expectedQuery)
+			}
+			if scores := overallScores(t, registry); !maps.Equal(scores, tt.expectedScores) {
+				t.Errorf("overall scores = %v, want %v", scores, tt.expectedScores)
+			}
+
+			var configMap corev1.ConfigMap
+			if err := r.Get(context.Background(), testRequest.NamespacedName, &configMap); err != nil {
+				t.Fatalf("failed to get ConfigMap: %v", err)
+			}
+			if lastErr := configMap.Annotations[LastErrorAnnotation]; !strings.Contains(lastErr, tt.expectLastErr) {
+				t.Errorf("%s = %q, want it to contain %q", LastErrorAnnotation, lastErr, tt.expectLastErr)
+			}
+		})
+	}
+}
+
+func TestReconcileDryRun(t *testing.T) {
+	configMap := newTestConfigMap(nil)
+	configMap.Annotations = map[string]string{DryRunAnnotation: "true"}
+
+	source := &fakeSource{}
+	r := newTestReconciler(t, &fakeProvider{repos: []string{"repo", "missing"}}, configMap)
+	r.ScorecardSource = source
+	registry := prometheus.NewRegistry()
+	r.MetricsCollector = metrics.NewCollector(metrics.WithRegistry(registry))
+
+	// Metrics exported before switching to dry run are removed
+	r.MetricsCollector.UpdateMetrics(testRequest.String(), "github.com", "org", "repo", &scorecard.ScorecardData{Score: 5})
+
+	if _, err := r.Reconcile(context.Background(), testRequest); err != nil {
+		t.Fatalf("Reconcile() unexpected error: %v", err)
+	}
+
+	if len(source.requests) != 0 {
+		t.Errorf("scorecard requests = %v, want none in dry run", source.requests)
+	}
+	if scores := overallScores(t, registry); len(scores) != 0 {
+		t.Errorf("overall scores = %v, want none in dry run", scores)
+	}
+
+	var updated corev1.ConfigMap
+	if err := r.Get(context.Background(), testRequest.NamespacedName, &updated); err != nil {
+		t.Fatalf("failed to get ConfigMap: %v", err)
+	}
+	if repos := updated.Annotations[RepositoryCountAnnotation]; repos != "2" {
+		t.Errorf("%s = %q, want 2", RepositoryCountAnnotation, repos)
+	}
+
+	events := recordedEvents(r)
+	if len(events) != 1 || !strings.HasPrefix(events[0], "Normal "+EventReasonDryRun) {
+		t.Errorf("recorded events = %v, want one %s event", events, EventReasonDryRun)
+	}
+}
+
+func TestReconcileRepositoryCounts(t *testing.T) {
+	r := newTestReconciler(t, &fakeProvider{repos: []string{"repo", "missing"}}, newTestConfigMap(nil))
+	registry := prometheus.NewRegistry()
+	r.MetricsCollector = metrics.NewCollector(metrics.WithRegistry(registry))
+
+	if _, err := r.Reconcile(context.Background(), testRequest); err != nil {
+		t.Fatalf("Reconcile() unexpected error: %v", err)
+	}
+
+	config := testRequest.String()
+	if total := gaugeValues(t, registry, "openssf_scorecard_repositories", "config"); total[config] != 2 {
+		t.Errorf("repositories = %v, want 2", total)
+	}
+	if withData := gaugeValues(t, registry, "openssf_scorecard_repositories_with_data", "config"); withData[config] != 1 {
+		t.Errorf("repositories_with_data = %v, want 1", withData)
+	}
+	if unavailable := gaugeValues(t, registry, "openssf_scorecard_unavailable_repositories", "config"); unavailable[config] != 1 {
+		t.Errorf("unavailable_repositories = %v, want 1", unavailable)
+	}
+}
+
+func TestReconcileInitialSyncWindow(t *testing.T) {
+	ctx := context.Background()
+	r := newTestReconciler(t, &fakeProvider{repos: []string{"repo"}}, newTestConfigMap(nil))
+	source := r.ScorecardSource.(*fakeSource)
+	now := time.Now()
+	r.initialSync.now = func() time.Time { return now }
+	r.InitialSyncWindow = 5 * time.Minute
+
+	// The first reconcile is postponed within the window
+	result, err := r.Reconcile(ctx, testRequest)
+	if err != nil {
+		t.Fatalf("Reconcile() unexpected error: %v", err)
+	}
+	if result.RequeueAfter < 0 || result.RequeueAfter >= r.InitialSyncWindow {
+		t.Errorf("Reconcile() RequeueAfter = %v, want within [0, %v)", result.RequeueAfter, r.InitialSyncWindow)
+	}
+	if result.RequeueAfter > 0 && len(source.requests) != 0 {
+		t.Errorf("scorecard requests = %v before the initial sync, want none", source.requests)
+	}
+
+	// Once its time has come, the config is reconciled
+	now = now.Add(result.RequeueAfter)
+	result, err = r.Reconcile(ctx, testRequest)
+	if err != nil {
+		t.Fatalf("Reconcile() unexpected error: %v", err)
+	}
+	if result.RequeueAfter < time.Hour/2 {
+		t.Errorf("Reconcile() RequeueAfter = %v, want the requeue interval", result.RequeueAfter)
+	}
+	if len(source.requests) != 1 {
+		t.Errorf("scorecard requests = %v, want one", source.requests)
+	}
+}
+
+func TestReconcileNamespaceLabel(t *testing.T) {
+	configMap := newTestConfigMap(nil)
+	configMap.Namespace = "team-a"
+	r := newTestReconciler(t, &fakeProvider{repos: []string{"repo"}}, configMap)
+	registry := prometheus.NewRegistry()
+	r.MetricsCollector = metrics.NewCollector(metrics.WithRegistry(registry), metrics.WithNamespaceLabel(true))
+
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "team-a", Name: configMap.Name}}
+	if _, err := r.Reconcile(context.Background(), request); err != nil {
+		t.Fatalf("Reconcile() unexpected error: %v", err)
+	}
+
+	for _, name := range []string{"openssf_scorecard_overall_score", "openssf_scorecard_repositories"} {
+		values := gaugeValues(t, registry, name, metrics.NamespaceLabel)
+		if len(values) != 1 || values["team-a"] == 0 {
+			t.Errorf("%s by namespace = %v, want a series with namespace team-a", name, values)
+		}
+	}
+}
+
+func TestReconcileWatchedConfigs(t *testing.T) {
+	ctx := context.Background()
+	other := newTestConfigMap(nil)
+	other.Name = "other-config"
+	r := newTestReconciler(t, &fakeProvider{repos: []string{"repo"}}, newTestConfigMap(nil), other)
+	registry := prometheus.NewRegistry()
+	r.MetricsCollector = metrics.NewCollector(metrics.WithRegistry(registry))
+
+	expectWatched := func(expected int) {
+		t.Helper()
+		want := fmt.Sprintf(`
+# HELP openssf_scorecard_watched_configs Number of scorecard ConfigMaps and ScorecardTargets tracked by the controller
+# TYPE openssf_scorecard_watched_configs gauge
+openssf_scorecard_watched_configs %d
+`, expected)
+		if err := testutil.GatherAndCompare(registry, strings.NewReader(want), "openssf_scorecard_watched_configs"); err != nil {
+			t.Error(err)
+		}
+	}
+
+	for _, req := range []ctrl.Request{testRequest, {NamespacedName: client.ObjectKeyFromObject(other)}} {
+		if _, err := r.Reconcile(ctx, req); err != nil {
+			t.Fatalf("Reconcile(%v) unexpected error: %v", req, err)
+		}
+	}
+	expectWatched(2)
+
+	// Reconciling a config again does not count it twice
+	if _, err := r.Reconcile(ctx, testRequest); err != nil {
+		t.Fatalf("Reconcile() unexpected error: %v", err)
+	}
+	expectWatched(2)
+
+	// Deleted configs are no longer counted
+	var configMap corev1.ConfigMap
+	if err := r.Get(ctx, testRequest.NamespacedName, &configMap); err != nil {
+		t.Fatalf("failed to get ConfigMap: %v", err)
+	}
+	if err := r.Delete(ctx, &configMap); err != nil {
+		t.Fatalf("failed to delete ConfigMap: %v", err)
+	}
+	if _, err := r.Reconcile(ctx, testRequest); err != nil {
+		t.Fatalf("Reconcile() unexpected error: %v", err)
+	}
+	expectWatched(1)
+}
+
+func TestReconcileCustomOverallScore(t *testing.T) {
+	tests := []struct {
+		name      string
+		data      map[string]string
+		expected  map[string]float64
+		expectErr string
+	}{
+		{name: "no weights", expected: map[string]float64{}},
+		{
+			name:     "weighted checks",
+			data:     map[string]string{CheckWeightsKey: "Code-Review=2,Fuzzing=1"},
+			expected: map[string]float64{"repo": 8},
+		},
+		{
+			name:     "weighted checks not exported",
+			data:     map[string]string{CheckWeightsKey: "Code-Review=2", ChecksKey: "Fuzzing"},
+			expected: map[string]float64{"repo": 8},
+		},
+		{
+			name:      "invalid weights",
+			data:      map[string]string{CheckWeightsKey: "Code-Review=0"},
+			expected:  map[string]float64{},
+			expectErr: "invalid weight",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestReconciler(t, &fakeProvider{repos: []string{"repo", "missing"}}, newTestConfigMap(tt.data))
+			registry := prometheus.NewRegistry()
+			r.MetricsCollector = metrics.NewCollector(metrics.WithRegistry(registry))
+
+			if _, err := r.Reconcile(context.Background(), testRequest); err != nil {
+				t.Fatalf("Reconcile() unexpected error: %v", err)
+			}
+
+			scores := gaugeValues(t, registry, "openssf_scorecard_custom_overall_score", "repository")
+			if !maps.Equal(scores, tt.expected) {
+				t.Errorf("custom_overall_score = %v, want %v", scores, tt.expected)
+			}
+
+			var configMap corev1.ConfigMap
+			if err := r.Get(context.Background(), testRequest.NamespacedName, &configMap); err != nil {
+				t.Fatalf("failed to get ConfigMap: %v", err)
+			}
+			if lastErr := configMap.Annotations[LastErrorAnnotation]; !strings.Contains(lastErr, tt.expectErr) ||
+				(tt.expectErr == "" && lastErr != "") {
+				t.Errorf("%s = %q, want it to contain %q", LastErrorAnnotation, lastErr, tt.expectErr)
+			}
+		})
+	}
+}
+
+func TestReconcileCoverageRatio(t *testing.T) {
+	// Two of the four repositories of the organization pass the filters
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Query().Get("page") != "1" {
+			_, _ = w.Write([]byte(`[]`))
+			return
+		}
+		_, _ = w.Write([]byte(`[
+			{"name": "repo"},
+			{"name": "missing-repo"},
+			{"name": "fork", "fork": true},
+			{"name": "archived", "archived": true}
+		]`))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		data     map[string]string
+		expected float64
+	}{
+		{name: "filtered repositories", expected: 0.5},
+		{name: "repository limit", data: map[string]string{MaxRepositoriesKey: "1"}, expected: 0.25},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestReconciler(t, nil, newTestConfigMap(tt.data))
+			r.ProviderFactory.Register(fakeProviderType, func(*vcs.Config) (vcs.Provider, error) {
+				return vcs.NewGiteaProvider(&vcs.Config{Type: vcs.ProviderTypeGitea, BaseURL: server.URL})
+			})
+			registry := prometheus.NewRegistry()
+			r.MetricsCollector = metrics.NewCollector(metrics.WithRegistry(registry))
+
+			if _, err := r.Reconcile(context.Background(), testRequest); err != nil {
+				t.Fatalf("Reconcile() unexpected error: %v", err)
+			}
+
+			ratios := gaugeValues(t, registry, "openssf_scorecard_coverage_ratio", "organization")
+			if ratio, ok := ratios["org"]; !ok || ratio != tt.expected {
+				t.Errorf("coverage_ratio = %v, want %v", ratios, tt.expected)
+			}
+		})
+	}
+}
+
+func TestReconcileBudget(t *testing.T) {
+	r := newTestReconciler(t, &fakeProvider{repos: []string{"repo", "missing-a", "missing-b"}}, newTestConfigMap(nil))
+	registry := prometheus.NewRegistry()
+	r.MetricsCollector = metrics.NewCollector(metrics.WithRegistry(registry))
+
+	// The budget is spent as soon as the first repository is exported, so
+	// the cycle over 3 repositories takes 3 reconciles
+	r.ReconcileBudget = time.Nanosecond
+
+	for i, expected := range [][]string{{"repo"}, {"missing-a", "repo"}} {
+		result, err := r.Reconcile(context.Background(), testRequest)
+		if err != nil {
+			t.Fatalf("Reconcile() #%d unexpected error: %v", i+1, err)
+		}
+		if result.RequeueAfter != partialReconcileRequeueDelay {
+			t.Errorf("Reconcile() #%d RequeueAfter = %v, want %v", i+1, result.RequeueAfter, partialReconcileRequeueDelay)
+		}
+		if exported := slices.Sorted(maps.Keys(overallScores(t, registry))); !slices.Equal(exported, expected) {
+			t.Errorf("after reconcile #%d, exported repositories = %v, want %v", i+1, exported, expected)
+		}
+		events := recordedEvents(r)
+		if !slices.ContainsFunc(events, func(event string) bool {
+			return strings.Contains(event, EventReasonPartialReconcile+" Exported scorecard data for 1 of 3 repositories")
+		}) {
+			t.Errorf("events after reconcile #%d = %v, want a %s event", i+1, events, EventReasonPartialReconcile)
+		}
+
+		// Aggregates of an incomplete cycle are not published
+		if totals := gaugeValues(t, registry, "openssf_scorecard_repositories", "config"); len(totals) != 0 {
+			t.Errorf("after reconcile #%d, repositories = %v, want none before the cycle completes", i+1, totals)
+		}
+	}
+
+	result, err := r.Reconcile(context.Background(), testRequest)
+	if err != nil {
+		t.Fatalf("Reconcile() #3 unexpected error: %v", err)
+	}
+	if result.RequeueAfter == partialReconcileRequeueDelay {
+		t.Errorf("Reconcile() #3 RequeueAfter = %v, want the requeue interval", result.RequeueAfter)
+	}
+	if exported := slices.Sorted(maps.Keys(overallScores(t, registry))); !slices.Equal(exported, []string{"missing-a", "missing-b", "repo"}) {
+		t.Errorf("after reconcile #3, exported repositories = %v, want all", exported)
+	}
+	if cycle := r.progress.cycle(testRequest.NamespacedName); cycle.offset != 0 {
+		t.Errorf("cycle offset = %d after a complete cycle, want 0", cycle.offset)
+	}
+
+	want := `
+# HELP openssf_scorecard_partial_reconciles_total Total number of reconciles that ran out of their time budget before exporting all repositories
+# TYPE openssf_scorecard_partial_reconciles_total counter
+openssf_scorecard_partial_reconciles_total{config="default/scorecard-config"} 2
+# HELP openssf_scorecard_repositories_with_data Number of repositories of a config with scorecard data available
+# TYPE openssf_scorecard_repositories_with_data gauge
+openssf_scorecard_repositories_with_data{config="default/scorecard-config",organization="org"} 1
+# HELP openssf_scorecard_unavailable_repositories Number of repositories of a config for which no scorecard data was found as of the last reconcile
+# TYPE openssf_scorecard_unavailable_repositories gauge
+openssf_scorecard_unavailable_repositories{config="default/scorecard-config",organization="org"} 2
+`
+	if err := testutil.GatherAndCompare(registry, strings.NewReader(want),
+		"openssf_scorecard_partial_reconciles_total",
+		"openssf_scorecard_repositories_with_data",
+		"openssf_scorecard_unavailable_repositories",
+	); err != nil {
+		t.Error(err)
+	}
+	if timestamps := gaugeValues(t, registry, "openssf_scorecard_config_last_success_timestamp", "config"); len(timestamps) != 1 {
+		t.Errorf("config_last_success_timestamp = %v, want it set once the cycle completes", timestamps)
+	}
+
+	// The next cycle starts over with the first repository
+	result, err = r.Reconcile(context.Background(), testRequest)
+	if err != nil {
+		t.Fatalf("Reconcile() #4 unexpected error: %v", err)
+	}
+	if result.RequeueAfter != partialReconcileRequeueDelay {
+		t.Errorf("Reconcile() #4 RequeueAfter = %v, want %v", result.RequeueAfter, partialReconcileRequeueDelay)
+	}
+	if cycle := r.progress.cycle(testRequest.NamespacedName); cycle.offset != 1 {
+		t.Errorf("cycle offset = %d after the first reconcile of a new cycle, want 1", cycle.offset)
+	}
+}
+
+func TestReconcileConfigLastSuccess(t *testing.T) {
+	tests := []struct {
+		name          string
+		repos         []string
+		data          map[string]string
+		expectSuccess bool
+	}{
+		{name: "successful reconcile", repos: []string{"repo", "missing"}, expectSuccess: true},
+		{name: "invalid config", repos: []string{"repo"}, data: map[string]string{CheckWeightsKey: "Code-Review"}},
+		{name: "scorecard API unavailable", repos: []string{"down"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestReconciler(t, &fakeProvider{repos: tt.repos}, newTestConfigMap(tt.data))
+			registry := prometheus.NewRegistry()
+			r.MetricsCollector = metrics.NewCollector(metrics.WithRegistry(registry))
+
+			before := float64(time.Now().Unix())
+			_, _ = r.Reconcile(context.Background(), testRequest)
+
+			timestamps := gaugeValues(t, registry, "openssf_scorecard_config_last_success_timestamp", "config")
+			timestamp, ok := timestamps[testRequest.String()]
+			if ok != tt.expectSuccess {
+				t.Fatalf("config_last_success_timestamp = %v, want it set %v", timestamps, tt.expectSuccess)
+			}
+			if ok && timestamp < before {
+				t.Errorf("config_last_success_timestamp = %v, want at least %v", timestamp, before)
+			}
+		})
+	}
+}
+
+func TestReconcileConfigChange(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     map[string]string
+		listErr  error
+		expected map[string]float64
+	}{
+		{
+			name:     "changed filter resets series",
+			data:     map[string]string{VisibilityKey: "public"},
+			expected: map[string]float64{"repo": 7.5},
+		},
+		{
+			name:     "unchanged config keeps series",
+			expected: map[string]float64{"repo": 7.5, "missing": -1},
+		},
+		{
+			name:     "changed pass threshold keeps series",
+			data:     map[string]string{PassThresholdKey: "5"},
+			expected: map[string]float64{"repo": 7.5, "missing": -1},
+		},
+		{
+			name:     "failed listing keeps series",
+			data:     map[string]string{VisibilityKey: "public"},
+			listErr:  vcs.NewRateLimitError(fakeProviderType, "rate limit exceeded").WithRetryAfter(time.Minute),
+			expected: map[string]float64{"repo": 7.5, "missing": -1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := &fakeProvider{repos: []string{"repo", "missing"}}
+			r := newTestReconciler(t, provider, newTestConfigMap(nil))
+			registry := prometheus.NewRegistry()
+			r.MetricsCollector = metrics.NewCollector(metrics.WithRegistry(registry))
+
+			if _, err := r.Reconcile(context.Background(), testRequest); err != nil {
+				t.Fatalf("Reconcile() unexpected error: %v", err)
+			}
+
+			// The provider no longer lists a repository, as a narrowed
+			// filter would
+			provider.repos = []string{"repo"}
+			provider.err = tt.listErr
+			if tt.data != nil {
+				var configMap corev1.ConfigMap
+				if err := r.Get(context.Background(), testRequest.NamespacedName, &configMap); err != nil {
+					t.Fatalf("failed to get ConfigMap: %v", err)
+				}
+				maps.Copy(configMap.Data, tt.data)
+				if err := r.Update(context.Background(), &configMap); err != nil {
+					t.Fatalf("failed to update ConfigMap: %v", err)
+				}
+			}
+			if _, err := r.Reconcile(context.Background(), testRequest); err != nil {
+				t.Fatalf("Reconcile() unexpected error: %v", err)
+			}
+
+			if scores := overallScores(t, registry); !maps.Equal(scores, tt.expected) {
+				t.Errorf("overall scores = %v, want %v", scores, tt.expected)
+			}
+		})
+	}
+}
+
+func TestReconcileSecondaryRateLimit(t *testing.T) {
+	secondary := vcs.NewRateLimitError(fakeProviderType, "secondary rate limit exceeded").WithRetryAfter(time.Minute)
+	secondary.Secondary = true
+	longRetry := vcs.NewRateLimitError(fakeProviderType, "secondary rate limit exceeded").WithRetryAfter(time.Hour)
+	longRetry.Secondary = true
+	primary := vcs.NewRateLimitError(fakeProviderType, "rate limit exceeded").WithRetryAfter(time.Minute)
+
+	provider := &fakeProvider{}
+	r := newTestReconciler(t, provider, newTestConfigMap(nil))
+
+	steps := []struct {
+		name     string
+		err      error
+		expected time.Duration
+	}{
+		{name: "first secondary rate limit", err: secondary, expected: 10 * time.Minute},
+		{name: "repeated secondary rate limit", err: secondary, expected: 20 * time.Minute},
+		{name: "longer retry after requested", err: longRetry, expected: time.Hour},
+		{name: "escalated beyond retry after", err: secondary, expected: 80 * time.Minute},
+		{name: "capped", err: secondary, expected: 2 * time.Hour},
+		{name: "primary rate limit not escalated", err: primary, expected: time.Minute},
+		{name: "successful reconcile", expected: time.Hour},
+		{name: "secondary rate limit after success", err: secondary, expected: 10 * time.Minute},
+	}
+	for _, step := range steps {
+		provider.err = step.err
+		provider.repos = []string{"repo"}
+		result, err := r.Reconcile(context.Background(), testRequest)
+		if err != nil {
+			t.Fatalf("%s: Reconcile() unexpected error: %v", step.name, err)
+		}
+		// Successful reconciles are jittered by up to 10%
+		if result.RequeueAfter < step.expected*9/10 || result.RequeueAfter > step.expected*11/10 ||
+			step.err != nil && result.RequeueAfter != step.expected {
+			t.Errorf("%s: RequeueAfter = %v, want %v", step.name, result.RequeueAfter, step.expected)
+		}
+	}
+}
+
+func TestReconcileGlobalRateLimit(t *testing.T) {
+	var mu sync.Mutex
+	var requests []time.Time
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests = append(requests, time.Now())
+		mu.Unlock()
+		http.NotFound(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	other := newTestConfigMap(nil)
+	other.Name = "other-config"
+	r := newTestReconciler(t, &fakeProvider{repos: []string{"a", "b", "c"}}, newTestConfigMap(nil), other)
+
+	// Both configs fetch through the same transport, paced to one request
+	// every 25ms without bursts
+	const interval = 25 * time.Millisecond
+	limiter := rate.NewLimiter(rate.Every(interval), 1)
+	r.ScorecardSource = scorecard.NewClient(
+		scorecard.WithAPIEndpoint(server.URL),
+		scorecard.WithTransport(httpclient.NewRateLimitedTransport(http.DefaultTransport, limiter)),
+	)
+
+	var wg sync.WaitGroup
+	for _, req := range []ctrl.Request{testRequest, {NamespacedName: types.NamespacedName{Namespace: "default", Name: other.Name}}} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := r.Reconcile(context.Background(), req); err != nil {
+				t.Errorf("Reconcile(%s) unexpected error: %v", req, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(requests) != 6 {
+		t.Fatalf("scorecard requests = %d, want 6", len(requests))
+	}
+	slices.SortFunc(requests, func(a, b time.Time) int { return a.Compare(b) })
+	if elapsed := requests[len(requests)-1].Sub(requests[0]); elapsed < 5*interval*9/10 {
+		t.Errorf("6 requests of two reconciles were sent within %v, want them paced over at least %v", elapsed, 5*interval)
+	}
+}
+
+func TestReconcileAuthenticated(t *testing.T) {
+	tests := []struct {
+		name         string
+		defaultToken string
+		expected     int
+	}{
+		{name: "tokened config", defaultToken: "token", expected: 1},
+		{name: "anonymous config", expected: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestReconciler(t, &fakeProvider{repos: []string{"repo"}}, newTestConfigMap(nil))
+			registry := prometheus.NewRegistry()
+			r.MetricsCollector = metrics.NewCollector(metrics.WithRegistry(registry))
+			r.DefaultToken = tt.defaultToken
+
+			if _, err := r.Reconcile(context.Background(), testRequest); err != nil {
+				t.Fatalf("Reconcile() unexpected error: %v", err)
+			}
+
+			want := fmt.Sprintf(`
+# HELP openssf_scorecard_authenticated Whether a config accesses the VCS with a token or GitHub App credentials (1) or anonymously (0)
+# TYPE openssf_scorecard_authenticated gauge
+openssf_scorecard_authenticated{config="default/scorecard-config"} %d
+`, tt.expected)
+			if err := testutil.GatherAndCompare(registry, strings.NewReader(want), "openssf_scorecard_authenticated"); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestReconcilePushMetrics(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+	}{
+		{name: "pushes after each reconcile", status: http.StatusOK},
+		{name: "push failures do not fail the reconcile", status: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var paths []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				mu.Lock()
+				defer mu.Unlock()
+				paths = append(paths, req.URL.Path)
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			r := newTestReconciler(t, &fakeProvider{repos: []string{"repo"}}, newTestConfigMap(nil))
+			r.MetricsPusher = metrics.NewPusher(r.MetricsCollector, server.URL, "scorecard")
+
+			for range 2 {
+				if _, err := r.Reconcile(context.Background(), testRequest); err != nil {
+					t.Fatalf("Reconcile() unexpected error: %v", err)
+				}
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			if want := []string{"/metrics/job/scorecard", "/metrics/job/scorecard"}; !slices.Equal(paths, want) {
+				t.Errorf("pushes = %v, want %v", paths, want)
+			}
+		})
+	}
+}
+
+func TestReconcileUnavailableRequeue(t *testing.T) {
+	tests := []struct {
+		name            string
+		repos           []string
+		unavailable     time.Duration
+		expectedRequeue time.Duration
+	}{
+		{
+			name:            "all data available",
+			repos:           []string{"repo"},
+			unavailable:     10 * time.Minute,
+			expectedRequeue: time.Hour,
+		},
+		{
+			name:            "unavailable data",
+			repos:           []string{"repo", "missing"},
+			unavailable:     10 * time.Minute,
+			expectedRequeue: 10 * time.Minute,
+		},
+		{
+			name:            "disabled",
+			repos:           []string{"repo", "missing"},
+			expectedRequeue: time.Hour,
+		},
+		{
+			name:            "longer than the requeue interval",
+			repos:           []string{"repo", "missing"},
+			unavailable:     2 * time.Hour,
+			expectedRequeue: time.Hour,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestReconciler(t, &fakeProvider{repos: tt.repos}, newTestConfigMap(nil))
+			r.MaxJitterPercent = 0
+			r.UnavailableRequeueInterval = tt.unavailable
+
+			result, err := r.Reconcile(context.Background(), testRequest)
+			if err != nil {
+				t.Fatalf("Reconcile() unexpected error: %v", err)
+			}
+			if result.RequeueAfter != tt.expectedRequeue {
+				t.Errorf("Reconcile() RequeueAfter = %v, want %v", result.RequeueAfter, tt.expectedRequeue)
+			}
+		})
+	}
+}
+
+func TestReconcileAuthFailure(t *testing.T) {
+	provider := &fakeProvider{err: vcs.NewAuthError(fakeProviderType, http.StatusUnauthorized, "Bad credentials")}
+	r := newTestReconciler(t, provider, newTestConfigMap(nil))
+	registry := prometheus.NewRegistry()
+	r.MetricsCollector = metrics.NewCollector(metrics.WithRegistry(registry))
+
+	if _, err := r.Reconcile(context.Background(), testRequest); err != nil {
+		t.Fatalf("Reconcile() unexpected error: %v", err)
+	}
+
+	events := recordedEvents(r)
+	if len(events) != 1 || !strings.HasPrefix(events[0], "Warning "+EventReasonAuthenticationFailed) {
+		t.Errorf("events = %v, want a single %s warning", events, EventReasonAuthenticationFailed)
+	}
+	if count, err := testutil.GatherAndCount(registry, "openssf_scorecard_vcs_auth_failures_total"); err != nil || count != 1 {
+		t.Errorf("vcs_auth_failures_total series = %d, %v, want 1", count, err)
+	}
+}
+
+func TestReconcileMissingOrganization(t *testing.T) {
+	tests := []struct {
+		name     string
+		failOn   bool
+		terminal bool
+	}{
+		{
+			name: "skipped",
+		},
+		{
+			name:     "terminal error",
+			failOn:   true,
+			terminal: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestReconciler(t, &fakeProvider{}, newTestConfigMap(map[string]string{OrganizationKey: ""}))
+			registry := prometheus.NewRegistry()
+			r.MetricsCollector = metrics.NewCollector(metrics.WithRegistry(registry))
+			r.FailOnMissingOrganization = tt.failOn
+
+			result, err := r.Reconcile(context.Background(), testRequest)
+			if tt.terminal != errors.Is(err, reconcile.TerminalError(nil)) {
+				t.Errorf("Reconcile() error = %v, want terminal error %v", err, tt.terminal)
+			}
+			if !tt.terminal && err != nil {
+				t.Errorf("Reconcile() unexpected error: %v", err)
+			}
+			if result.RequeueAfter != 0 {
+				t.Errorf("Reconcile() RequeueAfter = %v, want no requeue", result.RequeueAfter)
+			}
+
+			events := recordedEvents(r)
+			if len(events) != 1 || !strings.HasPrefix(events[0], "Warning "+EventReasonMissingOrganization) {
+				t.Errorf("events = %v, want a single %s warning", events, EventReasonMissingOrganization)
+			}
+
+			expected := `
+# HELP openssf_scorecard_config_errors_total Total number of reconciles that skipped a config because of a configuration error
+# TYPE openssf_scorecard_config_errors_total counter
+openssf_scorecard_config_errors_total{config="default/scorecard-config",reason="missing_organization"} 1
+`
+			if err := testutil.GatherAndCompare(registry, strings.NewReader(expected), "openssf_scorecard_config_errors_total"); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestReconcileDeniedOrganization(t *testing.T) {
+	tests := []struct {
+		name         string
+		organization string
+		denied       []string
+		expectSkip   bool
+	}{
+		{
+			name:         "allowed",
+			organization: "org",
+			denied:       []string{"sensitive-org"},
+		},
+		{
+			name:         "denied",
+			organization: "sensitive-org",
+			denied:       []string{"other-org", "sensitive-org"},
+			expectSkip:   true,
+		},
+		{
+			name:         "denied case-insensitively",
+			organization: "Sensitive-Org",
+			denied:       []string{"sensitive-org"},
+			expectSkip:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestReconciler(t, &fakeProvider{repos: []string{"repo"}},
+				newTestConfigMap(map[string]string{OrganizationKey: tt.organization}))
+			registry := prometheus.NewRegistry()
+			r.MetricsCollector = metrics.NewCollector(metrics.WithRegistry(registry))
+			r.DeniedOrganizations = tt.denied
+
+			if _, err := r.Reconcile(context.Background(), testRequest); err != nil {
+				t.Fatalf("Reconcile() unexpected error: %v", err)
+			}
+
+			events := recordedEvents(r)
+			denied := len(events) == 1 && strings.HasPrefix(events[0], "Warning "+EventReasonOrganizationDenied)
+			if denied != tt.expectSkip {
+				t.Errorf("events = %v, want %s warning %v", events, EventReasonOrganizationDenied, tt.expectSkip)
+			}
+			count, err := testutil.GatherAndCount(registry, "openssf_scorecard_repositories")
+			if err != nil {
+				t.Fatalf("failed to gather metrics: %v", err)
+			}
+			if scanned := count > 0; scanned == tt.expectSkip {
+				t.Errorf("organization scanned = %v, want %v", scanned, !tt.expectSkip)
+			}
+
+			expected := ""
+			if tt.expectSkip {
+				expected = `
+# HELP openssf_scorecard_config_errors_total Total number of reconciles that skipped a config because of a configuration error
+# TYPE openssf_scorecard_config_errors_total counter
+openssf_scorecard_config_errors_total{config="default/scorecard-config",reason="denied_organization"} 1
+`
+			}
+			if err := testutil.GatherAndCompare(registry, strings.NewReader(expected), "openssf_scorecard_config_errors_total"); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestReconcileInsufficientScope(t *testing.T) {
+	provider := &fakeProvider{err: &vcs.ScopeError{Provider: fakeProviderType, Scope: "read:org", Granted: []string{"public_repo"}}}
+	r := newTestReconciler(t, provider, newTestConfigMap(nil))
+
+	result, err := r.Reconcile(context.Background(), testRequest)
+	if err != nil {
+		t.Fatalf("Reconcile() unexpected error: %v", err)
+	}
+	if result.RequeueAfter != authFailureRequeueDelay {
+		t.Errorf("Reconcile() RequeueAfter = %v, want %v", result.RequeueAfter, authFailureRequeueDelay)
+	}
+
+	events := recordedEvents(r)
+	if len(events) != 1 || !strings.HasPrefix(events[0], "Warning "+EventReasonInsufficientScope) ||
+		!strings.Contains(events[0], "read:org") {
+		t.Errorf("events = %v, want a single %s warning naming the scope", events, EventReasonInsufficientScope)
+	}
+}
+
+func TestReconcileMaxRepositories(t *testing.T) {
+	tests := []struct {
+		name            string
+		data            map[string]string
+		maxRepositories int
+		expectRepos     []string
+		expectTruncated bool
+		expectLastErr   string
+	}{
+		{
+			name:        "unlimited by default",
+			expectRepos: []string{"repo", "missing", "missing-too"},
+		},
+		{
+			name:            "manager default",
+			maxRepositories: 2,
+			expectRepos:     []string{"repo", "missing"},
+			expectTruncated: true,
+		},
+		{
+			name:            "ConfigMap overrides manager default",
+			data:            map[string]string{MaxRepositoriesKey: "1"},
+			maxRepositories: 2,
+			expectRepos:     []string{"repo"},
+			expectTruncated: true,
+		},
+		{
+			name:            "ConfigMap disables the limit",
+			data:            map[string]string{MaxRepositoriesKey: "0"},
+			maxRepositories: 2,
+			expectRepos:     []string{"repo", "missing", "missing-too"},
+		},
+		{
+			name:            "limit not reached",
+			maxRepositories: 3,
+			expectRepos:     []string{"repo", "missing", "missing-too"},
+		},
+		{
+			name:          "invalid limit",
+			data:          map[string]string{MaxRepositoriesKey: "-1"},
+			expectLastErr: `invalid maxRepositories "-1"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := &fakeProvider{repos: []string{"repo", "missing", "missing-too"}}
+			r := newTestReconciler(t, provider, newTestConfigMap(tt.data))
+			r.MaxRepositories = tt.maxRepositories
+			registry := prometheus.NewRegistry()
+			r.MetricsCollector = metrics.NewCollector(metrics.WithRegistry(registry))
+
+			if _, err := r.Reconcile(context.Background(), testRequest); err != nil {
+				t.Fatalf("Reconcile() unexpected error: %v", err)
+			}
+
+			scores := overallScores(t, registry)
+			if got := slices.Sorted(maps.Keys(scores)); !slices.Equal(got, slices.Sorted(slices.Values(tt.expectRepos))) {
+				t.Errorf("exported repositories = %v, want %v", got, tt.expectRepos)
+			}
+
+			truncated, err := testutil.GatherAndCount(registry, "openssf_scorecard_repos_truncated_total")
+			if err != nil || (truncated == 1) != tt.expectTruncated {
+				t.Errorf("repos_truncated_total series = %d, %v, want truncated %v", truncated, err, tt.expectTruncated)
+			}
+
+			var configMap corev1.ConfigMap
+			if err := r.Get(context.Background(), testRequest.NamespacedName, &configMap); err != nil {
+				t.Fatalf("failed to get ConfigMap: %v", err)
+			}
+			if lastErr := configMap.Annotations[LastErrorAnnotation]; !strings.Contains(lastErr, tt.expectLastErr) ||
+				(tt.expectLastErr == "") != (lastErr == "") {
+				t.Errorf("%s = %q, want it to contain %q", LastErrorAnnotation, lastErr, tt.expectLastErr)
+			}
+		})
+	}
+}
+
+func TestReconcileMaxRepoAgeDays(t *testing.T) {
+	tests := []struct {
+		name          string
+		data          map[string]string
+		expectMaxAge  time.Duration
+		expectLastErr string
+	}{
+		{
+			name: "no limit by default",
+		},
+		{
+			name:         "days",
+			data:         map[string]string{MaxRepoAgeDaysKey: "30"},
+			expectMaxAge: 30 * 24 * time.Hour,
+		},
+		{
+			name:          "negative",
+			data:          map[string]string{MaxRepoAgeDaysKey: "-1"},
+			expectLastErr: `invalid maxRepoAgeDays "-1"`,
+		},
+		{
+			name:          "not a number",
+			data:          map[string]string{MaxRepoAgeDaysKey: "30d"},
+			expectLastErr: `invalid maxRepoAgeDays "30d"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestReconciler(t, nil, newTestConfigMap(tt.data))
+			var maxAge time.Duration
+			r.ProviderFactory.Register(fakeProviderType, func(config *vcs.Config) (vcs.Provider, error) {
+				maxAge = config.MaxRepositoryAge
+				return &fakeProvider{repos: []string{"repo"}}, nil
+			})
+
+			if _, err := r.Reconcile(context.Background(), testRequest); err != nil {
+				t.Fatalf("Reconcile() unexpected error: %v", err)
+			}
+			if maxAge != tt.expectMaxAge {
+				t.Errorf("MaxRepositoryAge = %v, want %v", maxAge, tt.expectMaxAge)
+			}
+
+			var configMap corev1.ConfigMap
+			if err := r.Get(context.Background(), testRequest.NamespacedName, &configMap); err != nil {
+				t.Fatalf("failed to get ConfigMap: %v", err)
+			}
+			if lastErr := configMap.Annotations[LastErrorAnnotation]; !strings.Contains(lastErr, tt.expectLastErr) ||
+				(tt.expectLastErr == "") != (lastErr == "") {
+				t.Errorf("%s = %q, want it to contain %q", LastErrorAnnotation, lastErr, tt.expectLastErr)
+			}
+		})
+	}
+}
+
+func TestReconcileMultipleInstances(t *testing.T) {
+	providers := map[string]*fakeProvider{
+		"":                                {repos: []string{"repo", "missing"}},
+		"https://ghe.example.com/api/v3/": {repos: []string{"repo"}, host: "ghe.example.com"},
+	}
+	r := newTestReconciler(t, nil, newTestConfigMap(map[string]string{
+		BaseURLKey: "default, https://ghe.example.com/api/v3/",
+	}))
+	r.ProviderFactory.Register(fakeProviderType, func(config *vcs.Config) (vcs.Provider, error) {
+		return providers[config.BaseURL], nil
+	})
+	registry := prometheus.NewRegistry()
+	r.MetricsCollector = metrics.NewCollector(metrics.WithRegistry(registry))
+
+	if _, err := r.Reconcile(context.Background(), testRequest); err != nil {
+		t.Fatalf("Reconcile() unexpected error: %v", err)
+	}
+
+	// Scores of the same repository on both instances are exported as distinct series
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("failed to gather metrics: %v", err)
+	}
+	scores := map[string]float64{}
+	for _, family := range families {
+		if family.GetName() != "openssf_scorecard_overall_score" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			labels := map[string]string{}
+			for _, label := range metric.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+			scores[labels["host"]+"/"+labels["repository"]] = metric.GetGauge().GetValue()
+		}
+	}
+	expected := map[string]float64{
+		"github.com/repo":      7.5,
+		"github.com/missing":   -1,
+		"ghe.example.com/repo": 7.5,
+	}
+	if !maps.Equal(scores, expected) {
+		t.Errorf("overall scores = %v, want %v", scores, expected)
+	}
+
+	if total := gaugeValues(t, registry, "openssf_scorecard_repositories", "config"); total[testRequest.String()] != 3 {
+		t.Errorf("repositories = %v, want 3", total)
+	}
+}
+
+func TestReconcileTokenRotation(t *testing.T) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "github-token", Namespace: "default"},
+		Data:       map[string][]byte{"token": []byte("old-token")},
+	}
+	r := newTestReconciler(t, nil, newTestConfigMap(map[string]string{TokenSecretKey: "github-token"}), secret)
+	r.ProviderCacheSize = DefaultProviderCacheSize
+	var tokens []string
+	r.ProviderFactory.Register(fakeProviderType, func(config *vcs.Config) (vcs.Provider, error) {
+		tokens = append(tokens, config.Token)
+		return &fakeProvider{repos: []string{"repo"}}, nil
+	})
+
+	reconcileOnce := func() {
+		t.Helper()
+		if _, err := r.Reconcile(context.Background(), testRequest); err != nil {
+			t.Fatalf("Reconcile() unexpected error: %v", err)
+		}
+	}
+
+	// The provider is reused while the token is unchanged
+	reconcileOnce()
+	reconcileOnce()
+	if !slices.Equal(tokens, []string{"old-token"}) {
+		t.Fatalf("providers created with tokens %v, want [old-token]", tokens)
+	}
+
+	// A rotated token takes effect on the next reconcile
+	secret.Data["token"] = []byte("new-token")
+	if err := r.Update(context.Background(), secret); err != nil {
+		t.Fatalf("failed to update secret: %v", err)
+	}
+	reconcileOnce()
+	if !slices.Equal(tokens, []string{"old-token", "new-token"}) {
+		t.Errorf("providers created with tokens %v, want [old-token new-token]", tokens)
+	}
+}
+
+// probingProvider is a fakeProvider that reports a fixed TokenInfo when probed
+type probingProvider struct {
+	*fakeProvider
+
+	info   vcs.TokenInfo
+	err    error
+	probes *int
+}
+
+func (p *probingProvider) ProbeToken(_ context.Context, _ string) (*vcs.TokenInfo, error) {
+	*p.probes++
+	if p.err != nil {
+		return nil, p.err
+	}
+	return &p.info, nil
+}
+
+func TestReconcileTokenProbe(t *testing.T) {
+	tests := []struct {
+		name           string
+		info           vcs.TokenInfo
+		repos          []string
+		err            error
+		expectProbes   int
+		expectWarnings int
+	}{
+		{
+			name:         "token with organization access",
+			info:         vcs.TokenInfo{Kind: vcs.TokenKindClassic, Scopes: []string{"read:org"}, OrganizationAccess: true},
+			repos:        []string{"repo"},
+			expectProbes: 2,
+		},
+		{
+			name:           "classic token without org scope",
+			info:           vcs.TokenInfo{Kind: vcs.TokenKindClassic, Scopes: []string{"public_repo"}},
+			repos:          []string{"repo"},
+			expectProbes:   2,
+			expectWarnings: 2,
+		},
+		{
+			name:         "fine-grained token of a public organization",
+			info:         vcs.TokenInfo{Kind: vcs.TokenKindFineGrained, OrganizationAccess: true, PublicRepositories: 12},
+			repos:        []string{"repo"},
+			expectProbes: 2,
+		},
+		{
+			name:           "fine-grained token listing none of the public repositories",
+			info:           vcs.TokenInfo{Kind: vcs.TokenKindFineGrained, OrganizationAccess: true, PublicRepositories: 12},
+			expectProbes:   2,
+			expectWarnings: 2,
+		},
+		{
+			name:         "organization without public repositories",
+			info:         vcs.TokenInfo{Kind: vcs.TokenKindFineGrained, OrganizationAccess: true},
+			expectProbes: 2,
+		},
+		{
+			name:         "failed probe retried",
+			err:          errors.New("connection refused"),
+			repos:        []string{"repo"},
+			expectProbes: 4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "github-token", Namespace: "default"},
+				Data:       map[string][]byte{"token": []byte("old-token")},
+			}
+			r := newTestReconciler(t, nil, newTestConfigMap(map[string]string{TokenSecretKey: "github-token"}), secret)
+			var probes int
+			r.ProviderFactory.Register(fakeProviderType, func(*vcs.Config) (vcs.Provider, error) {
+				return &probingProvider{fakeProvider: &fakeProvider{repos: tt.repos}, info: tt.info, err: tt.err, probes: &probes}, nil
+			})
+
+			reconcileTwice := func() {
+				t.Helper()
+				for range 2 {
+					if _, err := r.Reconcile(context.Background(), testRequest); err != nil {
+						t.Fatalf("Reconcile() unexpected error: %v", err)
+					}
+				}
+			}
+
+			// The token is probed once, and again once it is rotated
+			reconcileTwice()
+			secret.Data["token"] = []byte("new-token")
+			if err := r.Update(context.Background(), secret); err != nil {
+				t.Fatalf("failed to update secret: %v", err)
+			}
+			reconcileTwice()
+
+			if probes != tt.expectProbes {
+				t.Errorf("token probes = %d, want %d", probes, tt.expectProbes)
+			}
+			var warnings int
+			for _, event := range recordedEvents(r) {
+				if strings.Contains(event, EventReasonLimitedTokenAccess) {
+					warnings++
+				}
+			}
+			if warnings != tt.expectWarnings {
+				t.Errorf("%s events = %d, want %d", EventReasonLimitedTokenAccess, warnings, tt.expectWarnings)
+			}
+		})
+	}
+}
+
+func TestReconcileReports(t *testing.T) {
+	r := newTestReconciler(t, &fakeProvider{repos: []string{"repo", "missing"}}, newTestConfigMap(nil))
+	r.Reports = report.NewStore()
+
+	if _, err := r.Reconcile(context.Background(), testRequest); err != nil {
+		t.Fatalf("Reconcile() unexpected error: %v", err)
+	}
+	if data, ok := r.Reports.Get("github.com", "org", "repo"); !ok || data.Score != 7.5 {
+		t.Errorf("Reports.Get(github.com, org, repo) = %v, %v, want the fetched scorecard data", data, ok)
+	}
+	if _, ok := r.Reports.Get("github.com", "org", "missing"); ok {
+		t.Error("Reports.Get(github.com, org, missing) found a report for a repository without scorecard data")
+	}
+}
+
+func TestReconcileDebugState(t *testing.T) {
+	ctx := context.Background()
+	r := newTestReconciler(t, &fakeProvider{repos: []string{"repo", "missing"}}, newTestConfigMap(nil))
+	r.DebugState = debug.NewStore()
+
+	if _, err := r.Reconcile(ctx, testRequest); err != nil {
+		t.Fatalf("Reconcile() unexpected error: %v", err)
+	}
+	configs := r.DebugState.Configs()
+	if len(configs) != 1 || configs[0].Config != testRequest.String() ||
+		configs[0].Repositories != 2 || configs[0].LastError != "" || configs[0].LastSuccess.IsZero() {
+		t.Fatalf("DebugState.Configs() = %+v, want a successful reconcile of 2 repositories", configs)
+	}
+
+	// Deleted configs are dropped
+	var configMap corev1.ConfigMap
+	if err := r.Get(ctx, testRequest.NamespacedName, &configMap); err != nil {
+		t.Fatalf("failed to get ConfigMap: %v", err)
+	}
+	if err := r.Delete(ctx, &configMap); err != nil {
+		t.Fatalf("failed to delete ConfigMap: %v", err)
+	}
+	if _, err := r.Reconcile(ctx, testRequest); err != nil {
+		t.Fatalf("Reconcile() unexpected error: %v", err)
+	}
+	if configs := r.DebugState.Configs(); len(configs) != 0 {
+		t.Errorf("DebugState.Configs() = %+v after deletion, want none", configs)
+	}
+}
+
+func TestReconcileRepositoryInfo(t *testing.T) {
+	tests := []struct {
+		name           string
+		data           map[string]string
+		expectRequests int
+		expectInfo     map[string]float64
+		expectLastErr  string
+	}{
+		{
+			name:       "disabled by default",
+			expectInfo: map[string]float64{},
+		},
+		{
+			name:           "enabled",
+			data:           map[string]string{RepositoryInfoKey: "true"},
+			expectRequests: 2,
+			expectInfo:     map[string]float64{"repo": 1, "missing": 1},
+		},
+		{
+			name:          "invalid value",
+			data:          map[string]string{RepositoryInfoKey: "maybe"},
+			expectInfo:    map[string]float64{},
+			expectLastErr: `invalid repositoryInfo "maybe"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := &fakeProvider{repos: []string{"repo", "missing"}}
+			r := newTestReconciler(t, provider, newTestConfigMap(tt.data))
+			registry := prometheus.NewRegistry()
+			r.MetricsCollector = metrics.NewCollector(metrics.WithRegistry(registry))
+
+			if _, err := r.Reconcile(context.Background(), testRequest); err != nil {
+				t.Fatalf("Reconcile() unexpected error: %v", err)
+			}
+
+			if provider.detailRequests != tt.expectRequests {
+				t.Errorf("GetRepositoryDetails() calls = %d, want %d", provider.detailRequests, tt.expectRequests)
+			}
+			if info := gaugeValues(t, registry, "openssf_scorecard_repository_info", "repository"); !maps.Equal(info, tt.expectInfo) {
+				t.Errorf("repository_info = %v, want %v", info, tt.expectInfo)
+			}
+			if tt.expectRequests > 0 {
+				if branches := gaugeValues(t, registry, "openssf_scorecard_repository_info", "default_branch"); branches["main"] != 1 {
+					t.Errorf("repository_info default branches = %v, want main", branches)
+				}
+			}
+
+			var configMap corev1.ConfigMap
+			if err := r.Get(context.Background(), testRequest.NamespacedName, &configMap); err != nil {
+				t.Fatalf("failed to get ConfigMap: %v", err)
+			}
+			if lastErr := configMap.Annotations[LastErrorAnnotation]; !strings.Contains(lastErr, tt.expectLastErr) ||
+				(tt.expectLastErr == "") != (lastErr == "") {
+				t.Errorf("%s = %q, want it to contain %q", LastErrorAnnotation, lastErr, tt.expectLastErr)
+			}
+		})
+	}
+}
+
+func TestParseOwnerType(t *testing.T) {
+	tests := []struct {
+		name        string
+		ownerType   string
+		expected    vcs.OwnerType
+		expectedErr error
+	}{
+		{
+			name:     "defaults to organization",
+			expected: vcs.OwnerTypeOrganization,
+		},
+		{
+			name:      "organization",
+			ownerType: "org",
+			expected:  vcs.OwnerTypeOrganization,
+		},
+		{
+			name:      "user",
+			ownerType: "user",
+			expected:  vcs.OwnerTypeUser,
+		},
+		{
+			name:        "unknown",
+			ownerType:   "team",
+			expectedErr: errInvalidConfig,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ownerType, err := parseOwnerType(newTestConfigMap(map[string]string{OwnerTypeKey: tt.ownerType}))
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("parseOwnerType() error = %v, want %v", err, tt.expectedErr)
+			}
+			if ownerType != tt.expected {
+				t.Errorf("parseOwnerType() = %q, want %q", ownerType, tt.expected)
+			}
+		})
+	}
+}
+
+func TestReconcileUnavailableValue(t *testing.T) {
+	tests := []struct {
+		name           string
+		data           map[string]string
+		managerDefault UnavailableValue
+		expected       map[string]float64
+		expectNaN      bool
+		expectLastErr  string
+	}{
+		{
+			name:     "negative one by default",
+			expected: map[string]float64{"repo": 7.5, "missing": -1},
+		},
+		{
+			name:      "nan",
+			data:      map[string]string{UnavailableValueKey: "nan"},
+			expected:  map[string]float64{"repo": 7.5},
+			expectNaN: true,
+		},
+		{
+			name:     "absent removes previously exported series",
+			data:     map[string]string{UnavailableValueKey: "absent"},
+			expected: map[string]float64{"repo": 7.5},
+		},
+		{
+			name:           "manager default",
+			managerDefault: UnavailableValueAbsent,
+			expected:       map[string]float64{"repo": 7.5},
+		},
+		{
+			name:           "ConfigMap overrides manager default",
+			data:           map[string]string{UnavailableValueKey: "negative_one"},
+			managerDefault: UnavailableValueAbsent,
+			expected:       map[string]float64{"repo": 7.5, "missing": -1},
+		},
+		{
+			name:          "invalid value",
+			data:          map[string]string{UnavailableValueKey: "zero"},
+			expected:      map[string]float64{"missing": -1},
+			expectLastErr: `invalid unavailableValue`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestReconciler(t, &fakeProvider{repos: []string{"repo", "missing"}}, newTestConfigMap(tt.data))
+			r.UnavailableValue = tt.managerDefault
+			registry := prometheus.NewRegistry()
+			r.MetricsCollector = metrics.NewCollector(metrics.WithRegistry(registry))
+
+			// Series exported by an earlier reconcile
+			r.MetricsCollector.UpdateMetrics(testRequest.NamespacedName.String(), "github.com", "org", "missing",
+				&scorecard.ScorecardData{Score: -1})
+
+			if _, err := r.Reconcile(context.Background(), testRequest); err != nil {
+				t.Fatalf("Reconcile() unexpected error: %v", err)
+			}
+
+			scores := overallScores(t, registry)
+			if tt.expectNaN {
+				if !math.IsNaN(scores["missing"]) {
+					t.Errorf("overall score of missing = %v, want NaN", scores["missing"])
+				}
+				delete(scores, "missing")
+			}
+			if !maps.Equal(scores, tt.expected) {
+				t.Errorf("overall scores = %v, want %v", scores, tt.expected)
+			}
+
+			var configMap corev1.ConfigMap
+			if err := r.Get(context.Background(), testRequest.NamespacedName, &configMap); err != nil {
+				t.Fatalf("failed to get ConfigMap: %v", err)
+			}
+			if lastErr := configMap.Annotations[LastErrorAnnotation]; !strings.Contains(lastErr, tt.expectLastErr) ||
+				(tt.expectLastErr == "") != (lastErr == "") {
+				t.Errorf("%s = %q, want it to contain %q", LastErrorAnnotation, lastErr, tt.expectLastErr)
+			}
+		})
+	}
+}
+
+func TestReconcilePassThreshold(t *testing.T) {
+	tests := []struct {
+		name           string
+		data           map[string]string
+		managerDefault int
+		expected       map[string]float64
+		expectLastErr  string
+	}{
+		{
+			name:     "default threshold",
+			expected: map[string]float64{"Code-Review": 1},
+		},
+		{
+			name:     "score at threshold passes",
+			data:     map[string]string{PassThresholdKey: "8"},
+			expected: map[string]float64{"Code-Review": 1},
+		},
+		{
+			name:     "score below threshold fails",
+			data:     map[string]string{PassThresholdKey: "9"},
+			expected: map[string]float64{"Code-Review": 0},
+		},
+		{
+			name:           "manager default",
+			managerDefault: 10,
+			expected:       map[string]float64{"Code-Review": 0},
+		},
+		{
+			name:           "ConfigMap overrides manager default",
+			data:           map[string]string{PassThresholdKey: "7"},
+			managerDefault: 10,
+			expected:       map[string]float64{"Code-Review": 1},
+		},
+		{
+			name:          "out of range",
+			data:          map[string]string{PassThresholdKey: "11"},
+			expected:      map[string]float64{},
+			expectLastErr: PassThresholdKey,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestReconciler(t, &fakeProvider{repos: []string{"repo"}}, newTestConfigMap(tt.data))
+			r.PassThreshold = tt.managerDefault
+			registry := prometheus.NewRegistry()
+			r.MetricsCollector = metrics.NewCollector(metrics.WithRegistry(registry))
+
+			if _, err := r.Reconcile(context.Background(), testRequest); err != nil {
+				t.Fatalf("Reconcile() unexpected error: %v", err)
+			}
+
+			if status := gaugeValues(t, registry, "openssf_scorecard_check_status", "check"); !maps.Equal(status, tt.expected) {
+				t.Errorf("check status = %v, want %v", status, tt.expected)
+			}
+
+			var configMap corev1.ConfigMap
+			if err := r.Get(context.Background(), testRequest.NamespacedName, &configMap); err != nil {
+				t.Fatalf("failed to get ConfigMap: %v", err)
+			}
+			if lastErr := configMap.Annotations[LastErrorAnnotation]; !strings.Contains(lastErr, tt.expectLastErr) ||
+				(tt.expectLastErr == "") != (lastErr == "") {
+				t.Errorf("%s = %q, want it to contain %q", LastErrorAnnotation, lastErr, tt.expectLastErr)
+			}
+		})
+	}
+}
+
+func TestReconcileOrganizationAverageScore(t *testing.T) {
+	tests := []struct {
+		name      string
+		data      map[string]string
+		repos     []string
+		expected  map[string]float64
+		expectNaN bool
+	}{
+		{
+			name:     "unavailable repositories excluded",
+			repos:    []string{"repo", "missing"},
+			expected: map[string]float64{"org": 7.5},
+		},
+		{
+			name:     "no data exported as -1",
+			repos:    []string{"missing"},
+			expected: map[string]float64{"org": -1},
+		},
+		{
+			name:      "no data exported as NaN",
+			data:      map[string]string{UnavailableValueKey: "nan"},
+			repos:     []string{"missing"},
+			expectNaN: true,
+		},
+		{
+			name:     "no data not exported",
+			data:     map[string]string{UnavailableValueKey: "absent"},
+			repos:    []string{"missing"},
+			expected: map[string]float64{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestReconciler(t, &fakeProvider{repos: tt.repos}, newTestConfigMap(tt.data))
+			registry := prometheus.NewRegistry()
+			r.MetricsCollector = metrics.NewCollector(metrics.WithRegistry(registry))
+
+			// Average exported by an earlier reconcile
+			r.MetricsCollector.UpdateOrganizationAverageScore(testRequest.NamespacedName.String(), "org", 5)
+
+			if _, err := r.Reconcile(context.Background(), testRequest); err != nil {
+				t.Fatalf("Reconcile() unexpected error: %v", err)
+			}
+
+			averages := gaugeValues(t, registry, "openssf_scorecard_organization_average_score", "organization")
+			if tt.expectNaN {
+				if len(averages) != 1 || !math.IsNaN(averages["org"]) {
+					t.Errorf("organization average scores = %v, want NaN", averages)
+				}
+				return
+			}
+			if !maps.Equal(averages, tt.expected) {
+				t.Errorf("organization average scores = %v, want %v", averages, tt.expected)
+			}
+		})
+	}
+}
+
+func TestAverageScore(t *testing.T) {
+	if average, ok := averageScore([]float64{4, 6.5, 10, 0}); !ok || average != 5.125 {
+		t.Errorf("averageScore() = %v, %v, want 5.125, true", average, ok)
+	}
+	if _, ok := averageScore(nil); ok {
+		t.Error("averageScore(nil) ok = true, want false")
+	}
+}
+
+func TestReconcileChecks(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     map[string]string
+		excluded []string
+		expected map[string]float64
+	}{
+		{
+			name:     "all checks by default",
+			expected: map[string]float64{"Code-Review": 8},
+		},
+		{
+			name:     "allowed check",
+			data:     map[string]string{ChecksKey: "Branch-Protection,code-review"},
+			expected: map[string]float64{"Code-Review": 8},
+		},
+		{
+			name:     "other checks only",
+			data:     map[string]string{ChecksKey: "Branch-Protection"},
+			expected: map[string]float64{},
+		},
+		{
+			name:     "excluded check",
+			excluded: []string{"CI-Tests", "code-review"},
+			expected: map[string]float64{},
+		},
+		{
+			name:     "excluded check allowed by the config",
+			data:     map[string]string{ChecksKey: "Code-Review"},
+			excluded: []string{"Code-Review"},
+			expected: map[string]float64{},
+		},
+		{
+			name:     "other checks excluded",
+			data:     map[string]string{ChecksKey: "Code-Review"},
+			excluded: []string{"CI-Tests"},
+			expected: map[string]float64{"Code-Review": 8},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestReconciler(t, &fakeProvider{repos: []string{"repo"}}, newTestConfigMap(tt.data))
+			r.ExcludedChecks = tt.excluded
+			registry := prometheus.NewRegistry()
+			r.MetricsCollector = metrics.NewCollector(metrics.WithRegistry(registry))
+
+			if _, err := r.Reconcile(context.Background(), testRequest); err != nil {
+				t.Fatalf("Reconcile() unexpected error: %v", err)
+			}
+
+			if checks := gaugeValues(t, registry, "openssf_scorecard_check_score", "check"); !maps.Equal(checks, tt.expected) {
+				t.Errorf("check scores = %v, want %v", checks, tt.expected)
+			}
+			if scores := overallScores(t, registry); scores["repo"] != 7.5 {
+				t.Errorf("overall scores = %v, want the unfiltered score", scores)
+			}
+		})
+	}
+}
+
+func TestParseVisibility(t *testing.T) {
+	tests := []struct {
+		name        string
+		visibility  string
+		expected    vcs.Visibility
+		expectedErr error
+	}{
+		{
+			name:     "defaults to public",
+			expected: vcs.VisibilityPublic,
+		},
+		{
+			name:       "private",
+			visibility: "private",
+			expected:   vcs.VisibilityPrivate,
+		},
+		{
+			name:       "all",
+			visibility: "all",
+			expected:   vcs.VisibilityAll,
+		},
+		{
+			name:        "unknown",
+			visibility:  "internal",
+			expectedErr: errInvalidConfig,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			visibility, err := parseVisibility(newTestConfigMap(map[string]string{VisibilityKey: tt.visibility}))
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("parseVisibility() error = %v, want %v", err, tt.expectedErr)
+			}
+			if visibility != tt.expected {
+				t.Errorf("parseVisibility() = %q, want %q", visibility, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseAffiliations(t *testing.T) {
+	tests := []struct {
+		name        string
+		affiliation string
+		expected    []vcs.Affiliation
+		expectedErr error
+	}{
+		{
+			name: "none by default",
+		},
+		{
+			name:        "single",
+			affiliation: "organization_member",
+			expected:    []vcs.Affiliation{vcs.AffiliationOrganizationMember},
+		},
+		{
+			name:        "several, with duplicates",
+			affiliation: "owner, collaborator,owner,",
+			expected:    []vcs.Affiliation{vcs.AffiliationOwner, vcs.AffiliationCollaborator},
+		},
+		{
+			name:        "unknown",
+			affiliation: "owner,member",
+			expectedErr: errInvalidConfig,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			affiliations, err := parseAffiliations(newTestConfigMap(map[string]string{AffiliationKey: tt.affiliation}))
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("parseAffiliations() error = %v, want %v", err, tt.expectedErr)
+			}
+			if !slices.Equal(affiliations, tt.expected) {
+				t.Errorf("parseAffiliations() = %v, want %v", affiliations, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseTeam(t *testing.T) {
+	tests := []struct {
+		name         string
+		team         string
+		providerType vcs.ProviderType
+		ownerType    vcs.OwnerType
+		graphQL      bool
+		affiliations []vcs.Affiliation
+		expected     string
+		expectedErr  error
+	}{
+		{
+			name:         "none by default",
+			providerType: vcs.ProviderTypeGitHub,
+		},
+		{
+			name:         "github organization team",
+			team:         " platform ",
+			providerType: vcs.ProviderTypeGitHub,
+			ownerType:    vcs.OwnerTypeOrganization,
+			expected:     "platform",
+		},
+		{
+			name:         "other providers",
+			team:         "platform",
+			providerType: vcs.ProviderTypeGitLab,
+			expectedErr:  errInvalidConfig,
+		},
+		{
+			name:         "user accounts",
+			team:         "platform",
+			providerType: vcs.ProviderTypeGitHub,
+			ownerType:    vcs.OwnerTypeUser,
+			expectedErr:  errInvalidConfig,
+		},
+		{
+			name:         "with GraphQL",
+			team:         "platform",
+			providerType: vcs.ProviderTypeGitHub,
+			graphQL:      true,
+			expectedErr:  errInvalidConfig,
+		},
+		{
+			name:         "with affiliations",
+			team:         "platform",
+			providerType: vcs.ProviderTypeGitHub,
+			affiliations: []vcs.Affiliation{vcs.AffiliationOrganizationMember},
+			expectedErr:  errInvalidConfig,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configMap := newTestConfigMap(map[string]string{TeamKey: tt.team})
+			team, err := parseTeam(configMap, tt.providerType, tt.ownerType, tt.graphQL, tt.affiliations)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("parseTeam() error = %v, want %v", err, tt.expectedErr)
+			}
+			if team != tt.expected {
+				t.Errorf("parseTeam() = %q, want %q", team, tt.expected)
+			}
+		})
+	}
+}
+
+// newSlowServer starts a server that answers only once the request is
+// cancelled, or after a minute
+func newSlowServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Minute):
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestReconcileTimeouts(t *testing.T) {
+	const timeout = 100 * time.Millisecond
+
+	t.Run("repository listing", func(t *testing.T) {
+		server := newSlowServer(t)
+		r := newTestReconciler(t, nil, newTestConfigMap(nil))
+		r.ProviderFactory.Register(fakeProviderType, func(*vcs.Config) (vcs.Provider, error) {
+			return vcs.NewGiteaProvider(&vcs.Config{Type: vcs.ProviderTypeGitea, BaseURL: server.URL})
+		})
+		r.RepositoryListTimeout = timeout
+
+		start := time.Now()
+		result, err := r.Reconcile(context.Background(), testRequest)
+		if elapsed := time.Since(start); elapsed > 10*timeout {
+			t.Errorf("Reconcile() took %v, want it to abort after %v", elapsed, timeout)
+		}
+		if err != nil {
+			t.Fatalf("Reconcile() unexpected error: %v", err)
+		}
+		if result.RequeueAfter != transientBackoffBase {
+			t.Errorf("Reconcile() RequeueAfter = %v, want %v", result.RequeueAfter, transientBackoffBase)
+		}
+
+		var configMap corev1.ConfigMap
+		if err := r.Get(context.Background(), testRequest.NamespacedName, &configMap); err != nil {
+			t.Fatalf("failed to get ConfigMap: %v", err)
+		}
+		if lastErr := configMap.Annotations[LastErrorAnnotation]; !strings.Contains(lastErr, "listing repositories timed out after 100ms") {
+			t.Errorf("%s = %q, want a timeout error", LastErrorAnnotation, lastErr)
+		}
+	})
+
+	t.Run("scorecard fetch", func(t *testing.T) {
+		server := newSlowServer(t)
+		r := newTestReconciler(t, &fakeProvider{repos: []string{"repo"}}, newTestConfigMap(nil))
+		r.ScorecardSource = scorecard.NewClient(scorecard.WithAPIEndpoint(server.URL))
+		r.ScorecardFetchTimeout = timeout
+
+		start := time.Now()
+		_, err := r.Reconcile(context.Background(), testRequest)
+		if elapsed := time.Since(start); elapsed > 10*timeout {
+			t.Errorf("Reconcile() took %v, want it to abort after %v", elapsed, timeout)
+		}
+		if err == nil || !strings.Contains(err.Error(), "fetching scorecard data timed out after 100ms") {
+			t.Errorf("Reconcile() error = %v, want a timeout error", err)
+		}
+	})
+
+	t.Run("repository details", func(t *testing.T) {
+		provider := &fakeProvider{repos: []string{"repo"}, slowDetails: true}
+		r := newTestReconciler(t, provider, newTestConfigMap(map[string]string{RepositoryInfoKey: "true"}))
+		r.ScorecardFetchTimeout = timeout
+		registry := prometheus.NewRegistry()
+		r.MetricsCollector = metrics.NewCollector(metrics.WithRegistry(registry))
+
+		// Details that time out are left out, and the scores still exported
+		start := time.Now()
+		if _, err := r.Reconcile(context.Background(), testRequest); err != nil {
+			t.Fatalf("Reconcile() unexpected error: %v", err)
+		}
+		if elapsed := time.Since(start); elapsed > 10*timeout {
+			t.Errorf("Reconcile() took %v, want it to give up on the details after %v", elapsed, timeout)
+		}
+		if scores := overallScores(t, registry); scores["repo"] != 7.5 {
+			t.Errorf("overall scores = %v, want 7.5 for repo", scores)
+		}
+	})
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// ConfigMapWebhookPath is the path the validating webhook for scorecard
+// ConfigMaps is served at
+const ConfigMapWebhookPath = "/validate-scorecard-configmap"
+
+// ConfigMapValidator rejects scorecard ConfigMaps the reconciler would fail to
+// process, so that misconfigurations surface when they are applied rather
+// than as reconcile errors. ConfigMaps without the scorecard label are
+// always admitted.
+type ConfigMapValidator struct {
+	// Reconciler provides the manager defaults and registered providers the
+	// ConfigMaps are validated against
+	Reconciler *ConfigMapReconciler
+}
+
+var _ admission.CustomValidator = &ConfigMapValidator{}
+
+// SetupWebhookWithManager registers the validating webhook with the Manager
+func (v *ConfigMapValidator) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&corev1.ConfigMap{}).
+		WithValidator(v).
+		WithValidatorCustomPath(ConfigMapWebhookPath).
+		Complete()
+}
+
+// ValidateCreate implements admission.CustomValidator
+func (v *ConfigMapValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, v.validate(obj)
+}
+
+// ValidateUpdate implements admission.CustomValidator
+func (v *ConfigMapValidator) ValidateUpdate(_ context.Context, _, newObj runtime.Object) (admission.Warnings, error) {
+	return nil, v.validate(newObj)
+}
+
+// ValidateDelete implements admission.CustomValidator. Deletes are always
+// admitted.
+func (v *ConfigMapValidator) ValidateDelete(context.Context, runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *ConfigMapValidator) validate(obj runtime.Object) error {
+	configMap, ok := obj.(*corev1.ConfigMap)
+	if !ok {
+		return fmt.Errorf("expected a ConfigMap, got %T", obj)
+	}
+	if _, labeled := configMap.Labels[ScorecardLabelKey]; !labeled {
+		return nil
+	}
+	return v.Reconciler.validateConfig(configMap)
+}
+
+// validateConfig returns all the errors the reconciler would run into parsing
+// a scorecard ConfigMap. Secrets and the VCS are not read, so a ConfigMap
+// passing validation may still fail to reconcile.
+func (r *ConfigMapReconciler) validateConfig(configMap *corev1.ConfigMap) error {
+	var errs []error
+	switch organization := configMap.Data[OrganizationKey]; {
+	case organization == "":
+		errs = append(errs, fmt.Errorf("%w: missing required field %q", errInvalidConfig, OrganizationKey))
+	case r.organizationDenied(organization):
+		errs = append(errs, fmt.Errorf("%w: organization %q is denied by the manager", errInvalidConfig, organization))
+	}
+
+	_, err := r.parseConfig(configMap)
+	return errors.Join(append(errs, err)...)
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestConfigMapValidator(t *testing.T) {
+	tests := []struct {
+		name      string
+		configMap *corev1.ConfigMap
+		expected  []string
+	}{
+		{name: "minimal", configMap: newTestConfigMap(nil)},
+		{
+			name: "all settings",
+			configMap: newTestConfigMap(map[string]string{
+				OwnerTypeKey:            "org",
+				VisibilityKey:           "all",
+				ScorecardAPIEndpointKey: "https://scorecard.example.com",
+				MaxRepositoriesKey:      "100",
+				MaxRepoAgeDaysKey:       "365",
+				IncludeSubgroupsKey:     "true",
+				AffiliationKey:          "owner,collaborator",
+				ChecksKey:               "Code-Review,SAST",
+				CheckWeightsKey:         "Code-Review=10,SAST=5",
+				UnavailableValueKey:     "nan",
+				PassThresholdKey:        "7",
+				TokenSecretKey:          `{"org": "org-token"}`,
+				CommitKey:               "0123456789abcdef",
+			}),
+		},
+		{
+			name: "unlabeled",
+			configMap: func() *corev1.ConfigMap {
+				configMap := newTestConfigMap(map[string]string{OrganizationKey: ""})
+				configMap.Labels = nil
+				return configMap
+			}(),
+		},
+		{
+			name:      "missing organization",
+			configMap: newTestConfigMap(map[string]string{OrganizationKey: ""}),
+			expected:  []string{`missing required field "organization"`},
+		},
+		{
+			name:      "denied organization",
+			configMap: newTestConfigMap(map[string]string{OrganizationKey: "Denied"}),
+			expected:  []string{`organization "Denied" is denied by the manager`},
+		},
+		{
+			name:      "unsupported provider type",
+			configMap: newTestConfigMap(map[string]string{ProviderTypeKey: "svn"}),
+			expected:  []string{`unsupported providerType "svn"`},
+		},
+		{
+			name:      "invalid visibility",
+			configMap: newTestConfigMap(map[string]string{VisibilityKey: "internal"}),
+			expected:  []string{`unknown visibility "internal"`},
+		},
+		{
+			name:      "invalid repository age",
+			configMap: newTestConfigMap(map[string]string{MaxRepoAgeDaysKey: "a year"}),
+			expected:  []string{`invalid maxRepoAgeDays "a year"`},
+		},
+		{
+			name:      "invalid endpoint",
+			configMap: newTestConfigMap(map[string]string{ScorecardAPIEndpointKey: "scorecard.example.com"}),
+			expected:  []string{`scorecardAPIEndpoint "scorecard.example.com" is not an absolute HTTP(S) URL`},
+		},
+		{
+			name:      "affiliation with GraphQL",
+			configMap: newTestConfigMap(map[string]string{AffiliationKey: "owner", GraphQLKey: "true"}),
+			expected:  []string{"affiliation cannot be combined with graphql"},
+		},
+		{
+			name:      "unmapped organization token",
+			configMap: newTestConfigMap(map[string]string{TokenSecretKey: `{"other": "other-token"}`}),
+			expected:  []string{`no token secret mapped for organization "org"`},
+		},
+		{
+			name: "invalid app ID",
+			configMap: newTestConfigMap(map[string]string{
+				AppIDKey:               "my-app",
+				InstallationIDKey:      "2",
+				AppPrivateKeySecretKey: "app-key",
+			}),
+			expected: []string{`invalid appID "my-app"`},
+		},
+		{
+			name:      "incomplete GitHub App",
+			configMap: newTestConfigMap(map[string]string{InstallationIDKey: "2"}),
+			expected:  []string{"must all be set"},
+		},
+		{
+			name:      "invalid base URL",
+			configMap: newTestConfigMap(map[string]string{ProviderTypeKey: "github", BaseURLKey: "default,github.mycorp.com"}),
+			expected:  []string{`invalid base URL "github.mycorp.com"`},
+		},
+		{
+			name:      "branch and commit",
+			configMap: newTestConfigMap(map[string]string{BranchKey: "main", CommitKey: "0123456789abcdef"}),
+			expected:  []string{"only one of branch and commit may be set"},
+		},
+		{
+			name: "all errors",
+			configMap: newTestConfigMap(map[string]string{
+				PassThresholdKey:    "11",
+				CheckWeightsKey:     "Code-Review=-1",
+				IncludeSubgroupsKey: "maybe",
+			}),
+			expected: []string{"invalid passThreshold", "Code-Review", "includeSubgroups"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestReconciler(t, &fakeProvider{})
+			r.DeniedOrganizations = []string{"denied"}
+			validator := &ConfigMapValidator{Reconciler: r}
+
+			_, createErr := validator.ValidateCreate(context.Background(), tt.configMap)
+			_, updateErr := validator.ValidateUpdate(context.Background(), newTestConfigMap(nil), tt.configMap)
+			for _, err := range []error{createErr, updateErr} {
+				if len(tt.expected) == 0 {
+					if err != nil {
+						t.Errorf("validate() error = %v, want none", err)
+					}
+					continue
+				}
+				if err == nil {
+					t.Fatalf("validate() error = nil, want %v", tt.expected)
+				}
+				for _, expected := range tt.expected {
+					if !strings.Contains(err.Error(), expected) {
+						t.Errorf("validate() error = %v, want it to contain %q", err, expected)
+					}
+				}
+			}
+
+			// Invalid ConfigMaps can always be deleted
+			if _, err := validator.ValidateDelete(context.Background(), tt.configMap); err != nil {
+				t.Errorf("ValidateDelete() error = %v, want none", err)
+			}
+		})
+	}
+}
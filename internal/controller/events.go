@@ -0,0 +1,69 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// Event reasons recorded on scorecard ConfigMaps
+const (
+	// EventReasonReconcileSucceeded is recorded when scorecard data was exported for all repositories
+	EventReasonReconcileSucceeded = "ReconcileSucceeded"
+
+	// EventReasonRateLimited is recorded when the VCS or scorecard API rate limit postpones reconciliation
+	EventReasonRateLimited = "RateLimited"
+
+	// EventReasonVCSUnavailable is recorded when a transient VCS API failure postpones reconciliation
+	EventReasonVCSUnavailable = "VCSUnavailable"
+
+	// EventReasonAuthenticationFailed is recorded when the VCS API rejects the configured credentials
+	EventReasonAuthenticationFailed = "AuthenticationFailed"
+
+	// EventReasonInsufficientScope is recorded when the VCS token lacks the scope required to list repositories
+	EventReasonInsufficientScope = "InsufficientScope"
+
+	// EventReasonLimitedTokenAccess is recorded when a probe of the VCS token suggests it cannot read the organization
+	EventReasonLimitedTokenAccess = "LimitedTokenAccess"
+
+	// EventReasonScorecardFetchFailed is recorded when scorecard data for a repository cannot be fetched
+	EventReasonScorecardFetchFailed = "ScorecardFetchFailed"
+
+	// EventReasonSecretMissing is recorded when a referenced secret or secret key does not exist
+	EventReasonSecretMissing = "SecretMissing"
+
+	// EventReasonMissingOrganization is recorded when a config does not specify an organization
+	EventReasonMissingOrganization = "MissingOrganization"
+
+	// EventReasonOrganizationDenied is recorded when a config targets an organization denied by the manager
+	EventReasonOrganizationDenied = "OrganizationDenied"
+
+	// EventReasonPartialReconcile is recorded when a reconcile ran out of its
+	// time budget before exporting scorecard data for all repositories
+	EventReasonPartialReconcile = "PartialReconcile"
+
+	// EventReasonDryRun is recorded when a dry run discovered repositories without exporting metrics
+	EventReasonDryRun = "DryRun"
+)
+
+// recordEvent records an event on the given object if an event recorder is configured
+func (r *ConfigMapReconciler) recordEvent(object runtime.Object, eventType, reason, messageFmt string, args ...interface{}) {
+	if r.Recorder == nil {
+		return
+	}
+	r.Recorder.Eventf(object, eventType, reason, messageFmt, args...)
+}
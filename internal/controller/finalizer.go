@@ -0,0 +1,115 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// MetricsFinalizer is added to scorecard ConfigMaps and ScorecardTargets so
+	// their metrics are removed before they are deleted, even if the controller
+	// was not running
+	MetricsFinalizer = "openssf-scorecard.giantswarm.io/metrics-cleanup"
+
+	// conflictRequeueDelay is how long to wait before retrying a finalizer
+	// update that lost a race with another writer
+	conflictRequeueDelay = time.Second
+)
+
+// isManaged reports whether a ConfigMap should export metrics. ConfigMaps that
+// are being deleted or have lost the scorecard label are finalized instead.
+func isManaged(configMap *corev1.ConfigMap) bool {
+	_, hasLabel := configMap.Labels[ScorecardLabelKey]
+	return hasLabel && configMap.DeletionTimestamp.IsZero()
+}
+
+// ensureFinalizer adds the metrics finalizer to a ConfigMap or ScorecardTarget.
+// It reports whether the object was already up to date, requeueing on conflict.
+func (r *ConfigMapReconciler) ensureFinalizer(ctx context.Context, object client.Object) (bool, ctrl.Result, error) {
+	if controllerutil.ContainsFinalizer(object, MetricsFinalizer) {
+		return true, ctrl.Result{}, nil
+	}
+
+	// Finalizers are a list, which a merge patch replaces wholesale, so guard
+	// against dropping finalizers added concurrently by someone else
+	patch := client.MergeFromWithOptions(object.DeepCopyObject().(client.Object), client.MergeFromWithOptimisticLock{})
+	controllerutil.AddFinalizer(object, MetricsFinalizer)
+	if err := r.Patch(ctx, object, patch); err != nil {
+		if apierrors.IsConflict(err) {
+			log.FromContext(ctx).V(1).Info("Conflict adding finalizer, requeueing")
+			return false, ctrl.Result{RequeueAfter: conflictRequeueDelay}, nil
+		}
+		return false, ctrl.Result{}, err
+	}
+	return true, ctrl.Result{}, nil
+}
+
+// finalize removes the metrics of a ConfigMap or ScorecardTarget that is being
+// deleted or, for ConfigMaps, is no longer labeled, and then releases the
+// finalizer. The config is the key its state is tracked by.
+func (r *ConfigMapReconciler) finalize(
+	ctx context.Context, config types.NamespacedName, object client.Object,
+) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	logger.Info("Removing metrics", "namespace", object.GetNamespace(), "name", object.GetName())
+	r.forgetConfig(config)
+
+	if !controllerutil.ContainsFinalizer(object, MetricsFinalizer) {
+		return ctrl.Result{}, nil
+	}
+
+	patch := client.MergeFromWithOptions(object.DeepCopyObject().(client.Object), client.MergeFromWithOptimisticLock{})
+	controllerutil.RemoveFinalizer(object, MetricsFinalizer)
+	if err := r.Patch(ctx, object, patch); err != nil {
+		if apierrors.IsConflict(err) {
+			logger.V(1).Info("Conflict removing finalizer, requeueing")
+			return ctrl.Result{RequeueAfter: conflictRequeueDelay}, nil
+		}
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	return ctrl.Result{}, nil
+}
+
+// forgetConfig removes the metrics, reports and all other state tracked for a
+// config that is gone
+func (r *ConfigMapReconciler) forgetConfig(config types.NamespacedName) {
+	r.MetricsCollector.RemoveMetricsForConfig(config.String())
+	r.MetricsCollector.UnwatchConfig(config.String())
+	r.Reports.RemoveConfig(config.String())
+	r.DebugState.Remove(config.String())
+	r.secrets.remove(config)
+	r.providers.remove(config)
+	r.backoff.reset(config)
+	r.secondaryRateLimits.reset(config)
+	r.initialSync.remove(config)
+	r.warnedAnonymous.Delete(config)
+	r.tokenProbes.remove(config)
+	r.generations.remove(config)
+	r.progress.reset(config)
+	r.listings.reset(config)
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/giantswarm/openssf-scorecard-exporter/internal/metrics"
+)
+
+func TestReconcileFinalizer(t *testing.T) {
+	ctx := context.Background()
+	r := newTestReconciler(t, &fakeProvider{repos: []string{"repo"}}, newTestConfigMap(nil))
+	registry := prometheus.NewRegistry()
+	r.MetricsCollector = metrics.NewCollector(metrics.WithRegistry(registry))
+
+	// The first reconcile adds the finalizer and exports metrics
+	if _, err := r.Reconcile(ctx, testRequest); err != nil {
+		t.Fatalf("Reconcile() unexpected error: %v", err)
+	}
+
+	var configMap corev1.ConfigMap
+	if err := r.Get(ctx, testRequest.NamespacedName, &configMap); err != nil {
+		t.Fatalf("failed to get ConfigMap: %v", err)
+	}
+	if !controllerutil.ContainsFinalizer(&configMap, MetricsFinalizer) {
+		t.Fatalf("finalizers = %v, want %s", configMap.Finalizers, MetricsFinalizer)
+	}
+	if scores := overallScores(t, registry); len(scores) != 1 {
+		t.Fatalf("overall scores = %v, want one series", scores)
+	}
+
+	// Deleting the ConfigMap is held up by the finalizer until metrics are removed
+	if err := r.Delete(ctx, &configMap); err != nil {
+		t.Fatalf("failed to delete ConfigMap: %v", err)
+	}
+	if _, err := r.Reconcile(ctx, testRequest); err != nil {
+		t.Fatalf("Reconcile() unexpected error: %v", err)
+	}
+
+	if scores := overallScores(t, registry); len(scores) != 0 {
+		t.Errorf("overall scores = %v, want none after deletion", scores)
+	}
+	if err := r.Get(ctx, testRequest.NamespacedName, &configMap); !apierrors.IsNotFound(err) {
+		t.Errorf("Get() error = %v, want NotFound once the finalizer is removed", err)
+	}
+}
+
+func TestReconcileFinalizer_LabelRemoved(t *testing.T) {
+	ctx := context.Background()
+	r := newTestReconciler(t, &fakeProvider{repos: []string{"repo"}}, newTestConfigMap(nil))
+	registry := prometheus.NewRegistry()
+	r.MetricsCollector = metrics.NewCollector(metrics.WithRegistry(registry))
+
+	if _, err := r.Reconcile(ctx, testRequest); err != nil {
+		t.Fatalf("Reconcile() unexpected error: %v", err)
+	}
+
+	var configMap corev1.ConfigMap
+	if err := r.Get(ctx, testRequest.NamespacedName, &configMap); err != nil {
+		t.Fatalf("failed to get ConfigMap: %v", err)
+	}
+	delete(configMap.Labels, ScorecardLabelKey)
+	if err := r.Update(ctx, &configMap); err != nil {
+		t.Fatalf("failed to update ConfigMap: %v", err)
+	}
+
+	if _, err := r.Reconcile(ctx, testRequest); err != nil {
+		t.Fatalf("Reconcile() unexpected error: %v", err)
+	}
+
+	if scores := overallScores(t, registry); len(scores) != 0 {
+		t.Errorf("overall scores = %v, want none after the label is removed", scores)
+	}
+	if err := r.Get(ctx, testRequest.NamespacedName, &configMap); err != nil {
+		t.Fatalf("failed to get ConfigMap: %v", err)
+	}
+	if controllerutil.ContainsFinalizer(&configMap, MetricsFinalizer) {
+		t.Errorf("finalizers = %v, want %s removed", configMap.Finalizers, MetricsFinalizer)
+	}
+}
+
+func TestReconcileFinalizer_Conflict(t *testing.T) {
+	r := newTestReconciler(t, &fakeProvider{repos: []string{"repo"}}, newTestConfigMap(nil))
+	registry := prometheus.NewRegistry()
+	r.MetricsCollector = metrics.NewCollector(metrics.WithRegistry(registry))
+	r.Client = interceptor.NewClient(r.Client.(client.WithWatch), interceptor.Funcs{
+		Patch: func(context.Context, client.WithWatch, client.Object, client.Patch, ...client.PatchOption) error {
+			return apierrors.NewConflict(schema.GroupResource{Resource: "configmaps"}, "scorecard-config", nil)
+		},
+	})
+
+	result, err := r.Reconcile(context.Background(), testRequest)
+	if err != nil {
+		t.Fatalf("Reconcile() unexpected error: %v", err)
+	}
+	if result.RequeueAfter != conflictRequeueDelay {
+		t.Errorf("Reconcile() RequeueAfter = %v, want %v", result.RequeueAfter, conflictRequeueDelay)
+	}
+	if scores := overallScores(t, registry); len(scores) != 0 {
+		t.Errorf("overall scores = %v, want none until the finalizer is added", scores)
+	}
+}
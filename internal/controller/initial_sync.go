@@ -0,0 +1,88 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// initialSync staggers the first reconcile of each config over a window
+// starting with the first reconcile of the controller, so that the configs
+// existing on startup do not all hit the VCS and scorecard APIs at once.
+// Configs first seen after the window has passed are not delayed. It is safe
+// for concurrent use.
+type initialSync struct {
+	mu sync.Mutex
+
+	// start is the time of the first reconcile
+	start time.Time
+
+	// scheduled is the time each config is first reconciled at
+	scheduled map[types.NamespacedName]time.Time
+
+	// now returns the current time, time.Now if nil
+	now func() time.Time
+
+	// rnd picks the delays, math/rand's global source if nil
+	rnd *rand.Rand
+}
+
+// delay returns how long to postpone the reconcile of a config, so that its
+// first reconcile happens at a random time within window. It returns zero
+// once that time has come, or when window is not positive.
+func (s *initialSync) delay(config types.NamespacedName, window time.Duration) time.Duration {
+	if window <= 0 {
+		return 0
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if s.now != nil {
+		now = s.now()
+	}
+	if s.scheduled == nil {
+		s.start = now
+		s.scheduled = make(map[types.NamespacedName]time.Time)
+	}
+
+	at, ok := s.scheduled[config]
+	if !ok {
+		var offset int64
+		if s.rnd != nil {
+			offset = s.rnd.Int63n(int64(window))
+		} else {
+			offset = rand.Int63n(int64(window)) // nolint:gosec // rand not used for crypto.
+		}
+		at = s.start.Add(time.Duration(offset))
+		s.scheduled[config] = at
+	}
+	return max(at.Sub(now), 0)
+}
+
+// remove forgets the schedule of a config
+func (s *initialSync) remove(config types.NamespacedName) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.scheduled, config)
+}
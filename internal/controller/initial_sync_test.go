@@ -0,0 +1,77 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestInitialSyncDelay(t *testing.T) {
+	const window = 10 * time.Minute
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := initialSync{
+		now: func() time.Time { return now },
+		rnd: rand.New(rand.NewSource(1)),
+	}
+
+	// The first reconciles are spread over the whole window
+	delays := make(map[types.NamespacedName]time.Duration)
+	var quarters [4]int
+	for i := range 100 {
+		key := types.NamespacedName{Namespace: "default", Name: fmt.Sprintf("config-%d", i)}
+		delay := s.delay(key, window)
+		if delay < 0 || delay >= window {
+			t.Fatalf("delay(%s) = %v, want within [0, %v)", key, delay, window)
+		}
+		delays[key] = delay
+		quarters[delay*4/window]++
+	}
+	for i, count := range quarters {
+		if count < 10 {
+			t.Errorf("%d of 100 delays in quarter %d of the window, want them spread evenly: %v", count, i+1, quarters)
+		}
+	}
+
+	// Repeated reconciles wait for the same point in time
+	key := types.NamespacedName{Namespace: "default", Name: "config-0"}
+	now = now.Add(delays[key] / 2)
+	if got, want := s.delay(key, window), delays[key]-delays[key]/2; got != want {
+		t.Errorf("delay() after %v = %v, want %v", delays[key]/2, got, want)
+	}
+
+	// Nothing is delayed once the window has passed
+	now = now.Add(window)
+	if got := s.delay(key, window); got != 0 {
+		t.Errorf("delay() after the window = %v, want 0", got)
+	}
+	if got := s.delay(types.NamespacedName{Namespace: "default", Name: "new"}, window); got != 0 {
+		t.Errorf("delay() of a config created after the window = %v, want 0", got)
+	}
+}
+
+func TestInitialSyncDisabled(t *testing.T) {
+	var s initialSync
+	key := types.NamespacedName{Namespace: "default", Name: "config"}
+	if got := s.delay(key, 0); got != 0 {
+		t.Errorf("delay() with zero window = %v, want 0", got)
+	}
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/giantswarm/openssf-scorecard-exporter/internal/vcs"
+)
+
+// vcsInstance holds the repositories discovered on one VCS instance
+type vcsInstance struct {
+	provider vcs.Provider
+	ref      scorecardRef
+	repos    []string
+}
+
+// parseBaseURLs splits the comma-separated base URLs of a ConfigMap, mapping
+// DefaultBaseURL to the provider's default instance. An empty value selects
+// the default instance only.
+func parseBaseURLs(value string) []string {
+	var baseURLs []string
+	for baseURL := range strings.SplitSeq(value, ",") {
+		baseURL = strings.TrimSpace(baseURL)
+		switch baseURL {
+		case "":
+			continue
+		case DefaultBaseURL:
+			baseURL = ""
+		}
+		if !slices.Contains(baseURLs, baseURL) {
+			baseURLs = append(baseURLs, baseURL)
+		}
+	}
+	if len(baseURLs) == 0 {
+		return []string{""}
+	}
+	return baseURLs
+}
+
+// scorecardHost returns the host of a scorecard VCS path such as
+// "github.com/org/repo"
+func scorecardHost(vcsPath string) string {
+	host, _, _ := strings.Cut(vcsPath, "/")
+	return host
+}
+
+// labelCollision is a repository left out because it is exported with the
+// same label value as another repository of its VCS instance
+type labelCollision struct {
+	repository string
+	other      string
+	label      string
+}
+
+// dropLabelCollisions removes the repositories exported with the same label
+// value, as returned by label, as an earlier repository of the same instance,
+// since their series would overwrite each other. It returns the removed ones.
+func dropLabelCollisions(instances []vcsInstance, label func(string) string) []labelCollision {
+	var collisions []labelCollision
+	for i := range instances {
+		labeled := make(map[string]string, len(instances[i].repos))
+		instances[i].repos = slices.DeleteFunc(slices.Clone(instances[i].repos), func(repo string) bool {
+			value := label(repo)
+			if other, ok := labeled[value]; ok {
+				collisions = append(collisions, labelCollision{repository: repo, other: other, label: value})
+				return true
+			}
+			labeled[value] = repo
+			return false
+		})
+	}
+	return collisions
+}
+
+// countRepositories returns the number of repositories across all instances
+func countRepositories(instances []vcsInstance) int {
+	total := 0
+	for _, instance := range instances {
+		total += len(instance.repos)
+	}
+	return total
+}
+
+// truncateRepositories keeps the first maxRepositories repositories across
+// all instances, in instance order
+func truncateRepositories(instances []vcsInstance, maxRepositories int) {
+	remaining := maxRepositories
+	for i := range instances {
+		n := min(len(instances[i].repos), remaining)
+		instances[i].repos = instances[i].repos[:n]
+		remaining -= n
+	}
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestParseBaseURLs(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected []string
+	}{
+		{
+			name:     "empty",
+			expected: []string{""},
+		},
+		{
+			name:     "single instance",
+			value:    "https://ghe.example.com/api/v3",
+			expected: []string{"https://ghe.example.com/api/v3"},
+		},
+		{
+			name:     "default and custom instance",
+			value:    "default, https://ghe.example.com/api/v3",
+			expected: []string{"", "https://ghe.example.com/api/v3"},
+		},
+		{
+			name:     "duplicates and empty entries",
+			value:    "https://ghe.example.com/api/v3,,https://ghe.example.com/api/v3",
+			expected: []string{"https://ghe.example.com/api/v3"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseBaseURLs(tt.value); !slices.Equal(got, tt.expected) {
+				t.Errorf("parseBaseURLs() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTruncateRepositories(t *testing.T) {
+	instances := []vcsInstance{
+		{repos: []string{"a", "b"}},
+		{repos: []string{"c", "d"}},
+		{repos: []string{"e"}},
+	}
+
+	truncateRepositories(instances, 3)
+
+	var got []string
+	for _, instance := range instances {
+		got = append(got, instance.repos...)
+	}
+	if expected := []string{"a", "b", "c"}; !slices.Equal(got, expected) {
+		t.Errorf("repositories = %v, want %v", got, expected)
+	}
+	if total := countRepositories(instances); total != 3 {
+		t.Errorf("countRepositories() = %d, want 3", total)
+	}
+}
+
+func TestDropLabelCollisions(t *testing.T) {
+	label := func(repo string) string { return strings.ReplaceAll(repo, ".", "_") }
+	instances := []vcsInstance{
+		{repos: []string{"my.repo", "other", "my_repo"}},
+		{repos: []string{"my_repo"}},
+	}
+
+	collisions := dropLabelCollisions(instances, label)
+
+	expected := []labelCollision{{repository: "my_repo", other: "my.repo", label: "my_repo"}}
+	if !slices.Equal(collisions, expected) {
+		t.Errorf("dropLabelCollisions() = %+v, want %+v", collisions, expected)
+	}
+	// Instances have distinct hosts, so their repositories never collide
+	for i, repos := range [][]string{{"my.repo", "other"}, {"my_repo"}} {
+		if !slices.Equal(instances[i].repos, repos) {
+			t.Errorf("instance %d repositories = %v, want %v", i, instances[i].repos, repos)
+		}
+	}
+}
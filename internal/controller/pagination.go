@@ -0,0 +1,141 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/giantswarm/openssf-scorecard-exporter/internal/vcs"
+)
+
+// listingKey identifies the listing of a VCS instance of a config
+type listingKey struct {
+	config  types.NamespacedName
+	baseURL string
+}
+
+// listingCursor is where a rate limit interrupted the listing of the
+// repositories of a VCS instance
+type listingCursor struct {
+	cursor *vcs.ListCursor
+
+	// generation is the generation of the config the listing was made with,
+	// whose cursor does not apply to the listing of another generation
+	generation string
+
+	// excluded counts the repositories left out by filters before the cursor,
+	// which the provider does not report again when resuming
+	excluded int64
+}
+
+// listingCursors tracks where rate limits interrupted the repository listings
+// of each config, so that the reconcile requeued after a rate limit resumes
+// from the page it stopped at instead of spending the rate limit on the pages
+// already listed. It is safe for concurrent use.
+type listingCursors struct {
+	mu sync.Mutex
+
+	// cursors holds the interrupted listings of each VCS instance of each
+	// config
+	cursors map[listingKey]listingCursor
+}
+
+// get returns where the listing of a VCS instance of a config generation was
+// interrupted, nil if it was not
+func (c *listingCursors) get(config types.NamespacedName, baseURL, generation string) (*vcs.ListCursor, int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cursor, ok := c.cursors[listingKey{config: config, baseURL: baseURL}]
+	if !ok || cursor.generation != generation {
+		return nil, 0
+	}
+	return cursor.cursor, cursor.excluded
+}
+
+// set records where the listing of a VCS instance of a config generation was
+// interrupted
+func (c *listingCursors) set(
+	config types.NamespacedName, baseURL, generation string, cursor *vcs.ListCursor, excluded int64,
+) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.cursors == nil {
+		c.cursors = make(map[listingKey]listingCursor)
+	}
+	c.cursors[listingKey{config: config, baseURL: baseURL}] = listingCursor{
+		cursor:     cursor,
+		generation: generation,
+		excluded:   excluded,
+	}
+}
+
+// done starts the next listing of a VCS instance of a config from the first
+// page
+func (c *listingCursors) done(config types.NamespacedName, baseURL string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.cursors, listingKey{config: config, baseURL: baseURL})
+}
+
+// reset starts the next listings of all VCS instances of a config from the
+// first page
+func (c *listingCursors) reset(config types.NamespacedName) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key := range c.cursors {
+		if key.config == config {
+			delete(c.cursors, key)
+		}
+	}
+}
+
+// resumingLister returns a lister of the repositories of a VCS instance of a
+// config that resumes where a rate limit interrupted the previous listing of
+// the same config generation, and records where the listing stops when
+// interrupted again. Repositories left out by filters before the cursor are
+// added to excluded.
+func (r *ConfigMapReconciler) resumingLister(
+	config types.NamespacedName, baseURL, generation string, lister vcs.ResumableLister, excluded *atomic.Int64,
+) repositoryLister {
+	return func(ctx context.Context, organization string) ([]string, error) {
+		cursor, skipped := r.listings.get(config, baseURL, generation)
+		if cursor != nil {
+			log.FromContext(ctx).Info("Resuming repository listing interrupted by a rate limit",
+				"organization", organization,
+				"baseURL", baseURL,
+				"listed", len(cursor.Repositories))
+		}
+
+		start := excluded.Add(skipped) - skipped
+		repos, next, err := lister.ResumeRepositories(ctx, organization, cursor)
+		if next != nil {
+			r.listings.set(config, baseURL, generation, next, excluded.Load()-start)
+			return nil, err
+		}
+		r.listings.done(config, baseURL)
+		return repos, err
+	}
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/giantswarm/openssf-scorecard-exporter/internal/vcs"
+)
+
+// resumingProvider is a fakeProvider that lists repositories over pages,
+// rate limiting the first listing that reaches a page, and records the page
+// each listing starts at
+type resumingProvider struct {
+	*fakeProvider
+
+	pages   [][]string
+	limited int
+	starts  []int
+}
+
+func (p *resumingProvider) ResumeRepositories(
+	_ context.Context, _ string, cursor *vcs.ListCursor,
+) ([]string, *vcs.ListCursor, error) {
+	page := 1
+	var repos []string
+	if cursor != nil {
+		page, repos = cursor.Page, slices.Clone(cursor.Repositories)
+	}
+	p.starts = append(p.starts, page)
+
+	for ; page <= len(p.pages); page++ {
+		if page == p.limited {
+			p.limited = 0
+			return nil, &vcs.ListCursor{Page: page, Repositories: repos},
+				vcs.NewRateLimitError(vcs.ProviderTypeGitHub, "rate limited").WithRetryAfter(time.Minute)
+		}
+		repos = append(repos, p.pages[page-1]...)
+	}
+	return repos, nil, nil
+}
+
+func TestReconcileResumesListing(t *testing.T) {
+	tests := []struct {
+		name string
+
+		// changed changes the config after the rate limit
+		changed        bool
+		expectedStarts []int
+	}{
+		{
+			name:           "resumes at the rate limited page",
+			expectedStarts: []int{1, 2, 1},
+		},
+		{
+			name:           "starts over after a config change",
+			changed:        true,
+			expectedStarts: []int{1, 1, 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := &resumingProvider{
+				fakeProvider: &fakeProvider{},
+				pages:        [][]string{{"repo"}, {"missing-a"}, {"missing-b"}},
+				limited:      2,
+			}
+			r := newTestReconciler(t, nil, newTestConfigMap(nil))
+			r.ProviderFactory.Register(fakeProviderType, func(*vcs.Config) (vcs.Provider, error) {
+				return provider, nil
+			})
+
+			result, err := r.Reconcile(context.Background(), testRequest)
+			if err != nil {
+				t.Fatalf("Reconcile() unexpected error: %v", err)
+			}
+			if result.RequeueAfter != time.Minute {
+				t.Errorf("Reconcile() RequeueAfter = %v, want %v", result.RequeueAfter, time.Minute)
+			}
+
+			if tt.changed {
+				var configMap corev1.ConfigMap
+				if err := r.Get(context.Background(), testRequest.NamespacedName, &configMap); err != nil {
+					t.Fatalf("failed to get ConfigMap: %v", err)
+				}
+				configMap.Data[VisibilityKey] = string(vcs.VisibilityAll)
+				if err := r.Update(context.Background(), &configMap); err != nil {
+					t.Fatalf("failed to update ConfigMap: %v", err)
+				}
+			}
+
+			// The listing completes, and the next one starts from the first page
+			for range 2 {
+				if _, err := r.Reconcile(context.Background(), testRequest); err != nil {
+					t.Fatalf("Reconcile() unexpected error: %v", err)
+				}
+			}
+
+			if !slices.Equal(provider.starts, tt.expectedStarts) {
+				t.Errorf("listings started at pages %v, want %v", provider.starts, tt.expectedStarts)
+			}
+			requests := r.ScorecardSource.(*fakeSource).requests
+			for _, repo := range []string{"repo", "missing-a", "missing-b"} {
+				if !slices.Contains(requests, "github.com/org/"+repo+"|") {
+					t.Errorf("scorecard requests = %v, want a request for %s", requests, repo)
+				}
+			}
+		})
+	}
+}
+
+func TestListingCursors(t *testing.T) {
+	var c listingCursors
+	config := types.NamespacedName{Namespace: "default", Name: "config"}
+	other := types.NamespacedName{Namespace: "default", Name: "other"}
+
+	c.set(config, "https://github.com", "gen", &vcs.ListCursor{Page: 2}, 1)
+	c.set(config, "https://github.example.com", "gen", &vcs.ListCursor{Page: 3}, 2)
+	c.set(other, "https://github.com", "gen", &vcs.ListCursor{Page: 4}, 0)
+
+	// Each VCS instance of a config has its own cursor
+	if cursor, excluded := c.get(config, "https://github.example.com", "gen"); cursor == nil || cursor.Page != 3 || excluded != 2 {
+		t.Errorf("get() = %+v, %d, want page 3 with 2 excluded", cursor, excluded)
+	}
+
+	// A listing with a changed configuration starts from the first page
+	if cursor, _ := c.get(config, "https://github.example.com", "next"); cursor != nil {
+		t.Errorf("get() of another generation = %+v, want nil", cursor)
+	}
+
+	// A completed listing leaves the other instances alone
+	c.done(config, "https://github.com")
+	if cursor, _ := c.get(config, "https://github.com", "gen"); cursor != nil {
+		t.Errorf("get() = %+v after done(), want nil", cursor)
+	}
+	if cursor, _ := c.get(config, "https://github.example.com", "gen"); cursor == nil {
+		t.Error("get() of another instance = nil after done(), want its cursor")
+	}
+
+	// Resetting a config leaves the other configs alone
+	c.reset(config)
+	if cursor, _ := c.get(config, "https://github.example.com", "gen"); cursor != nil {
+		t.Errorf("get() = %+v after reset(), want nil", cursor)
+	}
+	if cursor, _ := c.get(other, "https://github.com", "gen"); cursor == nil || cursor.Page != 4 {
+		t.Errorf("get() of another config = %+v after reset(), want page 4", cursor)
+	}
+}
@@ -0,0 +1,183 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/giantswarm/openssf-scorecard-exporter/internal/vcs"
+)
+
+// DefaultProviderCacheSize is the default number of VCS providers kept for reuse
+const DefaultProviderCacheSize = 100
+
+// providerCache reuses VCS providers, along with their HTTP clients and
+// connection pools, between reconciles and between configs using the same
+// credentials. The least recently used providers are evicted beyond the cache
+// size. The provider a config used is evicted as soon as its token or settings
+// change, so that a rotated token takes effect on the next reconcile and the
+// previous one is not kept around. It is safe for concurrent use.
+type providerCache struct {
+	mu sync.Mutex
+
+	// lru orders the cached providers from most to least recently used
+	lru *list.List
+
+	// entries indexes lru by key
+	entries map[providerCacheKey]*list.Element
+
+	// instances records the key of the provider last used by each VCS
+	// instance of a config
+	instances map[providerInstance]providerCacheKey
+}
+
+// providerCacheKey identifies the providers that can be shared. Credentials
+// are hashed into a fixed-size key that changes with them, so that a rotated
+// token selects a new provider. The cached providers still hold the token.
+type providerCacheKey struct {
+	providerType vcs.ProviderType
+	baseURL      string
+	tokenHash    string
+
+	// settingsHash is the hash of the settings a provider selects
+	// repositories by, which are fixed when it is created
+	settingsHash string
+}
+
+// providerInstance identifies a VCS instance of a config
+type providerInstance struct {
+	config  types.NamespacedName
+	baseURL string
+}
+
+// cachedProvider is a provider in the lru list
+type cachedProvider struct {
+	key      providerCacheKey
+	provider vcs.Provider
+}
+
+// newProviderCacheKey returns the cache key of the provider for a configuration.
+// The transport and rate limiter are shared by all providers and left out.
+func newProviderCacheKey(config *vcs.Config) providerCacheKey {
+	return providerCacheKey{
+		providerType: config.Type,
+		baseURL:      config.BaseURL,
+		tokenHash: hashFields(config.AccessToken(), config.Username,
+			config.AppID, config.InstallationID, string(config.AppPrivateKey)),
+		settingsHash: hashFields(config.OwnerType, config.Visibility, config.Affiliations, config.Team,
+			config.IncludeSubgroups, config.GraphQL, config.MaxRepositories, config.MaxRepositoryAge),
+	}
+}
+
+// hashFields returns the hex-encoded SHA-256 hash of the quoted fields
+func hashFields(fields ...any) string {
+	hash := sha256.New()
+	for _, field := range fields {
+		fmt.Fprintf(hash, "%q ", fmt.Sprint(field))
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// get returns the provider for a VCS instance of a config, calling create for
+// a new one if none is cached for vcsConfig. At most size providers are
+// cached, and none if size is zero.
+func (c *providerCache) get(
+	config types.NamespacedName, vcsConfig *vcs.Config, size int, create func(*vcs.Config) (vcs.Provider, error),
+) (vcs.Provider, error) {
+	if size <= 0 {
+		return create(vcsConfig)
+	}
+
+	key := newProviderCacheKey(vcsConfig)
+	instance := providerInstance{config: config, baseURL: vcsConfig.BaseURL}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.lru == nil {
+		c.lru = list.New()
+		c.entries = make(map[providerCacheKey]*list.Element)
+		c.instances = make(map[providerInstance]providerCacheKey)
+	}
+
+	// Evict the provider of a previous token or settings, unless other
+	// configs still use it
+	if previous, ok := c.instances[instance]; ok && previous != key {
+		delete(c.instances, instance)
+		c.evictUnused(previous)
+	}
+
+	if element, ok := c.entries[key]; ok {
+		c.lru.MoveToFront(element)
+		c.instances[instance] = key
+		return element.Value.(cachedProvider).provider, nil
+	}
+
+	provider, err := create(vcsConfig)
+	if err != nil {
+		return nil, err
+	}
+	c.entries[key] = c.lru.PushFront(cachedProvider{key: key, provider: provider})
+	c.instances[instance] = key
+
+	for c.lru.Len() > size {
+		c.evict(c.lru.Back().Value.(cachedProvider).key)
+	}
+	return provider, nil
+}
+
+// remove evicts the providers of a config that no other config uses
+func (c *providerCache) remove(config types.NamespacedName) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for instance, key := range c.instances {
+		if instance.config == config {
+			delete(c.instances, instance)
+			c.evictUnused(key)
+		}
+	}
+}
+
+// evictUnused evicts a provider if no VCS instance uses it
+func (c *providerCache) evictUnused(key providerCacheKey) {
+	for _, used := range c.instances {
+		if used == key {
+			return
+		}
+	}
+	c.evict(key)
+}
+
+// evict removes a provider from the cache, along with the VCS instances using it
+func (c *providerCache) evict(key providerCacheKey) {
+	if element, ok := c.entries[key]; ok {
+		c.lru.Remove(element)
+		delete(c.entries, key)
+	}
+	for instance, used := range c.instances {
+		if used == key {
+			delete(c.instances, instance)
+		}
+	}
+}
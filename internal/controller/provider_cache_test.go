@@ -0,0 +1,145 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/giantswarm/openssf-scorecard-exporter/internal/vcs"
+)
+
+// providerCacheStep gets the provider of a config with a token
+type providerCacheStep struct {
+	config string
+	token  string
+}
+
+func TestProviderCache(t *testing.T) {
+	tests := []struct {
+		name string
+		size int
+
+		// steps lists the config and token of each get
+		steps []providerCacheStep
+
+		// expectedCreated lists the tokens providers were created with
+		expectedCreated []string
+
+		// expectedCached lists the tokens of the cached providers
+		expectedCached []string
+	}{
+		{
+			name:            "identical config hits",
+			size:            10,
+			steps:           []providerCacheStep{{"a", "token"}, {"a", "token"}, {"b", "token"}},
+			expectedCreated: []string{"token"},
+			expectedCached:  []string{"token"},
+		},
+		{
+			name:            "token change misses and evicts the previous token",
+			size:            10,
+			steps:           []providerCacheStep{{"a", "old-token"}, {"a", "new-token"}, {"a", "new-token"}},
+			expectedCreated: []string{"old-token", "new-token"},
+			expectedCached:  []string{"new-token"},
+		},
+		{
+			name:            "previous token kept while used by another config",
+			size:            10,
+			steps:           []providerCacheStep{{"a", "old-token"}, {"b", "old-token"}, {"a", "new-token"}},
+			expectedCreated: []string{"old-token", "new-token"},
+			expectedCached:  []string{"new-token", "old-token"},
+		},
+		{
+			name:            "least recently used evicted",
+			size:            2,
+			steps:           []providerCacheStep{{"a", "token-a"}, {"b", "token-b"}, {"a", "token-a"}, {"c", "token-c"}, {"b", "token-b"}},
+			expectedCreated: []string{"token-a", "token-b", "token-c", "token-b"},
+			expectedCached:  []string{"token-b", "token-c"},
+		},
+		{
+			name:            "disabled",
+			steps:           []providerCacheStep{{"a", "token"}, {"a", "token"}},
+			expectedCreated: []string{"token", "token"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cache providerCache
+			var created []string
+			create := func(config *vcs.Config) (vcs.Provider, error) {
+				created = append(created, config.Token)
+				return &fakeProvider{}, nil
+			}
+
+			for _, step := range tt.steps {
+				config := &vcs.Config{Type: fakeProviderType, Token: step.token}
+				name := types.NamespacedName{Namespace: "default", Name: step.config}
+				if _, err := cache.get(name, config, tt.size, create); err != nil {
+					t.Fatalf("get(%s, %q) unexpected error: %v", step.config, step.token, err)
+				}
+			}
+
+			if !slices.Equal(created, tt.expectedCreated) {
+				t.Errorf("providers created with tokens %v, want %v", created, tt.expectedCreated)
+			}
+			var cached []string
+			for _, token := range tt.expectedCached {
+				key := newProviderCacheKey(&vcs.Config{Type: fakeProviderType, Token: token})
+				if _, ok := cache.entries[key]; ok {
+					cached = append(cached, token)
+				}
+			}
+			if !slices.Equal(cached, tt.expectedCached) || len(cache.entries) != len(tt.expectedCached) {
+				t.Errorf("cached %d providers, want those of tokens %v", len(cache.entries), tt.expectedCached)
+			}
+		})
+	}
+}
+
+func TestProviderCacheRemove(t *testing.T) {
+	var cache providerCache
+	a := types.NamespacedName{Namespace: "default", Name: "a"}
+	b := types.NamespacedName{Namespace: "default", Name: "b"}
+	create := func(*vcs.Config) (vcs.Provider, error) { return &fakeProvider{}, nil }
+
+	_, _ = cache.get(a, &vcs.Config{Token: "shared"}, 10, create)
+	_, _ = cache.get(b, &vcs.Config{Token: "shared"}, 10, create)
+	_, _ = cache.get(a, &vcs.Config{Token: "token-a", BaseURL: "https://ghe.example.com/"}, 10, create)
+
+	// Only providers no other config uses are evicted
+	cache.remove(a)
+	if len(cache.entries) != 1 {
+		t.Errorf("cached %d providers after removing a, want the shared one", len(cache.entries))
+	}
+	cache.remove(b)
+	if len(cache.entries) != 0 || len(cache.instances) != 0 {
+		t.Errorf("cached %d providers for %d instances, want none", len(cache.entries), len(cache.instances))
+	}
+
+	// Providers that cannot be created are not cached
+	_, err := cache.get(a, &vcs.Config{Token: "invalid"}, 10, func(*vcs.Config) (vcs.Provider, error) {
+		return nil, errors.New("invalid token")
+	})
+	if err == nil || len(cache.entries) != 0 {
+		t.Errorf("get() = %v with %d cached providers, want an error and none", err, len(cache.entries))
+	}
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/giantswarm/openssf-scorecard-exporter/internal/scorecard"
+	"github.com/giantswarm/openssf-scorecard-exporter/internal/vcs"
+)
+
+// scorecardRef is the branch or commit a ConfigMap reports on. When both are
+// empty, the latest report for the default branch is used.
+type scorecardRef struct {
+	branch string
+	commit string
+
+	// resolver resolves branch to a commit for each repository
+	resolver vcs.CommitResolver
+}
+
+// parseScorecardRef reads the branch or commit to report on from a ConfigMap
+func parseScorecardRef(configMap *corev1.ConfigMap, provider vcs.Provider) (scorecardRef, error) {
+	ref := scorecardRef{
+		branch: configMap.Data[BranchKey],
+		commit: configMap.Data[CommitKey],
+	}
+
+	if ref.branch != "" && ref.commit != "" {
+		return ref, fmt.Errorf("%w: only one of %s and %s may be set", errInvalidConfig, BranchKey, CommitKey)
+	}
+
+	if ref.branch != "" {
+		resolver, ok := provider.(vcs.CommitResolver)
+		if !ok {
+			return ref, fmt.Errorf("%w: %s is not supported by the %s provider",
+				errInvalidConfig, BranchKey, provider.GetProviderType())
+		}
+		ref.resolver = resolver
+	}
+
+	return ref, nil
+}
+
+// fetchOptions returns the options to fetch the report for a repository at
+// the configured ref. A branch that does not exist in the repository results
+// in an error wrapping vcs.ErrNotFound.
+func (ref scorecardRef) fetchOptions(ctx context.Context, organization, repository string) ([]scorecard.FetchOption, error) {
+	commit := ref.commit
+	if ref.branch != "" {
+		var err error
+		commit, err = ref.resolver.ResolveCommit(ctx, organization, repository, ref.branch)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if commit == "" {
+		return nil, nil
+	}
+	return []scorecard.FetchOption{scorecard.AtCommit(commit)}, nil
+}
@@ -0,0 +1,306 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	scorecardv1alpha1 "github.com/giantswarm/openssf-scorecard-exporter/api/v1alpha1"
+	"github.com/giantswarm/openssf-scorecard-exporter/internal/utils"
+)
+
+// targetConfigPrefix sets the names of ScorecardTarget configs apart from those
+// of ConfigMaps, e.g. in the config label of their metrics
+const targetConfigPrefix = "scorecardtarget/"
+
+// ScorecardTargetReconciler reconciles ScorecardTarget objects. Their spec is
+// translated to the data of a scorecard ConfigMap and reconciled by the
+// ConfigMap reconciler, sharing its VCS providers, scorecard sources and
+// metrics collector.
+type ScorecardTargetReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+
+	// ConfigMaps exports the metrics of the translated ScorecardTargets
+	ConfigMaps *ConfigMapReconciler
+}
+
+// +kubebuilder:rbac:groups=openssf-scorecard.giantswarm.io,resources=scorecardtargets,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=openssf-scorecard.giantswarm.io,resources=scorecardtargets/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=openssf-scorecard.giantswarm.io,resources=scorecardtargets/finalizers,verbs=update
+
+// Reconcile exports scorecard metrics for a ScorecardTarget
+func (r *ScorecardTargetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	// Targets are tracked under their own key, so that they don't share
+	// metrics and state with a ConfigMap of the same name
+	config := targetConfig(req.NamespacedName)
+	defer r.ConfigMaps.inFlight.start()()
+	unlock := r.ConfigMaps.locks.lock(config)
+	defer unlock()
+	defer r.ConfigMaps.pushMetrics(ctx)
+
+	var target scorecardv1alpha1.ScorecardTarget
+	if err := r.Get(ctx, req.NamespacedName, &target); err != nil {
+		// ScorecardTarget not found, likely deleted. Remove metrics for it.
+		r.ConfigMaps.forgetConfig(config)
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if !target.DeletionTimestamp.IsZero() {
+		return r.ConfigMaps.finalize(ctx, config, &target)
+	}
+
+	// Make sure metrics are cleaned up when the ScorecardTarget is deleted
+	if ok, result, err := r.ConfigMaps.ensureFinalizer(ctx, &target); !ok {
+		return result, err
+	}
+
+	// Spread the first reconciles after startup over InitialSyncWindow, unless
+	// a scan was requested
+	scanNow, requested := scanRequested(&target)
+	if requested {
+		logger.Info("Scan requested", "scanNow", scanNow)
+	} else if delay := r.ConfigMaps.initialSync.delay(config, r.ConfigMaps.InitialSyncWindow); delay > 0 {
+		logger.V(1).Info("Postponing initial reconcile", "delay", delay)
+		return ctrl.Result{RequeueAfter: delay}, nil
+	}
+
+	status := &reconcileStatus{scanNow: scanNow, refresh: requested}
+	result, err := r.ConfigMaps.reconcileConfigMap(ctx, ctrl.Request{NamespacedName: config}, targetConfigMap(&target), &target, status)
+	if err != nil {
+		status.err = err
+	}
+	r.ConfigMaps.DebugState.Record(config.String(), status.repositories, status.partial, status.err)
+
+	// Successful reconciles requeue at the interval of the ScorecardTarget,
+	// unless they left repositories to continue with
+	if status.err == nil && !status.partial && target.Spec.RequeueInterval != nil {
+		result = utils.JitterRequeue(target.Spec.RequeueInterval.Duration, r.ConfigMaps.MaxJitterPercent, logger)
+	}
+
+	if err := r.recordScanNow(ctx, &target, status.scanNow); err != nil {
+		logger.Error(err, "Failed to record the handled scan request")
+	}
+	if statusErr := r.updateStatus(ctx, &target, status); statusErr != nil {
+		logger.Error(statusErr, "Failed to update ScorecardTarget status")
+	}
+
+	return result, err
+}
+
+// targetConfig returns the key the metrics and state of a ScorecardTarget are
+// tracked by, which sets it apart from a ConfigMap of the same name
+func targetConfig(target types.NamespacedName) types.NamespacedName {
+	return types.NamespacedName{Namespace: target.Namespace, Name: targetConfigPrefix + target.Name}
+}
+
+// updateStatus writes the outcome of a reconcile to the status of a ScorecardTarget
+func (r *ScorecardTargetReconciler) updateStatus(
+	ctx context.Context, target *scorecardv1alpha1.ScorecardTarget, status *reconcileStatus,
+) error {
+	patch := client.MergeFrom(target.DeepCopy())
+	target.Status.ObservedGeneration = target.Generation
+	if status.err != nil {
+		target.Status.LastError = status.err.Error()
+	} else {
+		now := metav1.Now()
+		target.Status.LastReconcileTime = &now
+		target.Status.Repositories = status.repositories
+		target.Status.LastError = ""
+	}
+	return r.Status().Patch(ctx, target, patch)
+}
+
+// recordScanNow records the value of the scan-now annotation handled by a
+// reconcile in the LastScanNowAnnotation of a ScorecardTarget, so that the
+// scan is not requested again
+func (r *ScorecardTargetReconciler) recordScanNow(
+	ctx context.Context, target *scorecardv1alpha1.ScorecardTarget, scanNow string,
+) error {
+	if target.Annotations[LastScanNowAnnotation] == scanNow {
+		return nil
+	}
+
+	patch := client.MergeFrom(target.DeepCopy())
+	if scanNow == "" {
+		delete(target.Annotations, LastScanNowAnnotation)
+	} else {
+		metav1.SetMetaDataAnnotation(&target.ObjectMeta, LastScanNowAnnotation, scanNow)
+	}
+	return r.Patch(ctx, target, patch)
+}
+
+// userAnnotationsChanged passes updates of a ScorecardTarget changing its
+// annotations, except for the status annotations written by the controller
+func userAnnotationsChanged() predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return !maps.Equal(withoutStatusAnnotations(e.ObjectOld.GetAnnotations()),
+				withoutStatusAnnotations(e.ObjectNew.GetAnnotations()))
+		},
+	}
+}
+
+// withoutStatusAnnotations returns a copy of annotations without the status
+// annotations
+func withoutStatusAnnotations(annotations map[string]string) map[string]string {
+	c := maps.Clone(annotations)
+	for _, key := range statusAnnotations {
+		delete(c, key)
+	}
+	return c
+}
+
+// targetConfigMap translates a ScorecardTarget to the equivalent scorecard
+// ConfigMap, carrying over its annotations such as dry-run
+func targetConfigMap(target *scorecardv1alpha1.ScorecardTarget) *corev1.ConfigMap {
+	spec := target.Spec
+	data := map[string]string{
+		OrganizationKey:     spec.Organization,
+		ProviderTypeKey:     spec.ProviderType,
+		OwnerTypeKey:        spec.OwnerType,
+		VisibilityKey:       spec.Filters.Visibility,
+		BaseURLKey:          strings.Join(spec.BaseURLs, ","),
+		UsernameKey:         spec.Username,
+		SourceKey:           spec.Source,
+		IncludeSubgroupsKey: strconv.FormatBool(spec.Filters.IncludeSubgroups),
+		RepositoryInfoKey:   strconv.FormatBool(spec.RepositoryInfo),
+		GraphQLKey:          strconv.FormatBool(spec.GraphQL),
+		ChecksKey:           strings.Join(spec.Filters.Checks, ","),
+		AffiliationKey:      strings.Join(spec.Filters.Affiliations, ","),
+		TeamKey:             spec.Filters.Team,
+		SearchQueryKey:      spec.Filters.SearchQuery,
+		UnavailableValueKey: spec.UnavailableValue,
+	}
+	if spec.ScorecardAPIEndpoint != "" {
+		data[ScorecardAPIEndpointKey] = spec.ScorecardAPIEndpoint
+	}
+	if spec.Branch != "" {
+		data[BranchKey] = spec.Branch
+	}
+	if spec.Commit != "" {
+		data[CommitKey] = spec.Commit
+	}
+	if len(spec.CheckWeights) > 0 {
+		weights := make([]string, 0, len(spec.CheckWeights))
+		for _, check := range slices.Sorted(maps.Keys(spec.CheckWeights)) {
+			weights = append(weights, check+"="+spec.CheckWeights[check])
+		}
+		data[CheckWeightsKey] = strings.Join(weights, ",")
+	}
+	if spec.Filters.MaxRepoAgeDays != 0 {
+		data[MaxRepoAgeDaysKey] = strconv.Itoa(int(spec.Filters.MaxRepoAgeDays))
+	}
+	if spec.Filters.MaxRepositories != nil {
+		data[MaxRepositoriesKey] = strconv.Itoa(int(*spec.Filters.MaxRepositories))
+	}
+	if spec.PassThreshold != nil {
+		data[PassThresholdKey] = strconv.Itoa(int(*spec.PassThreshold))
+	}
+	if spec.TokenSecretRef != nil {
+		data[TokenSecretKey] = spec.TokenSecretRef.Name
+		data[TokenSecretKeyName] = spec.TokenSecretRef.Key
+	}
+	if app := spec.GitHubApp; app != nil {
+		data[AppIDKey] = strconv.FormatInt(app.AppID, 10)
+		data[InstallationIDKey] = strconv.FormatInt(app.InstallationID, 10)
+		data[AppPrivateKeySecretKey] = app.PrivateKeySecretRef.Name
+		data[AppPrivateKeySecretKeyName] = app.PrivateKeySecretRef.Key
+	}
+
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        target.Name,
+			Namespace:   target.Namespace,
+			Annotations: target.Annotations,
+		},
+		Data: data,
+	}
+}
+
+// targetSecretIndex is the field index of ScorecardTargets by the names of
+// the Secrets they reference
+const targetSecretIndex = "spec.secretRefs.name"
+
+// targetSecrets returns the names of the Secrets a ScorecardTarget references,
+// for targetSecretIndex
+func targetSecrets(object client.Object) []string {
+	spec := object.(*scorecardv1alpha1.ScorecardTarget).Spec
+	var names []string
+	if spec.TokenSecretRef != nil {
+		names = append(names, spec.TokenSecretRef.Name)
+	}
+	if spec.GitHubApp != nil {
+		names = append(names, spec.GitHubApp.PrivateKeySecretRef.Name)
+	}
+	return names
+}
+
+// targetsForSecret maps a Secret event to reconcile requests for every
+// ScorecardTarget that references it
+func (r *ScorecardTargetReconciler) targetsForSecret(ctx context.Context, secret client.Object) []reconcile.Request {
+	var targets scorecardv1alpha1.ScorecardTargetList
+	if err := r.List(ctx, &targets, client.InNamespace(secret.GetNamespace()),
+		client.MatchingFields{targetSecretIndex: secret.GetName()}); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list ScorecardTargets referencing Secret",
+			"secret", client.ObjectKeyFromObject(secret))
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(targets.Items))
+	for _, target := range targets.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&target)})
+	}
+	return requests
+}
+
+// SetupWithManager sets up the controller with the Manager
+func (r *ScorecardTargetReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &scorecardv1alpha1.ScorecardTarget{},
+		targetSecretIndex, targetSecrets); err != nil {
+		return err
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		// Status updates do not change the generation and need no reconcile
+		For(&scorecardv1alpha1.ScorecardTarget{}, builder.WithPredicates(
+			predicate.Or(predicate.GenerationChangedPredicate{}, userAnnotationsChanged()))).
+		// Re-reconcile ScorecardTargets when a referenced Secret changes
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.targetsForSecret)).
+		WithOptions(controller.Options{MaxConcurrentReconciles: r.ConfigMaps.MaxConcurrentReconciles}).
+		Complete(r)
+}
@@ -0,0 +1,422 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	scorecardv1alpha1 "github.com/giantswarm/openssf-scorecard-exporter/api/v1alpha1"
+	"github.com/giantswarm/openssf-scorecard-exporter/internal/metrics"
+	"github.com/giantswarm/openssf-scorecard-exporter/internal/scorecard"
+)
+
+// newTestTargetReconciler creates a ScorecardTarget reconciler backed by a
+// fake client, the given fake provider and a fake scorecard source
+func newTestTargetReconciler(t *testing.T, provider *fakeProvider, objects ...client.Object) (*ScorecardTargetReconciler, *prometheus.Registry) {
+	t.Helper()
+
+	testScheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(testScheme))
+	utilruntime.Must(scorecardv1alpha1.AddToScheme(testScheme))
+
+	configMaps := newTestReconciler(t, provider)
+	configMaps.Client = fake.NewClientBuilder().
+		WithScheme(testScheme).
+		WithObjects(objects...).
+		WithStatusSubresource(&scorecardv1alpha1.ScorecardTarget{}).
+		WithIndex(&scorecardv1alpha1.ScorecardTarget{}, targetSecretIndex, targetSecrets).
+		Build()
+	configMaps.Scheme = testScheme
+	registry := prometheus.NewRegistry()
+	configMaps.MetricsCollector = metrics.NewCollector(metrics.WithRegistry(registry))
+
+	return &ScorecardTargetReconciler{
+		Client:     configMaps.Client,
+		Scheme:     testScheme,
+		ConfigMaps: configMaps,
+	}, registry
+}
+
+// newTestTarget creates a ScorecardTarget using the fake provider, with the
+// same name as newTestConfigMap
+func newTestTarget() *scorecardv1alpha1.ScorecardTarget {
+	return &scorecardv1alpha1.ScorecardTarget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testRequest.Name,
+			Namespace: testRequest.Namespace,
+		},
+		Spec: scorecardv1alpha1.ScorecardTargetSpec{
+			Organization: "org",
+			ProviderType: string(fakeProviderType),
+		},
+	}
+}
+
+func TestScorecardTargetReconcile(t *testing.T) {
+	target := newTestTarget()
+	target.Spec.RequeueInterval = &metav1.Duration{Duration: 10 * time.Minute}
+	r, registry := newTestTargetReconciler(t, &fakeProvider{repos: []string{"repo", "missing"}}, target)
+	ctx := context.Background()
+
+	result, err := r.Reconcile(ctx, testRequest)
+	if err != nil {
+		t.Fatalf("Reconcile() unexpected error: %v", err)
+	}
+	if result.RequeueAfter < 9*time.Minute || result.RequeueAfter > 11*time.Minute {
+		t.Errorf("Reconcile() RequeueAfter = %v, want the ScorecardTarget interval with jitter", result.RequeueAfter)
+	}
+
+	config := "default/scorecardtarget/scorecard-config"
+	if total := gaugeValues(t, registry, "openssf_scorecard_repositories", "config"); total[config] != 2 {
+		t.Errorf("repositories = %v, want 2", total)
+	}
+
+	var updated scorecardv1alpha1.ScorecardTarget
+	if err := r.Get(ctx, testRequest.NamespacedName, &updated); err != nil {
+		t.Fatalf("failed to get ScorecardTarget: %v", err)
+	}
+	if updated.Status.Repositories != 2 || updated.Status.LastReconcileTime == nil || updated.Status.LastError != "" {
+		t.Errorf("status = %+v, want 2 repositories and no error", updated.Status)
+	}
+	if !slices.Contains(updated.Finalizers, MetricsFinalizer) {
+		t.Errorf("finalizers = %v, want %s", updated.Finalizers, MetricsFinalizer)
+	}
+
+	events := recordedEvents(r.ConfigMaps)
+	if len(events) != 1 || !strings.HasPrefix(events[0], "Normal "+EventReasonReconcileSucceeded) {
+		t.Errorf("events = %v, want a single %s event", events, EventReasonReconcileSucceeded)
+	}
+}
+
+func TestScorecardTargetReconcile_Failure(t *testing.T) {
+	target := newTestTarget()
+	target.Spec.RequeueInterval = &metav1.Duration{Duration: 10 * time.Minute}
+	r, _ := newTestTargetReconciler(t, &fakeProvider{err: errors.New("boom")}, target)
+	ctx := context.Background()
+
+	result, err := r.Reconcile(ctx, testRequest)
+	if err == nil {
+		t.Fatal("Reconcile() expected error")
+	}
+	if result.RequeueAfter != 0 {
+		t.Errorf("Reconcile() RequeueAfter = %v, want the error to be retried", result.RequeueAfter)
+	}
+
+	var updated scorecardv1alpha1.ScorecardTarget
+	if err := r.Get(ctx, testRequest.NamespacedName, &updated); err != nil {
+		t.Fatalf("failed to get ScorecardTarget: %v", err)
+	}
+	if !strings.Contains(updated.Status.LastError, "boom") || updated.Status.LastReconcileTime != nil {
+		t.Errorf("status = %+v, want the error", updated.Status)
+	}
+}
+
+func TestScorecardTargetReconcile_Deleted(t *testing.T) {
+	target := newTestTarget()
+	r, registry := newTestTargetReconciler(t, &fakeProvider{repos: []string{"repo"}}, target)
+	ctx := context.Background()
+
+	if _, err := r.Reconcile(ctx, testRequest); err != nil {
+		t.Fatalf("Reconcile() unexpected error: %v", err)
+	}
+
+	// The finalizer holds the ScorecardTarget until its metrics are removed
+	if err := r.Get(ctx, testRequest.NamespacedName, target); err != nil {
+		t.Fatalf("failed to get ScorecardTarget: %v", err)
+	}
+	if err := r.Delete(ctx, target); err != nil {
+		t.Fatalf("failed to delete ScorecardTarget: %v", err)
+	}
+	if _, err := r.Reconcile(ctx, testRequest); err != nil {
+		t.Fatalf("Reconcile() unexpected error: %v", err)
+	}
+
+	if total := gaugeValues(t, registry, "openssf_scorecard_repositories", "config"); len(total) != 0 {
+		t.Errorf("repositories = %v after deletion, want no series", total)
+	}
+	if err := r.Get(ctx, testRequest.NamespacedName, target); !apierrors.IsNotFound(err) {
+		t.Errorf("Get() error = %v, want the ScorecardTarget to be gone", err)
+	}
+}
+
+func TestScorecardTargetReconcile_SameNameConfigMap(t *testing.T) {
+	target := newTestTarget()
+	r, registry := newTestTargetReconciler(t, &fakeProvider{repos: []string{"repo"}}, target, newTestConfigMap(nil))
+	ctx := context.Background()
+
+	if _, err := r.ConfigMaps.Reconcile(ctx, testRequest); err != nil {
+		t.Fatalf("ConfigMap Reconcile() unexpected error: %v", err)
+	}
+	if _, err := r.Reconcile(ctx, testRequest); err != nil {
+		t.Fatalf("Reconcile() unexpected error: %v", err)
+	}
+
+	configs := []string{"default/scorecard-config", "default/scorecardtarget/scorecard-config"}
+	if total := gaugeValues(t, registry, "openssf_scorecard_repositories", "config"); !slices.Equal(slices.Sorted(maps.Keys(total)), configs) {
+		t.Errorf("repositories = %v, want series for %v", total, configs)
+	}
+
+	// Deleting the ScorecardTarget leaves the metrics of the ConfigMap alone
+	if err := r.Get(ctx, testRequest.NamespacedName, target); err != nil {
+		t.Fatalf("failed to get ScorecardTarget: %v", err)
+	}
+	if err := r.Delete(ctx, target); err != nil {
+		t.Fatalf("failed to delete ScorecardTarget: %v", err)
+	}
+	if _, err := r.Reconcile(ctx, testRequest); err != nil {
+		t.Fatalf("Reconcile() unexpected error: %v", err)
+	}
+
+	total := gaugeValues(t, registry, "openssf_scorecard_repositories", "config")
+	if len(total) != 1 || total["default/scorecard-config"] != 1 {
+		t.Errorf("repositories = %v after deleting the ScorecardTarget, want only the ConfigMap", total)
+	}
+}
+
+func TestScorecardTargetReconcile_ScanNow(t *testing.T) {
+	// Every request returns a higher score
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		score := requests.Add(1)
+		_, _ = fmt.Fprintf(w, `{"date": "%s", "repo": {"name": "github.com/org/repo"}, "score": %d}`,
+			time.Now().UTC().Format(time.RFC3339), score)
+	}))
+	t.Cleanup(server.Close)
+
+	target := newTestTarget()
+	r, registry := newTestTargetReconciler(t, &fakeProvider{repos: []string{"repo"}}, target)
+	r.ConfigMaps.ScorecardSource = scorecard.NewClient(
+		scorecard.WithAPIEndpoint(server.URL),
+		scorecard.WithCache(time.Hour, time.Hour),
+	)
+	ctx := context.Background()
+
+	if _, err := r.Reconcile(ctx, testRequest); err != nil {
+		t.Fatalf("Reconcile() unexpected error: %v", err)
+	}
+
+	// A requested scan is not postponed by the initial sync, fetches the
+	// report again and is recorded
+	r.ConfigMaps.InitialSyncWindow = time.Hour
+	r.ConfigMaps.initialSync.rnd = rand.New(rand.NewSource(1))
+	if err := r.Get(ctx, testRequest.NamespacedName, target); err != nil {
+		t.Fatalf("failed to get ScorecardTarget: %v", err)
+	}
+	metav1.SetMetaDataAnnotation(&target.ObjectMeta, ScanNowAnnotation, "1")
+	if err := r.Update(ctx, target); err != nil {
+		t.Fatalf("failed to update ScorecardTarget: %v", err)
+	}
+	if _, err := r.Reconcile(ctx, testRequest); err != nil {
+		t.Fatalf("Reconcile() unexpected error: %v", err)
+	}
+	if scores := overallScores(t, registry); requests.Load() != 2 || !maps.Equal(scores, map[string]float64{"repo": 2}) {
+		t.Errorf("scores = %v after %d requests, want the second report", scores, requests.Load())
+	}
+	if err := r.Get(ctx, testRequest.NamespacedName, target); err != nil {
+		t.Fatalf("failed to get ScorecardTarget: %v", err)
+	}
+	if got := target.Annotations[LastScanNowAnnotation]; got != "1" {
+		t.Errorf("annotation %s = %q, want \"1\"", LastScanNowAnnotation, got)
+	}
+
+	// A scan already handled is not requested again
+	result, err := r.Reconcile(ctx, testRequest)
+	if err != nil {
+		t.Fatalf("Reconcile() unexpected error: %v", err)
+	}
+	if result.RequeueAfter <= 0 || requests.Load() != 2 {
+		t.Errorf("Reconcile() RequeueAfter = %v after %d requests, want the reconcile postponed",
+			result.RequeueAfter, requests.Load())
+	}
+}
+
+func TestUserAnnotationsChanged(t *testing.T) {
+	base := newTestTarget()
+	base.Annotations = map[string]string{ScanNowAnnotation: "1"}
+
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		expected    bool
+	}{
+		{
+			name:        "scan-now recorded",
+			annotations: map[string]string{ScanNowAnnotation: "1", LastScanNowAnnotation: "1"},
+		},
+		{
+			name:        "scan requested",
+			annotations: map[string]string{ScanNowAnnotation: "2"},
+			expected:    true,
+		},
+		{
+			name:        "dry-run set",
+			annotations: map[string]string{ScanNowAnnotation: "1", DryRunAnnotation: "true"},
+			expected:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updated := base.DeepCopy()
+			updated.Annotations = tt.annotations
+			got := userAnnotationsChanged().Update(event.UpdateEvent{ObjectOld: base, ObjectNew: updated})
+			if got != tt.expected {
+				t.Errorf("Update() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTargetConfigMap(t *testing.T) {
+	maxRepositories := int32(0)
+	target := newTestTarget()
+	target.Annotations = map[string]string{DryRunAnnotation: "true"}
+	target.Spec.BaseURLs = []string{"default", "https://github.example.com"}
+	target.Spec.TokenSecretRef = &scorecardv1alpha1.SecretKeyReference{Name: "token", Key: "pat"}
+	target.Spec.Filters = scorecardv1alpha1.ScorecardTargetFilters{
+		Visibility:      "all",
+		MaxRepositories: &maxRepositories,
+		MaxRepoAgeDays:  90,
+		Checks:          []string{"Code-Review", "Fuzzing"},
+		Affiliations:    []string{"collaborator", "organization_member"},
+		Team:            "platform",
+		SearchQuery:     "topic:tier1",
+	}
+	target.Spec.GraphQL = true
+	target.Spec.ScorecardAPIEndpoint = "https://scorecard.example.com"
+	target.Spec.Branch = "release-1.0"
+	target.Spec.CheckWeights = map[string]string{"Fuzzing": "0.5", "Code-Review": "2"}
+	target.Spec.GitHubApp = &scorecardv1alpha1.GitHubAppReference{
+		AppID:               12345,
+		InstallationID:      67890,
+		PrivateKeySecretRef: scorecardv1alpha1.SecretKeyReference{Name: "app-key"},
+	}
+
+	configMap := targetConfigMap(target)
+
+	expected := map[string]string{
+		OrganizationKey:         "org",
+		ProviderTypeKey:         string(fakeProviderType),
+		VisibilityKey:           "all",
+		BaseURLKey:              "default,https://github.example.com",
+		TokenSecretKey:          "token",
+		TokenSecretKeyName:      "pat",
+		MaxRepositoriesKey:      "0",
+		MaxRepoAgeDaysKey:       "90",
+		ChecksKey:               "Code-Review,Fuzzing",
+		AffiliationKey:          "collaborator,organization_member",
+		TeamKey:                 "platform",
+		SearchQueryKey:          "topic:tier1",
+		GraphQLKey:              "true",
+		IncludeSubgroupsKey:     "false",
+		ScorecardAPIEndpointKey: "https://scorecard.example.com",
+		BranchKey:               "release-1.0",
+		CommitKey:               "",
+		CheckWeightsKey:         "Code-Review=2,Fuzzing=0.5",
+		AppIDKey:                "12345",
+		InstallationIDKey:       "67890",
+		AppPrivateKeySecretKey:  "app-key",
+	}
+	for key, value := range expected {
+		if configMap.Data[key] != value {
+			t.Errorf("data[%s] = %q, want %q", key, configMap.Data[key], value)
+		}
+	}
+	if configMap.Namespace != "default" || configMap.Name != testRequest.Name {
+		t.Errorf("ConfigMap = %s/%s, want the ScorecardTarget name", configMap.Namespace, configMap.Name)
+	}
+	if configMap.Annotations[DryRunAnnotation] != "true" {
+		t.Errorf("annotations = %v, want the dry-run annotation", configMap.Annotations)
+	}
+}
+
+func TestTargetsForSecret(t *testing.T) {
+	tokened := newTestTarget()
+	tokened.Name = "tokened"
+	tokened.Spec.TokenSecretRef = &scorecardv1alpha1.SecretKeyReference{Name: "github-token"}
+	app := newTestTarget()
+	app.Name = "app"
+	app.Spec.GitHubApp = &scorecardv1alpha1.GitHubAppReference{
+		AppID:               12345,
+		InstallationID:      67890,
+		PrivateKeySecretRef: scorecardv1alpha1.SecretKeyReference{Name: "github-token"},
+	}
+	other := newTestTarget()
+	other.Name = "other"
+	other.Spec.TokenSecretRef = &scorecardv1alpha1.SecretKeyReference{Name: "other-token"}
+	elsewhere := tokened.DeepCopy()
+	elsewhere.Namespace = "elsewhere"
+	r, _ := newTestTargetReconciler(t, &fakeProvider{}, tokened, app, other, elsewhere)
+
+	tests := []struct {
+		name     string
+		secret   types.NamespacedName
+		expected []string
+	}{
+		{
+			name:     "token and private key",
+			secret:   types.NamespacedName{Namespace: "default", Name: "github-token"},
+			expected: []string{"default/app", "default/tokened"},
+		},
+		{
+			name:     "other namespace",
+			secret:   types.NamespacedName{Namespace: "elsewhere", Name: "github-token"},
+			expected: []string{"elsewhere/tokened"},
+		},
+		{
+			name:   "unreferenced",
+			secret: types.NamespacedName{Namespace: "default", Name: "unused"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: tt.secret.Namespace, Name: tt.secret.Name}}
+			var got []string
+			for _, request := range r.targetsForSecret(context.Background(), secret) {
+				got = append(got, request.String())
+			}
+			slices.Sort(got)
+			if !slices.Equal(got, tt.expected) {
+				t.Errorf("targetsForSecret() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/giantswarm/openssf-scorecard-exporter/internal/vcs"
+)
+
+// repositoryLister lists the repositories of an organization
+type repositoryLister func(ctx context.Context, organization string) ([]string, error)
+
+// parseSearchQuery returns the search query discovering the repositories of
+// a ConfigMap, empty if unset. A query replaces the other ways of listing
+// repositories, so it cannot be combined with them.
+func parseSearchQuery(configMap *corev1.ConfigMap, team string, graphQL bool, affiliations []vcs.Affiliation) (string, error) {
+	query := strings.TrimSpace(configMap.Data[SearchQueryKey])
+	switch {
+	case query == "":
+		return "", nil
+	case team != "":
+		return "", fmt.Errorf("%w: %s cannot be combined with %s", errInvalidConfig, SearchQueryKey, TeamKey)
+	case graphQL:
+		return "", fmt.Errorf("%w: %s cannot be combined with %s", errInvalidConfig, SearchQueryKey, GraphQLKey)
+	case len(affiliations) > 0:
+		return "", fmt.Errorf("%w: %s cannot be combined with %s", errInvalidConfig, SearchQueryKey, AffiliationKey)
+	}
+	return query, nil
+}
+
+// searchLister returns a lister of the repositories of an organization
+// matching a search query. The query is scoped to the organization, and
+// repositories of other owners, which qualifiers of the query itself may
+// match, are left out.
+func searchLister(provider vcs.Provider, ownerType vcs.OwnerType, query string) (repositoryLister, error) {
+	searcher, ok := provider.(vcs.RepositorySearcher)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s is not supported by the %s provider",
+			errInvalidConfig, SearchQueryKey, provider.GetProviderType())
+	}
+
+	qualifier := "org"
+	if ownerType == vcs.OwnerTypeUser {
+		qualifier = "user"
+	}
+
+	return func(ctx context.Context, organization string) ([]string, error) {
+		fullNames, err := searcher.SearchRepositories(ctx, fmt.Sprintf("%s %s:%s", query, qualifier, organization))
+		if err != nil {
+			return nil, err
+		}
+
+		repos := make([]string, 0, len(fullNames))
+		for _, fullName := range fullNames {
+			owner, name, ok := strings.Cut(fullName, "/")
+			if ok && strings.EqualFold(owner, organization) {
+				repos = append(repos, name)
+			}
+		}
+		return repos, nil
+	}, nil
+}
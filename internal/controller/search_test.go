@@ -0,0 +1,136 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/giantswarm/openssf-scorecard-exporter/internal/vcs"
+)
+
+// searchingProvider is a fakeProvider that returns fixed search results and
+// records the queries
+type searchingProvider struct {
+	*fakeProvider
+
+	results []string
+	queries []string
+}
+
+func (p *searchingProvider) GetRepositories(_ context.Context, _ string) ([]string, error) {
+	return nil, errors.New("unexpected listing with a search query")
+}
+
+func (p *searchingProvider) SearchRepositories(_ context.Context, query string) ([]string, error) {
+	p.queries = append(p.queries, query)
+	return p.results, nil
+}
+
+func TestParseSearchQuery(t *testing.T) {
+	tests := []struct {
+		name         string
+		query        string
+		team         string
+		graphQL      bool
+		affiliations []vcs.Affiliation
+		expected     string
+		expectedErr  error
+	}{
+		{name: "unset"},
+		{name: "query", query: " topic:tier1 ", expected: "topic:tier1"},
+		{name: "with team", query: "topic:tier1", team: "platform", expectedErr: errInvalidConfig},
+		{name: "with GraphQL", query: "topic:tier1", graphQL: true, expectedErr: errInvalidConfig},
+		{
+			name:         "with affiliations",
+			query:        "topic:tier1",
+			affiliations: []vcs.Affiliation{vcs.AffiliationOwner},
+			expectedErr:  errInvalidConfig,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configMap := newTestConfigMap(map[string]string{SearchQueryKey: tt.query})
+			query, err := parseSearchQuery(configMap, tt.team, tt.graphQL, tt.affiliations)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("parseSearchQuery() error = %v, want %v", err, tt.expectedErr)
+			}
+			if query != tt.expected {
+				t.Errorf("parseSearchQuery() = %q, want %q", query, tt.expected)
+			}
+		})
+	}
+}
+
+func TestReconcileSearchQuery(t *testing.T) {
+	tests := []struct {
+		name            string
+		data            map[string]string
+		unsupported     bool
+		expectedQueries []string
+		expectedAPI     []string
+	}{
+		{
+			name:            "organization",
+			data:            map[string]string{SearchQueryKey: "topic:tier1"},
+			expectedQueries: []string{"topic:tier1 org:org"},
+			expectedAPI:     []string{"github.com/org/repo|", "github.com/org/missing|"},
+		},
+		{
+			name:            "user",
+			data:            map[string]string{SearchQueryKey: "topic:tier1", OwnerTypeKey: string(vcs.OwnerTypeUser)},
+			expectedQueries: []string{"topic:tier1 user:org"},
+			expectedAPI:     []string{"github.com/org/repo|", "github.com/org/missing|"},
+		},
+		{
+			name:        "provider without search",
+			data:        map[string]string{SearchQueryKey: "topic:tier1"},
+			unsupported: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Repositories of other owners are left out
+			provider := &searchingProvider{
+				fakeProvider: &fakeProvider{},
+				results:      []string{"org/repo", "Org/missing", "other/repo"},
+			}
+			r := newTestReconciler(t, nil, newTestConfigMap(tt.data))
+			r.ProviderFactory.Register(fakeProviderType, func(*vcs.Config) (vcs.Provider, error) {
+				if tt.unsupported {
+					return &fakeProvider{repos: []string{"repo"}}, nil
+				}
+				return provider, nil
+			})
+
+			if _, err := r.Reconcile(context.Background(), testRequest); err != nil {
+				t.Fatalf("Reconcile() unexpected error: %v", err)
+			}
+
+			if !slices.Equal(provider.queries, tt.expectedQueries) {
+				t.Errorf("search queries = %v, want %v", provider.queries, tt.expectedQueries)
+			}
+			if got := r.ScorecardSource.(*fakeSource).requests; !slices.Equal(got, tt.expectedAPI) {
+				t.Errorf("API source requests = %v, want %v", got, tt.expectedAPI)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"sync"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// secretIndex tracks which scorecard ConfigMaps reference which Secrets, so
+// that a change to a Secret can be mapped back to the ConfigMaps using it.
+// The index is populated during reconcile and is safe for concurrent use.
+type secretIndex struct {
+	mu sync.RWMutex
+
+	// refs maps a ConfigMap to the Secrets it references
+	refs map[types.NamespacedName][]types.NamespacedName
+}
+
+// set records the Secrets referenced by a ConfigMap, replacing any previous entry
+func (i *secretIndex) set(configMap types.NamespacedName, secrets []types.NamespacedName) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if len(secrets) == 0 {
+		delete(i.refs, configMap)
+		return
+	}
+
+	if i.refs == nil {
+		i.refs = make(map[types.NamespacedName][]types.NamespacedName)
+	}
+	i.refs[configMap] = secrets
+}
+
+// remove drops all references held by a ConfigMap
+func (i *secretIndex) remove(configMap types.NamespacedName) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	delete(i.refs, configMap)
+}
+
+// configMapsFor returns the ConfigMaps that reference the given Secret
+func (i *secretIndex) configMapsFor(secret types.NamespacedName) []types.NamespacedName {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	var configMaps []types.NamespacedName
+	for configMap, secrets := range i.refs {
+		for _, s := range secrets {
+			if s == secret {
+				configMaps = append(configMaps, configMap)
+				break
+			}
+		}
+	}
+	return configMaps
+}
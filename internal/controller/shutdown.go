@@ -0,0 +1,102 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// DefaultShutdownFlushTimeout bounds the time spent on shutdown waiting for
+// in-flight reconciles and pushing the final metrics
+const DefaultShutdownFlushTimeout = 10 * time.Second
+
+// inFlightReconciles counts the reconciles in progress so that shutdown can
+// wait for them to return. It is safe for concurrent use.
+type inFlightReconciles struct {
+	mu     sync.Mutex
+	active int
+
+	// idle is closed when no reconcile is in progress, nil otherwise
+	idle chan struct{}
+}
+
+// start records the start of a reconcile and returns the function recording
+// its end
+func (f *inFlightReconciles) start() func() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.active == 0 {
+		f.idle = make(chan struct{})
+	}
+	f.active++
+
+	return func() {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		if f.active--; f.active == 0 {
+			close(f.idle)
+		}
+	}
+}
+
+// wait blocks until no reconcile is in progress or ctx ends
+func (f *inFlightReconciles) wait(ctx context.Context) error {
+	f.mu.Lock()
+	idle := f.idle
+	active := f.active
+	f.mu.Unlock()
+	if active == 0 {
+		return nil
+	}
+
+	select {
+	case <-idle:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// flushMetricsOnShutdown blocks until ctx ends when the manager stops, then
+// waits for the in-flight reconciles, whose fetches are cancelled along with
+// ctx, and pushes the final metrics to MetricsPusher. It runs as a manager
+// runnable requiring leader election, so that only the leader pushes.
+func (r *ConfigMapReconciler) flushMetricsOnShutdown(ctx context.Context) error {
+	<-ctx.Done()
+	logger := log.FromContext(ctx)
+
+	timeout := r.ShutdownFlushTimeout
+	if timeout <= 0 {
+		timeout = DefaultShutdownFlushTimeout
+	}
+	flushCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), timeout)
+	defer cancel()
+
+	if err := r.inFlight.wait(flushCtx); err != nil {
+		logger.Error(err, "Timed out waiting for in-flight reconciles, pushing metrics anyway")
+	}
+	if err := r.MetricsPusher.Push(flushCtx); err != nil {
+		logger.Error(err, "Failed to push final metrics to the Pushgateway")
+		return nil
+	}
+	logger.Info("Pushed final metrics to the Pushgateway")
+	return nil
+}
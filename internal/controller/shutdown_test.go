@@ -0,0 +1,117 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/giantswarm/openssf-scorecard-exporter/internal/metrics"
+	"github.com/giantswarm/openssf-scorecard-exporter/internal/scorecard"
+)
+
+func TestInFlightReconciles(t *testing.T) {
+	var f inFlightReconciles
+
+	// Nothing to wait for
+	if err := f.wait(context.Background()); err != nil {
+		t.Fatalf("wait() with no reconcile unexpected error: %v", err)
+	}
+
+	first, second := f.start(), f.start()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := f.wait(ctx); err == nil {
+		t.Fatal("wait() with reconciles in progress error = nil, want context error")
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- f.wait(context.Background())
+	}()
+	first()
+	select {
+	case <-done:
+		t.Fatal("wait() returned while a reconcile is still in progress")
+	case <-time.After(50 * time.Millisecond):
+	}
+	second()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("wait() unexpected error: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("wait() did not return after the reconciles ended")
+	}
+}
+
+func TestShutdownCancelsInFlightFetch(t *testing.T) {
+	var pushes atomic.Int32
+	gateway := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		pushes.Add(1)
+	}))
+	defer gateway.Close()
+
+	r := newTestReconciler(t, &fakeProvider{repos: []string{"repo"}}, newTestConfigMap(nil))
+	r.ScorecardSource = scorecard.NewClient(scorecard.WithAPIEndpoint(newSlowServer(t).URL))
+	r.MetricsPusher = metrics.NewPusher(r.MetricsCollector, gateway.URL, "scorecard")
+
+	// The manager cancels the context of reconciles and runnables alike
+	ctx, cancel := context.WithCancel(context.Background())
+	reconciled := make(chan struct{})
+	go func() {
+		defer close(reconciled)
+		_, _ = r.Reconcile(ctx, testRequest)
+	}()
+	flushed := make(chan error, 1)
+	go func() {
+		flushed <- r.flushMetricsOnShutdown(ctx)
+	}()
+
+	// Shut down while the scorecard fetch is in flight
+	time.Sleep(100 * time.Millisecond)
+	start := time.Now()
+	cancel()
+
+	select {
+	case <-reconciled:
+	case <-time.After(time.Second):
+		t.Fatal("Reconcile() did not return promptly after shutdown")
+	}
+	select {
+	case err := <-flushed:
+		if err != nil {
+			t.Errorf("flushMetricsOnShutdown() unexpected error: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("flushMetricsOnShutdown() did not return promptly after shutdown")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("shutdown took %v, want the in-flight fetch to be cancelled", elapsed)
+	}
+
+	// The interrupted reconcile skips its push in favor of the final one
+	if got := pushes.Load(); got != 1 {
+		t.Errorf("pushes = %d, want 1 final push", got)
+	}
+}
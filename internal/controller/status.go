@@ -0,0 +1,165 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+const (
+	// LastReconcileAnnotation records when the ConfigMap was last reconciled successfully
+	LastReconcileAnnotation = "openssf-scorecard.giantswarm.io/last-reconcile"
+
+	// RepositoryCountAnnotation records the number of repositories exported by the last successful reconcile
+	RepositoryCountAnnotation = "openssf-scorecard.giantswarm.io/repo-count"
+
+	// LastErrorAnnotation records the error of the last failed reconcile, and is removed on success
+	LastErrorAnnotation = "openssf-scorecard.giantswarm.io/last-error"
+
+	// LastScanNowAnnotation records the value of ScanNowAnnotation handled by
+	// the last reconcile
+	LastScanNowAnnotation = "openssf-scorecard.giantswarm.io/last-scan-now"
+)
+
+// statusAnnotations are the annotations written back by the controller
+var statusAnnotations = []string{
+	LastReconcileAnnotation,
+	RepositoryCountAnnotation,
+	LastErrorAnnotation,
+	LastScanNowAnnotation,
+}
+
+// reconcileStatus captures the outcome of a reconcile
+type reconcileStatus struct {
+	// repositories is the number of repositories exported
+	repositories int
+
+	// err is the reason the reconcile failed, if any
+	err error
+
+	// partial is set when the reconcile ran out of its time budget before
+	// exporting all repositories
+	partial bool
+
+	// scanNow is the value of ScanNowAnnotation the reconcile handled
+	scanNow string
+
+	// refresh is set when a scan was requested, so that scorecard reports
+	// are fetched from the API instead of the cache or freshness window
+	refresh bool
+}
+
+// annotations returns the status annotations for the outcome. An empty value
+// means the annotation is removed.
+func (s *reconcileStatus) annotations(now time.Time) map[string]string {
+	if s.err != nil {
+		return map[string]string{
+			LastErrorAnnotation:   s.err.Error(),
+			LastScanNowAnnotation: s.scanNow,
+		}
+	}
+	return map[string]string{
+		LastReconcileAnnotation:   now.UTC().Format(time.RFC3339),
+		RepositoryCountAnnotation: strconv.Itoa(s.repositories),
+		LastErrorAnnotation:       "",
+		LastScanNowAnnotation:     s.scanNow,
+	}
+}
+
+// scanRequested returns the value of the scan-now annotation of a ConfigMap
+// or ScorecardTarget, and whether it differs from the value handled by the
+// last reconcile. Any change to the annotation triggers a reconcile, as it is
+// not a status annotation.
+func scanRequested(object metav1.Object) (string, bool) {
+	annotations := object.GetAnnotations()
+	scanNow := annotations[ScanNowAnnotation]
+	return scanNow, scanNow != "" && scanNow != annotations[LastScanNowAnnotation]
+}
+
+// patchStatusAnnotations writes the reconcile outcome to the ConfigMap's annotations
+// using a merge patch. The patch is skipped when the annotations are already up to date.
+func (r *ConfigMapReconciler) patchStatusAnnotations(ctx context.Context, configMap *corev1.ConfigMap, status *reconcileStatus) error {
+	patched := configMap.DeepCopy()
+	changed := false
+	for key, value := range status.annotations(time.Now()) {
+		current, exists := patched.Annotations[key]
+		switch {
+		case value == "" && exists:
+			delete(patched.Annotations, key)
+			changed = true
+		case value != "" && current != value:
+			if patched.Annotations == nil {
+				patched.Annotations = make(map[string]string)
+			}
+			patched.Annotations[key] = value
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+
+	if err := r.Patch(ctx, patched, client.MergeFrom(configMap)); err != nil {
+		return err
+	}
+	*configMap = *patched
+	return nil
+}
+
+// ignoreStatusAnnotationUpdates filters out ConfigMap updates that only touch
+// the status annotations, so writing them back does not trigger another reconcile
+func ignoreStatusAnnotationUpdates() predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldConfigMap, okOld := e.ObjectOld.(*corev1.ConfigMap)
+			newConfigMap, okNew := e.ObjectNew.(*corev1.ConfigMap)
+			if !okOld || !okNew {
+				return true
+			}
+			return !equality.Semantic.DeepEqual(withoutStatus(oldConfigMap), withoutStatus(newConfigMap))
+		},
+	}
+}
+
+// withoutStatus returns a copy of the ConfigMap without status annotations, our
+// finalizer and server-managed metadata, for comparing user-facing content
+func withoutStatus(configMap *corev1.ConfigMap) *corev1.ConfigMap {
+	c := configMap.DeepCopy()
+	c.ResourceVersion = ""
+	c.ManagedFields = nil
+	controllerutil.RemoveFinalizer(c, MetricsFinalizer)
+	if len(c.Finalizers) == 0 {
+		c.Finalizers = nil
+	}
+	for _, key := range statusAnnotations {
+		delete(c.Annotations, key)
+	}
+	if len(c.Annotations) == 0 {
+		c.Annotations = nil
+	}
+	return c
+}
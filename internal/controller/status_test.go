@@ -0,0 +1,239 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	"github.com/giantswarm/openssf-scorecard-exporter/internal/metrics"
+	"github.com/giantswarm/openssf-scorecard-exporter/internal/scorecard"
+)
+
+func TestReconcileStatusAnnotations(t *testing.T) {
+	r := newTestReconciler(t, &fakeProvider{repos: []string{"repo", "missing"}},
+		newTestConfigMap(map[string]string{TokenSecretKey: "missing"}))
+
+	// A failing reconcile records the error
+	_, _ = r.Reconcile(context.Background(), testRequest)
+
+	var configMap corev1.ConfigMap
+	if err := r.Get(context.Background(), testRequest.NamespacedName, &configMap); err != nil {
+		t.Fatalf("failed to get ConfigMap: %v", err)
+	}
+	if configMap.Annotations[LastErrorAnnotation] == "" {
+		t.Errorf("annotation %s not set after failed reconcile", LastErrorAnnotation)
+	}
+
+	// Failing again with the same error does not patch the ConfigMap
+	resourceVersion := configMap.ResourceVersion
+	_, _ = r.Reconcile(context.Background(), testRequest)
+	if err := r.Get(context.Background(), testRequest.NamespacedName, &configMap); err != nil {
+		t.Fatalf("failed to get ConfigMap: %v", err)
+	}
+	if configMap.ResourceVersion != resourceVersion {
+		t.Errorf("unchanged status patched the ConfigMap: resourceVersion %s -> %s",
+			resourceVersion, configMap.ResourceVersion)
+	}
+
+	// A successful reconcile records the repository count and clears the error
+	delete(configMap.Data, TokenSecretKey)
+	if err := r.Update(context.Background(), &configMap); err != nil {
+		t.Fatalf("failed to update ConfigMap: %v", err)
+	}
+	if _, err := r.Reconcile(context.Background(), testRequest); err != nil {
+		t.Fatalf("Reconcile() unexpected error: %v", err)
+	}
+	if err := r.Get(context.Background(), testRequest.NamespacedName, &configMap); err != nil {
+		t.Fatalf("failed to get ConfigMap: %v", err)
+	}
+	if got := configMap.Annotations[RepositoryCountAnnotation]; got != "2" {
+		t.Errorf("annotation %s = %q, want \"2\"", RepositoryCountAnnotation, got)
+	}
+	if configMap.Annotations[LastReconcileAnnotation] == "" {
+		t.Errorf("annotation %s not set after successful reconcile", LastReconcileAnnotation)
+	}
+	if _, exists := configMap.Annotations[LastErrorAnnotation]; exists {
+		t.Errorf("annotation %s not removed after successful reconcile", LastErrorAnnotation)
+	}
+}
+
+func TestReconcileScanNow(t *testing.T) {
+	ctx := context.Background()
+	configMap := newTestConfigMap(nil)
+	configMap.Annotations = map[string]string{ScanNowAnnotation: "1"}
+	r := newTestReconciler(t, &fakeProvider{repos: []string{"repo"}}, configMap)
+	source := r.ScorecardSource.(*fakeSource)
+	r.InitialSyncWindow = time.Hour
+	r.initialSync.rnd = rand.New(rand.NewSource(1))
+
+	// A requested scan is not postponed by the initial sync, and is recorded
+	if _, err := r.Reconcile(ctx, testRequest); err != nil {
+		t.Fatalf("Reconcile() unexpected error: %v", err)
+	}
+	if len(source.requests) != 1 {
+		t.Errorf("scorecard requests = %v, want one", source.requests)
+	}
+	if err := r.Get(ctx, testRequest.NamespacedName, configMap); err != nil {
+		t.Fatalf("failed to get ConfigMap: %v", err)
+	}
+	if got := configMap.Annotations[LastScanNowAnnotation]; got != "1" {
+		t.Errorf("annotation %s = %q, want \"1\"", LastScanNowAnnotation, got)
+	}
+
+	// A scan already handled is not requested again
+	result, err := r.Reconcile(ctx, testRequest)
+	if err != nil {
+		t.Fatalf("Reconcile() unexpected error: %v", err)
+	}
+	if result.RequeueAfter <= 0 || len(source.requests) != 1 {
+		t.Errorf("Reconcile() RequeueAfter = %v with scorecard requests %v, want the reconcile postponed",
+			result.RequeueAfter, source.requests)
+	}
+
+	// Changing the annotation requests another scan
+	configMap.Annotations[ScanNowAnnotation] = "2"
+	if err := r.Update(ctx, configMap); err != nil {
+		t.Fatalf("failed to update ConfigMap: %v", err)
+	}
+	if _, err := r.Reconcile(ctx, testRequest); err != nil {
+		t.Fatalf("Reconcile() unexpected error: %v", err)
+	}
+	if len(source.requests) != 2 {
+		t.Errorf("scorecard requests = %v, want two", source.requests)
+	}
+	if err := r.Get(ctx, testRequest.NamespacedName, configMap); err != nil {
+		t.Fatalf("failed to get ConfigMap: %v", err)
+	}
+	if got := configMap.Annotations[LastScanNowAnnotation]; got != "2" {
+		t.Errorf("annotation %s = %q, want \"2\"", LastScanNowAnnotation, got)
+	}
+}
+
+func TestReconcileScanNowRefresh(t *testing.T) {
+	// Every request returns a higher score
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		score := requests.Add(1)
+		_, _ = fmt.Fprintf(w, `{"date": "%s", "repo": {"name": "github.com/org/repo"}, "score": %d}`,
+			time.Now().UTC().Format(time.RFC3339), score)
+	}))
+	t.Cleanup(server.Close)
+
+	ctx := context.Background()
+	configMap := newTestConfigMap(nil)
+	r := newTestReconciler(t, &fakeProvider{repos: []string{"repo"}}, configMap)
+	r.ScorecardSource = scorecard.NewClient(
+		scorecard.WithAPIEndpoint(server.URL),
+		scorecard.WithCache(time.Hour, time.Hour),
+		scorecard.WithFreshnessWindow(24*time.Hour),
+	)
+	registry := prometheus.NewRegistry()
+	r.MetricsCollector = metrics.NewCollector(metrics.WithRegistry(registry))
+
+	// Without a requested scan, the report is served from the cache
+	for range 2 {
+		if _, err := r.Reconcile(ctx, testRequest); err != nil {
+			t.Fatalf("Reconcile() unexpected error: %v", err)
+		}
+	}
+	if scores := overallScores(t, registry); requests.Load() != 1 || !maps.Equal(scores, map[string]float64{"repo": 1}) {
+		t.Fatalf("scores = %v after %d requests, want the first report", scores, requests.Load())
+	}
+
+	// A requested scan fetches the report again
+	if err := r.Get(ctx, testRequest.NamespacedName, configMap); err != nil {
+		t.Fatalf("failed to get ConfigMap: %v", err)
+	}
+	configMap.Annotations[ScanNowAnnotation] = "1"
+	if err := r.Update(ctx, configMap); err != nil {
+		t.Fatalf("failed to update ConfigMap: %v", err)
+	}
+	if _, err := r.Reconcile(ctx, testRequest); err != nil {
+		t.Fatalf("Reconcile() unexpected error: %v", err)
+	}
+	if scores := overallScores(t, registry); requests.Load() != 2 || !maps.Equal(scores, map[string]float64{"repo": 2}) {
+		t.Errorf("scores = %v after %d requests, want the second report", scores, requests.Load())
+	}
+}
+
+func TestIgnoreStatusAnnotationUpdates(t *testing.T) {
+	base := newTestConfigMap(nil)
+	base.ResourceVersion = "1"
+
+	statusOnly := base.DeepCopy()
+	statusOnly.ResourceVersion = "2"
+	statusOnly.Annotations = map[string]string{
+		LastReconcileAnnotation:   "2025-01-01T00:00:00Z",
+		RepositoryCountAnnotation: "5",
+		LastScanNowAnnotation:     "1",
+	}
+
+	dataChanged := base.DeepCopy()
+	dataChanged.ResourceVersion = "2"
+	dataChanged.Data[OrganizationKey] = "other"
+
+	otherAnnotation := base.DeepCopy()
+	otherAnnotation.ResourceVersion = "2"
+	otherAnnotation.Annotations = map[string]string{"example.com/note": "changed"}
+
+	scanRequested := base.DeepCopy()
+	scanRequested.ResourceVersion = "2"
+	scanRequested.Annotations = map[string]string{ScanNowAnnotation: "2025-01-01T00:00:00Z"}
+
+	finalizerAdded := base.DeepCopy()
+	finalizerAdded.ResourceVersion = "2"
+	finalizerAdded.Finalizers = []string{MetricsFinalizer}
+
+	deleting := finalizerAdded.DeepCopy()
+	deleting.ResourceVersion = "3"
+	deleting.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+
+	tests := []struct {
+		name     string
+		updated  *corev1.ConfigMap
+		expected bool
+	}{
+		{name: "status annotations only", updated: statusOnly, expected: false},
+		{name: "data changed", updated: dataChanged, expected: true},
+		{name: "other annotation changed", updated: otherAnnotation, expected: true},
+		{name: "scan requested", updated: scanRequested, expected: true},
+		{name: "finalizer added", updated: finalizerAdded, expected: false},
+		{name: "deletion requested", updated: deleting, expected: true},
+	}
+
+	p := ignoreStatusAnnotationUpdates()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := p.Update(event.UpdateEvent{ObjectOld: base, ObjectNew: tt.updated}); got != tt.expected {
+				t.Errorf("Update() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	// DefaultRepositoryListTimeout is the default time budget for listing the
+	// repositories of a VCS instance
+	DefaultRepositoryListTimeout = 5 * time.Minute
+
+	// DefaultScorecardFetchTimeout is the default time budget for fetching the
+	// scorecard data of a repository, long enough for local scorecard runs
+	DefaultScorecardFetchTimeout = 10 * time.Minute
+)
+
+// withTimeout bounds an operation by timeout, leaving ctx unbounded when the
+// timeout is zero
+func withTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// timeoutError annotates err with the operation that ran out of time, when it
+// was the operation's timeout rather than the reconcile context that ended it
+func timeoutError(ctx, opCtx context.Context, operation string, timeout time.Duration, err error) error {
+	if err == nil || ctx.Err() != nil || !errors.Is(opCtx.Err(), context.DeadlineExceeded) {
+		return err
+	}
+	return fmt.Errorf("%s timed out after %v: %w", operation, timeout, err)
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/giantswarm/openssf-scorecard-exporter/internal/vcs"
+)
+
+// tokenProbes records the credentials and settings each VCS instance of a
+// config was last probed with, so that tokens are probed once when a config
+// starts using them rather than on every reconcile. It is safe for concurrent
+// use.
+type tokenProbes struct {
+	mu sync.Mutex
+
+	probed map[providerInstance]providerCacheKey
+}
+
+// needed returns whether the credentials of a VCS instance of a config have not
+// been probed yet
+func (p *tokenProbes) needed(config types.NamespacedName, vcsConfig *vcs.Config) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	key, ok := p.probed[providerInstance{config: config, baseURL: vcsConfig.BaseURL}]
+	return !ok || key != newProviderCacheKey(vcsConfig)
+}
+
+// done records that the credentials of a VCS instance of a config were probed
+func (p *tokenProbes) done(config types.NamespacedName, vcsConfig *vcs.Config) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.probed == nil {
+		p.probed = make(map[providerInstance]providerCacheKey)
+	}
+	p.probed[providerInstance{config: config, baseURL: vcsConfig.BaseURL}] = newProviderCacheKey(vcsConfig)
+}
+
+// remove forgets the probes of a config
+func (p *tokenProbes) remove(config types.NamespacedName) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for instance := range p.probed {
+		if instance.config == config {
+			delete(p.probed, instance)
+		}
+	}
+}
+
+// probeToken logs what the token of a VCS instance of a config has access to,
+// the first time the config uses it with providers that can tell, and warns
+// when it appears unable to read the organization, e.g. a classic token
+// without an org scope. It returns what the probe found, nil if the token was
+// not probed. Probe failures are logged and do not affect the reconcile, and
+// the probe is retried on the next one.
+func (r *ConfigMapReconciler) probeToken(
+	ctx context.Context, req types.NamespacedName, object runtime.Object,
+	provider vcs.Provider, vcsConfig *vcs.Config, organization string,
+) *vcs.TokenInfo {
+	logger := log.FromContext(ctx)
+
+	prober, ok := provider.(vcs.TokenProber)
+	if !ok || !r.tokenProbes.needed(req, vcsConfig) {
+		return nil
+	}
+
+	info, err := prober.ProbeToken(ctx, organization)
+	if err != nil {
+		logger.V(1).Info("Failed to probe VCS token", "baseURL", vcsConfig.BaseURL, "error", err.Error())
+		return nil
+	}
+	r.tokenProbes.done(req, vcsConfig)
+
+	logger.Info("Probed VCS token",
+		"provider", provider.GetProviderType(),
+		"baseURL", vcsConfig.BaseURL,
+		"organization", organization,
+		"kind", info.Kind,
+		"scopes", info.Scopes,
+		"organizationAccess", info.OrganizationAccess,
+		"publicRepositories", info.PublicRepositories)
+	if !info.OrganizationAccess {
+		logger.Info("VCS token appears unable to read the organization, repositories may not be listed",
+			"organization", organization,
+			"kind", info.Kind)
+		r.recordEvent(object, corev1.EventTypeWarning, EventReasonLimitedTokenAccess,
+			"%s %s token appears unable to read organization %s, grant it access to the organization "+
+				"or read:org scope if no repositories are listed", provider.GetProviderType(), info.Kind, organization)
+	}
+	return info
+}
+
+// checkListing warns when a token probed by probeToken listed no repositories
+// of an organization that reports public ones, and no filter left them out.
+// Such tokens, e.g. fine-grained tokens the organization has not granted
+// access, list no repositories rather than failing.
+func (r *ConfigMapReconciler) checkListing(
+	ctx context.Context, object runtime.Object, provider vcs.Provider, info *vcs.TokenInfo,
+	organization string, listed int,
+) {
+	if info == nil || !info.OrganizationAccess || info.PublicRepositories == 0 || listed > 0 {
+		return
+	}
+
+	log.FromContext(ctx).Info("VCS token listed no repositories of an organization with public repositories",
+		"organization", organization,
+		"kind", info.Kind,
+		"publicRepositories", info.PublicRepositories)
+	r.recordEvent(object, corev1.EventTypeWarning, EventReasonLimitedTokenAccess,
+		"%s %s token listed none of the %d public repositories of organization %s, grant it access to the organization",
+		provider.GetProviderType(), info.Kind, info.PublicRepositories, organization)
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package debug
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHandler(t *testing.T) {
+	store := NewStore()
+	store.Record("default/ok", 12, false, nil)
+	store.Record("default/failing", 0, false, errors.New(`missing required field "organization"`))
+
+	handler := NewHandler(store)
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/debug/configs", nil))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("GET /debug/configs status = %d, want %d", recorder.Code, http.StatusOK)
+	}
+	if contentType := recorder.Header().Get("Content-Type"); contentType != "application/json" {
+		t.Errorf("Content-Type = %q, want application/json", contentType)
+	}
+
+	var out []map[string]any
+	if err := json.NewDecoder(recorder.Body).Decode(&out); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(out) != 2 {
+		t.Fatalf("GET /debug/configs = %v, want 2 configs", out)
+	}
+
+	failing, ok := out[0], out[1]
+	if failing["config"] != "default/failing" || failing["last_error"] != `missing required field "organization"` {
+		t.Errorf("failing config = %v, want its last error", failing)
+	}
+	if _, found := failing["last_success"]; found {
+		t.Errorf("failing config = %v, want no last_success", failing)
+	}
+	if ok["config"] != "default/ok" || ok["repositories"] != float64(12) || ok["last_success"] == nil {
+		t.Errorf("ok config = %v, want 12 repositories and a last_success", ok)
+	}
+	if _, found := ok["last_error"]; found {
+		t.Errorf("ok config = %v, want no last_error", ok)
+	}
+
+	// Other routes and methods are not served
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/debug/configs", nil))
+	if recorder.Code != http.StatusMethodNotAllowed {
+		t.Errorf("POST /debug/configs status = %d, want %d", recorder.Code, http.StatusMethodNotAllowed)
+	}
+
+	// Without any config, an empty list is served
+	recorder = httptest.NewRecorder()
+	NewHandler(NewStore()).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/debug/configs", nil))
+	if body := recorder.Body.String(); body != "[]\n" {
+		t.Errorf("GET /debug/configs = %q, want an empty list", body)
+	}
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package debug
+
+import (
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ConfigState is the outcome of the reconciles of a config
+type ConfigState struct {
+	// Config is the namespace/name of the config
+	Config string `json:"config"`
+
+	// LastReconcile is when the config was last reconciled, successfully or not
+	LastReconcile time.Time `json:"last_reconcile"`
+
+	// LastSuccess is when the config was last reconciled successfully, zero
+	// if it never was
+	LastSuccess time.Time `json:"last_success,omitzero"`
+
+	// Repositories is the number of repositories exported by the last
+	// successful reconcile
+	Repositories int `json:"repositories"`
+
+	// Partial is set when the last successful reconcile ran out of its time
+	// budget before exporting all repositories
+	Partial bool `json:"partial,omitempty"`
+
+	// LastError is the error of the last reconcile, empty if it succeeded
+	LastError string `json:"last_error,omitempty"`
+}
+
+// Store keeps the reconcile state of each config, so that it can be served
+// for troubleshooting. A nil Store holds no state and ignores updates. It is
+// safe for concurrent use.
+type Store struct {
+	mu sync.RWMutex
+
+	// configs holds the state of each config, keyed by namespace/name
+	configs map[string]ConfigState
+}
+
+// NewStore creates an empty Store
+func NewStore() *Store {
+	return &Store{configs: make(map[string]ConfigState)}
+}
+
+// Record records the outcome of a reconcile of a config. A failed reconcile
+// keeps the repository count of the last successful one.
+func (s *Store) Record(configName string, repositories int, partial bool, err error) {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state := s.configs[configName]
+	state.Config = configName
+	state.LastReconcile = time.Now()
+	if err != nil {
+		state.LastError = err.Error()
+	} else {
+		state.LastSuccess = state.LastReconcile
+		state.Repositories = repositories
+		state.Partial = partial
+		state.LastError = ""
+	}
+	s.configs[configName] = state
+}
+
+// Remove drops the state of a config
+func (s *Store) Remove(configName string) {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.configs, configName)
+}
+
+// Configs returns the state of all configs, ordered by config name
+func (s *Store) Configs() []ConfigState {
+	if s == nil {
+		return nil
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	configs := make([]ConfigState, 0, len(s.configs))
+	for _, state := range s.configs {
+		configs = append(configs, state)
+	}
+	slices.SortFunc(configs, func(a, b ConfigState) int {
+		return strings.Compare(a.Config, b.Config)
+	})
+	return configs
+}
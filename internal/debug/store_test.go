@@ -0,0 +1,62 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package debug
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestStore(t *testing.T) {
+	store := NewStore()
+	store.Record("default/b", 3, false, nil)
+	store.Record("default/a", 5, true, nil)
+
+	configs := store.Configs()
+	if len(configs) != 2 || configs[0].Config != "default/a" || configs[1].Config != "default/b" {
+		t.Fatalf("Configs() = %+v, want default/a and default/b in order", configs)
+	}
+	if a := configs[0]; a.Repositories != 5 || !a.Partial || a.LastError != "" || !a.LastSuccess.Equal(a.LastReconcile) {
+		t.Errorf("default/a = %+v, want a successful partial reconcile of 5 repositories", a)
+	}
+
+	// Failed reconciles keep the outcome of the last successful one
+	store.Record("default/a", 0, false, errors.New("rate limited"))
+	a := store.Configs()[0]
+	if a.Repositories != 5 || a.LastError != "rate limited" || a.LastReconcile.Before(a.LastSuccess) {
+		t.Errorf("default/a = %+v, want the error and the repositories of the last success", a)
+	}
+
+	// Successful reconciles clear the error
+	store.Record("default/a", 6, false, nil)
+	if a := store.Configs()[0]; a.Repositories != 6 || a.Partial || a.LastError != "" {
+		t.Errorf("default/a = %+v, want a complete reconcile of 6 repositories without error", a)
+	}
+
+	store.Remove("default/a")
+	if configs := store.Configs(); len(configs) != 1 || configs[0].Config != "default/b" {
+		t.Errorf("Configs() = %+v after Remove, want default/b only", configs)
+	}
+
+	// A nil store holds nothing
+	var disabled *Store
+	disabled.Record("default/a", 1, false, nil)
+	disabled.Remove("default/a")
+	if configs := disabled.Configs(); len(configs) != 0 {
+		t.Errorf("nil Store returned %+v", configs)
+	}
+}
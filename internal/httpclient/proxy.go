@@ -0,0 +1,60 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package httpclient
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// proxyKey is the context key of the proxy overriding the one of a transport
+type proxyKey struct{}
+
+// proxyTransport routes the requests of a client through a specific proxy
+type proxyTransport struct {
+	base     http.RoundTripper
+	proxyURL *url.URL
+}
+
+// NewProxyTransport wraps a transport so that requests go through proxyURL
+// instead of the proxy the transport was created with. This lets clients
+// sharing a transport, and its connection pool, use different proxies. The
+// proxy is only honored by transports created by NewTransport. A nil
+// proxyURL returns base unchanged.
+func NewProxyTransport(base http.RoundTripper, proxyURL *url.URL) http.RoundTripper {
+	if proxyURL == nil {
+		return base
+	}
+	return &proxyTransport{base: base, proxyURL: proxyURL}
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *proxyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return t.base.RoundTrip(req.WithContext(context.WithValue(req.Context(), proxyKey{}, t.proxyURL)))
+}
+
+// requestProxy returns the proxy of a request, the one set by a
+// proxyTransport if any, or else the one returned by proxy
+func requestProxy(proxy func(*http.Request) (*url.URL, error)) func(*http.Request) (*url.URL, error) {
+	return func(req *http.Request) (*url.URL, error) {
+		if proxyURL, ok := req.Context().Value(proxyKey{}).(*url.URL); ok {
+			return proxyURL, nil
+		}
+		return proxy(req)
+	}
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package httpclient
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+// newRecordingProxy starts a proxy that records the hosts of the requests it
+// receives instead of forwarding them
+func newRecordingProxy(t *testing.T) (*url.URL, *[]string) {
+	t.Helper()
+
+	var hosts []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hosts = append(hosts, r.Host)
+	}))
+	t.Cleanup(proxy.Close)
+
+	proxyURL, err := url.Parse(proxy.URL)
+	if err != nil {
+		t.Fatalf("url.Parse() unexpected error: %v", err)
+	}
+	return proxyURL, &hosts
+}
+
+func TestNewProxyTransport(t *testing.T) {
+	sharedProxy, sharedHosts := newRecordingProxy(t)
+	vcsProxy, vcsHosts := newRecordingProxy(t)
+
+	shared, err := NewTransport(Options{ProxyURL: sharedProxy.String()})
+	if err != nil {
+		t.Fatalf("NewTransport() unexpected error: %v", err)
+	}
+
+	if NewProxyTransport(shared, nil) != http.RoundTripper(shared) {
+		t.Error("NewProxyTransport() without a proxy does not return the base transport")
+	}
+
+	get := func(transport http.RoundTripper, target string) {
+		t.Helper()
+		resp, err := (&http.Client{Transport: transport}).Get(target)
+		if err != nil {
+			t.Fatalf("Get() unexpected error: %v", err)
+		}
+		_ = resp.Body.Close()
+	}
+
+	// Clients sharing the transport go through their own proxy, or else the
+	// one of the transport
+	get(NewUserAgentTransport(NewProxyTransport(shared, vcsProxy), "test"), "http://github.example.com/user")
+	get(shared, "http://scorecard.example.com/projects")
+
+	if len(*vcsHosts) != 1 || (*vcsHosts)[0] != "github.example.com" {
+		t.Errorf("VCS proxy requests = %v, want [github.example.com]", *vcsHosts)
+	}
+	if len(*sharedHosts) != 1 || (*sharedHosts)[0] != "scorecard.example.com" {
+		t.Errorf("shared proxy requests = %v, want [scorecard.example.com]", *sharedHosts)
+	}
+}
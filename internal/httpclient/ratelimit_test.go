@@ -0,0 +1,110 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package httpclient
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRateLimitedTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	t.Cleanup(server.Close)
+
+	tests := []struct {
+		name              string
+		requestsPerSecond float64
+		requests          int
+		minDuration       time.Duration
+		maxDuration       time.Duration
+	}{
+		{
+			name:        "unlimited",
+			requests:    10,
+			maxDuration: 500 * time.Millisecond,
+		},
+		{
+			name:              "burst is not delayed",
+			requestsPerSecond: 20,
+			requests:          20,
+			maxDuration:       500 * time.Millisecond,
+		},
+		{
+			name:              "paces requests beyond the burst",
+			requestsPerSecond: 20,
+			requests:          30,
+			minDuration:       450 * time.Millisecond,
+			maxDuration:       2 * time.Second,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &http.Client{
+				Transport: NewRateLimitedTransport(&http.Transport{}, NewLimiter(tt.requestsPerSecond)),
+			}
+
+			start := time.Now()
+			for range tt.requests {
+				resp, err := client.Get(server.URL)
+				if err != nil {
+					t.Fatalf("Get() unexpected error: %v", err)
+				}
+				_ = resp.Body.Close()
+			}
+			elapsed := time.Since(start)
+
+			if elapsed < tt.minDuration || elapsed > tt.maxDuration {
+				t.Errorf("%d requests took %v, want between %v and %v",
+					tt.requests, elapsed, tt.minDuration, tt.maxDuration)
+			}
+		})
+	}
+}
+
+func TestRateLimitedTransport_Shared(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	t.Cleanup(server.Close)
+
+	// Two clients sending concurrently share the limiter's budget
+	limiter := NewLimiter(20)
+	var wg sync.WaitGroup
+	start := time.Now()
+	for range 2 {
+		client := &http.Client{Transport: NewRateLimitedTransport(&http.Transport{}, limiter)}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 15 {
+				resp, err := client.Get(server.URL)
+				if err != nil {
+					t.Errorf("Get() unexpected error: %v", err)
+					return
+				}
+				_ = resp.Body.Close()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if elapsed := time.Since(start); elapsed < 450*time.Millisecond {
+		t.Errorf("30 requests through a shared limit of 20 per second took %v, want at least 450ms", elapsed)
+	}
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package httpclient
+
+import (
+	"io"
+	"net/http"
+	"time"
+)
+
+const (
+	// DefaultMaxRetries is the default number of retries for transient failures
+	DefaultMaxRetries = 3
+
+	// DefaultRetryBackoff is the default delay before the first retry. It
+	// doubles with every further retry.
+	DefaultRetryBackoff = time.Second
+)
+
+// retryTransport retries idempotent requests that fail with a transient
+// server error
+type retryTransport struct {
+	base       http.RoundTripper
+	maxRetries int
+	backoff    time.Duration
+}
+
+// NewRetryTransport wraps a transport so that GET and HEAD requests answered
+// with a 500, 502, 503 or 504 are retried up to maxRetries times, with
+// exponential backoff starting at backoff. The last response is returned when
+// all retries fail.
+func NewRetryTransport(base http.RoundTripper, maxRetries int, backoff time.Duration) http.RoundTripper {
+	return &retryTransport{base: base, maxRetries: maxRetries, backoff: backoff}
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		return t.base.RoundTrip(req)
+	}
+
+	delay := t.backoff
+	for attempt := 0; ; attempt++ {
+		resp, err := t.base.RoundTrip(req)
+		if err != nil || !isTransientStatus(resp.StatusCode) || attempt >= t.maxRetries {
+			return resp, err
+		}
+
+		// Drain and close the body so the connection can be reused
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+		delay *= 2
+	}
+}
+
+// isTransientStatus reports whether a status code indicates a server error
+// that is likely to go away on retry
+func isTransientStatus(code int) bool {
+	switch code {
+	case http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package httpclient
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetryTransport(t *testing.T) {
+	tests := []struct {
+		name         string
+		method       string
+		statuses     []int
+		expected     int
+		expectedReqs int32
+	}{
+		{
+			name:         "success is not retried",
+			method:       http.MethodGet,
+			statuses:     []int{http.StatusOK},
+			expected:     http.StatusOK,
+			expectedReqs: 1,
+		},
+		{
+			name:         "transient failure retried",
+			method:       http.MethodGet,
+			statuses:     []int{http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusOK},
+			expected:     http.StatusOK,
+			expectedReqs: 3,
+		},
+		{
+			name:         "gives up after max retries",
+			method:       http.MethodGet,
+			statuses:     []int{http.StatusInternalServerError},
+			expected:     http.StatusInternalServerError,
+			expectedReqs: 3,
+		},
+		{
+			name:         "client error not retried",
+			method:       http.MethodGet,
+			statuses:     []int{http.StatusForbidden},
+			expected:     http.StatusForbidden,
+			expectedReqs: 1,
+		},
+		{
+			name:         "non-idempotent request not retried",
+			method:       http.MethodPost,
+			statuses:     []int{http.StatusServiceUnavailable},
+			expected:     http.StatusServiceUnavailable,
+			expectedReqs: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := int(requests.Add(1))
+				w.WriteHeader(tt.statuses[min(n, len(tt.statuses))-1])
+			}))
+			t.Cleanup(server.Close)
+
+			client := &http.Client{Transport: NewRetryTransport(&http.Transport{}, 2, time.Millisecond)}
+			req, _ := http.NewRequest(tt.method, server.URL, nil)
+			resp, err := client.Do(req)
+			if err != nil {
+				t.Fatalf("Do() unexpected error: %v", err)
+			}
+			_ = resp.Body.Close()
+
+			if resp.StatusCode != tt.expected {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.expected)
+			}
+			if got := requests.Load(); got != tt.expectedReqs {
+				t.Errorf("requests = %d, want %d", got, tt.expectedReqs)
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package httpclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// DefaultMinTLSVersion is the lowest TLS version of outbound connections
+// unless configured otherwise
+const DefaultMinTLSVersion = tls.VersionTLS12
+
+// Options configures the transport used for outbound requests
+type Options struct {
+	// ProxyURL is the proxy used for all requests, unless overridden with
+	// NewProxyTransport. When empty, the standard HTTPS_PROXY, HTTP_PROXY and
+	// NO_PROXY environment variables are honored.
+	ProxyURL string
+
+	// CAFile is a PEM bundle of additional CA certificates to trust,
+	// on top of the system certificate pool
+	CAFile string
+
+	// MinTLSVersion is the lowest TLS version accepted, DefaultMinTLSVersion
+	// if zero
+	MinTLSVersion uint16
+
+	// CipherSuites restricts the cipher suites offered for TLS 1.2
+	// connections. When empty, Go's defaults are used. TLS 1.3 cipher suites
+	// are not configurable.
+	CipherSuites []uint16
+}
+
+// NewTransport creates an HTTP transport configured with the given options
+func NewTransport(opts Options) (*http.Transport, error) {
+	tr := http.DefaultTransport.(*http.Transport).Clone()
+	tr.Proxy = http.ProxyFromEnvironment
+
+	if opts.ProxyURL != "" {
+		proxyURL, err := url.Parse(opts.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse proxy URL: %w", err)
+		}
+		tr.Proxy = http.ProxyURL(proxyURL)
+	}
+	tr.Proxy = requestProxy(tr.Proxy)
+
+	tr.TLSClientConfig = &tls.Config{
+		MinVersion:   opts.MinTLSVersion,
+		CipherSuites: opts.CipherSuites,
+	}
+	if tr.TLSClientConfig.MinVersion == 0 {
+		tr.TLSClientConfig.MinVersion = DefaultMinTLSVersion
+	}
+
+	if opts.CAFile != "" {
+		pool, err := loadCertPool(opts.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		tr.TLSClientConfig.RootCAs = pool
+	}
+
+	return tr, nil
+}
+
+// ParseTLSVersion parses a TLS version of the form "1.2" or "1.3". Older
+// versions are not accepted.
+func ParseTLSVersion(value string) (uint16, error) {
+	switch strings.TrimSpace(value) {
+	case "1.2":
+		return tls.VersionTLS12, nil
+	case "1.3":
+		return tls.VersionTLS13, nil
+	default:
+		return 0, fmt.Errorf("unsupported TLS version %q, must be 1.2 or 1.3", value)
+	}
+}
+
+// ParseCipherSuites parses a comma-separated list of cipher suite names, e.g.
+// "TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256". Only the suites Go considers
+// secure are accepted. An empty value returns no suites, keeping Go's
+// defaults.
+func ParseCipherSuites(value string) ([]uint16, error) {
+	var suites []uint16
+	for name := range strings.SplitSeq(value, ",") {
+		if name = strings.TrimSpace(name); name == "" {
+			continue
+		}
+		id, ok := cipherSuiteID(name)
+		if !ok {
+			return nil, fmt.Errorf("unsupported cipher suite %q", name)
+		}
+		suites = append(suites, id)
+	}
+	return suites, nil
+}
+
+// cipherSuiteID returns the ID of a secure cipher suite by name
+func cipherSuiteID(name string) (uint16, bool) {
+	for _, suite := range tls.CipherSuites() {
+		if suite.Name == name {
+			return suite.ID, true
+		}
+	}
+	return 0, false
+}
+
+// loadCertPool returns the system certificate pool extended with the
+// certificates from a PEM bundle
+func loadCertPool(caFile string) (*x509.CertPool, error) {
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+
+	pemData, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA bundle: %w", err)
+	}
+	if !pool.AppendCertsFromPEM(pemData) {
+		return nil, fmt.Errorf("no certificates found in CA bundle %s", caFile)
+	}
+
+	return pool, nil
+}
@@ -0,0 +1,235 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package httpclient
+
+import (
+	"crypto/tls"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestNewTransport_Proxy(t *testing.T) {
+	t.Setenv("HTTPS_PROXY", "http://env-proxy.example.com:3128")
+
+	tests := []struct {
+		name     string
+		opts     Options
+		expected string
+	}{
+		{
+			name:     "proxy from environment",
+			opts:     Options{},
+			expected: "http://env-proxy.example.com:3128",
+		},
+		{
+			name:     "explicit proxy overrides environment",
+			opts:     Options{ProxyURL: "http://proxy.example.com:8080"},
+			expected: "http://proxy.example.com:8080",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr, err := NewTransport(tt.opts)
+			if err != nil {
+				t.Fatalf("NewTransport() unexpected error: %v", err)
+			}
+
+			req, _ := http.NewRequest(http.MethodGet, "https://api.securityscorecards.dev/projects", nil)
+			proxyURL, err := tr.Proxy(req)
+			if err != nil {
+				t.Fatalf("Proxy() unexpected error: %v", err)
+			}
+			if proxyURL == nil || proxyURL.String() != tt.expected {
+				t.Errorf("Proxy() = %v, want %s", proxyURL, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNewTransport_CAFile(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(caFile, caPEM, 0o600); err != nil {
+		t.Fatalf("failed to write CA file: %v", err)
+	}
+
+	// Without the CA, the server certificate is not trusted
+	tr, err := NewTransport(Options{})
+	if err != nil {
+		t.Fatalf("NewTransport() unexpected error: %v", err)
+	}
+	if _, err := (&http.Client{Transport: tr}).Get(server.URL); err == nil {
+		t.Error("request without custom CA expected to fail")
+	}
+
+	tr, err = NewTransport(Options{CAFile: caFile})
+	if err != nil {
+		t.Fatalf("NewTransport() unexpected error: %v", err)
+	}
+	resp, err := (&http.Client{Transport: tr}).Get(server.URL)
+	if err != nil {
+		t.Fatalf("request with custom CA failed: %v", err)
+	}
+	_ = resp.Body.Close()
+}
+
+func TestNewTransport_InvalidCAFile(t *testing.T) {
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(caFile, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatalf("failed to write CA file: %v", err)
+	}
+
+	if _, err := NewTransport(Options{CAFile: caFile}); err == nil {
+		t.Error("NewTransport() expected error for invalid CA bundle")
+	}
+	if _, err := NewTransport(Options{CAFile: filepath.Join(t.TempDir(), "missing.pem")}); err == nil {
+		t.Error("NewTransport() expected error for missing CA bundle")
+	}
+}
+
+func TestNewTransport_TLS(t *testing.T) {
+	tests := []struct {
+		name            string
+		opts            Options
+		expectedVersion uint16
+		expectedSuites  []uint16
+	}{
+		{
+			name:            "TLS 1.2 minimum by default",
+			opts:            Options{},
+			expectedVersion: tls.VersionTLS12,
+		},
+		{
+			name:            "TLS 1.3 minimum",
+			opts:            Options{MinTLSVersion: tls.VersionTLS13},
+			expectedVersion: tls.VersionTLS13,
+		},
+		{
+			name: "cipher suites",
+			opts: Options{
+				CipherSuites: []uint16{tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256},
+			},
+			expectedVersion: tls.VersionTLS12,
+			expectedSuites:  []uint16{tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr, err := NewTransport(tt.opts)
+			if err != nil {
+				t.Fatalf("NewTransport() unexpected error: %v", err)
+			}
+			if tr.TLSClientConfig == nil {
+				t.Fatal("TLSClientConfig is nil")
+			}
+			if tr.TLSClientConfig.MinVersion != tt.expectedVersion {
+				t.Errorf("MinVersion = %x, want %x", tr.TLSClientConfig.MinVersion, tt.expectedVersion)
+			}
+			if !slices.Equal(tr.TLSClientConfig.CipherSuites, tt.expectedSuites) {
+				t.Errorf("CipherSuites = %v, want %v", tr.TLSClientConfig.CipherSuites, tt.expectedSuites)
+			}
+		})
+	}
+}
+
+func TestNewTransport_TLSMinVersionRejectsOlderServers(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	server.TLS = &tls.Config{MaxVersion: tls.VersionTLS12}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	tr, err := NewTransport(Options{MinTLSVersion: tls.VersionTLS13})
+	if err != nil {
+		t.Fatalf("NewTransport() unexpected error: %v", err)
+	}
+	tr.TLSClientConfig.RootCAs = server.Client().Transport.(*http.Transport).TLSClientConfig.RootCAs
+	if _, err := (&http.Client{Transport: tr}).Get(server.URL); err == nil {
+		t.Error("request to a TLS 1.2 server expected to fail with TLS 1.3 minimum")
+	}
+}
+
+func TestParseTLSVersion(t *testing.T) {
+	tests := []struct {
+		value     string
+		expected  uint16
+		expectErr bool
+	}{
+		{value: "1.2", expected: tls.VersionTLS12},
+		{value: " 1.3 ", expected: tls.VersionTLS13},
+		{value: "1.1", expectErr: true},
+		{value: "", expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			version, err := ParseTLSVersion(tt.value)
+			if (err != nil) != tt.expectErr {
+				t.Fatalf("ParseTLSVersion() error = %v, expectErr %v", err, tt.expectErr)
+			}
+			if version != tt.expected {
+				t.Errorf("ParseTLSVersion() = %x, want %x", version, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseCipherSuites(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     string
+		expected  []uint16
+		expectErr bool
+	}{
+		{name: "Go defaults when empty"},
+		{
+			name:  "secure suites",
+			value: "TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256, TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,",
+			expected: []uint16{
+				tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+				tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+			},
+		},
+		{name: "insecure suite", value: "TLS_RSA_WITH_RC4_128_SHA", expectErr: true},
+		{name: "unknown suite", value: "TLS_MADE_UP", expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			suites, err := ParseCipherSuites(tt.value)
+			if (err != nil) != tt.expectErr {
+				t.Fatalf("ParseCipherSuites() error = %v, expectErr %v", err, tt.expectErr)
+			}
+			if !slices.Equal(suites, tt.expected) {
+				t.Errorf("ParseCipherSuites() = %v, want %v", suites, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package httpclient
+
+import (
+	"net/http"
+	"runtime/debug"
+)
+
+// userAgentProduct is the product name of the default User-Agent
+const userAgentProduct = "openssf-scorecard-exporter"
+
+// userAgentTransport sets the User-Agent header of requests
+type userAgentTransport struct {
+	base      http.RoundTripper
+	userAgent string
+}
+
+// NewUserAgentTransport wraps a transport so that requests are sent with the
+// given User-Agent, replacing the one set by API client libraries such as
+// go-github. An empty userAgent returns base unchanged.
+func NewUserAgentTransport(base http.RoundTripper, userAgent string) http.RoundTripper {
+	if userAgent == "" {
+		return base
+	}
+	return &userAgentTransport{base: base, userAgent: userAgent}
+}
+
+// DefaultUserAgent returns the User-Agent identifying the exporter, with the
+// module version from the build info, e.g. openssf-scorecard-exporter/v1.2.3
+func DefaultUserAgent() string {
+	version := "dev"
+	if info, ok := debug.ReadBuildInfo(); ok && info.Main.Version != "" && info.Main.Version != "(devel)" {
+		version = info.Main.Version
+	}
+	return userAgentProduct + "/" + version
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *userAgentTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// Round trippers must not modify the request they are given
+	req = req.Clone(req.Context())
+	req.Header.Set("User-Agent", t.userAgent)
+	return t.base.RoundTrip(req)
+}
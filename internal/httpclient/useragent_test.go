@@ -0,0 +1,60 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package httpclient
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNewUserAgentTransport(t *testing.T) {
+	var userAgent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgent = r.UserAgent()
+	}))
+	t.Cleanup(server.Close)
+
+	client := &http.Client{Transport: NewUserAgentTransport(http.DefaultTransport, "exporter/v1.2.3")}
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	req.Header.Set("User-Agent", "go-github/v80.0.0")
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("Do() unexpected error: %v", err)
+	}
+	_ = resp.Body.Close()
+
+	// The User-Agent of client libraries is replaced, without modifying the request
+	if userAgent != "exporter/v1.2.3" {
+		t.Errorf("User-Agent = %q, want exporter/v1.2.3", userAgent)
+	}
+	if got := req.Header.Get("User-Agent"); got != "go-github/v80.0.0" {
+		t.Errorf("request User-Agent = %q, want it unchanged", got)
+	}
+
+	if NewUserAgentTransport(http.DefaultTransport, "") != http.DefaultTransport {
+		t.Error("NewUserAgentTransport() with empty User-Agent did not return the base transport")
+	}
+}
+
+func TestDefaultUserAgent(t *testing.T) {
+	userAgent := DefaultUserAgent()
+	if !strings.HasPrefix(userAgent, "openssf-scorecard-exporter/") || userAgent == "openssf-scorecard-exporter/" {
+		t.Errorf("DefaultUserAgent() = %q, want openssf-scorecard-exporter/<version>", userAgent)
+	}
+}
@@ -17,16 +17,34 @@ limitations under the License.
 package metrics
 
 import (
+	"slices"
+	"strconv"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
 
 	"github.com/giantswarm/openssf-scorecard-exporter/internal/scorecard"
+	"github.com/giantswarm/openssf-scorecard-exporter/internal/vcs"
 )
 
 const (
 	metricsNamespace = "openssf_scorecard"
+
+	// maxReasonLength bounds the length of the reason label of check_info
+	maxReasonLength = 128
+)
+
+// Reasons of the config_errors_total metric
+const (
+	// ConfigErrorMissingOrganization is recorded for configs without an organization
+	ConfigErrorMissingOrganization = "missing_organization"
+
+	// ConfigErrorDeniedOrganization is recorded for configs targeting an
+	// organization denied by the manager
+	ConfigErrorDeniedOrganization = "denied_organization"
 )
 
 // Collector manages Prometheus metrics for OpenSSF Scorecard data
@@ -43,39 +61,224 @@ type Collector struct {
 	// Last update timestamp
 	lastUpdate *prometheus.GaugeVec
 
+	// Age of the scorecard data at the time it was fetched
+	dataAge *prometheus.GaugeVec
+
+	// Commit the scorecard data was computed for
+	commitInfo *prometheus.GaugeVec
+
+	// Reasons given for failing checks
+	checkInfo *prometheus.GaugeVec
+
+	// Scorecard reports exported per repository, with the commit of the
+	// report as exemplar. Gauges cannot carry exemplars.
+	scoreUpdates *prometheus.CounterVec
+
+	// Lowest check score of a repository, and the check scoring it
+	worstCheckScore *prometheus.GaugeVec
+	worstCheckInfo  *prometheus.GaugeVec
+
+	// Number of checks of a repository by status
+	checksPassing     *prometheus.GaugeVec
+	checksFailing     *prometheus.GaugeVec
+	checksUnavailable *prometheus.GaugeVec
+
+	// customOverallScore is the overall score recomputed from configured
+	// check weights
+	customOverallScore *prometheus.GaugeVec
+
+	// Documentation URL of each check, shared by all repositories
+	checkDocumentationInfo *prometheus.GaugeVec
+
+	// Findings behind each check score by level, if details are fetched
+	checkDetails *prometheus.GaugeVec
+
+	// Repository metadata from the VCS provider
+	repositoryInfo *prometheus.GaugeVec
+
+	// Repositories left out by the VCS provider, by reason
+	reposExcluded *prometheus.CounterVec
+
+	// Reconciles that processed only part of the repositories due to a limit
+	reposTruncated *prometheus.CounterVec
+
+	// VCS requests rejected due to invalid or insufficient credentials
+	authFailures *prometheus.CounterVec
+
+	// Reconciles that ran out of their time budget
+	partialReconciles *prometheus.CounterVec
+
+	// Whether a config accesses the VCS with credentials
+	authenticated *prometheus.GaugeVec
+
+	// When each config was last reconciled successfully
+	configLastSuccess *prometheus.GaugeVec
+
+	// Reconciles that skipped a config because it is misconfigured, by reason
+	configErrors *prometheus.CounterVec
+
+	// Number of configs reconciled and not deleted since
+	watchedConfigs prometheus.Gauge
+
+	// Repositories discovered per config, those with scorecard data, and
+	// those the scorecard source has no data for
+	repositories            *prometheus.GaugeVec
+	repositoriesWithData    *prometheus.GaugeVec
+	unavailableRepositories *prometheus.GaugeVec
+
+	// Fraction of the repositories discovered before filtering that a config scans
+	coverageRatio *prometheus.GaugeVec
+
+	// Distribution of the overall scores of the repositories of a config
+	scoreDistribution *prometheus.HistogramVec
+
+	// Mean overall score of the repositories of a config with scorecard data
+	organizationAverageScore *prometheus.GaugeVec
+
+	// Duration of requests to the scorecard API, by status code
+	apiRequestDuration *prometheus.HistogramVec
+
+	// All of the above, registered together and gathered by Pusher
+	collectors []prometheus.Collector
+
+	// constLabels are added to all of the above
+	constLabels prometheus.Labels
+
+	// normalizeLabels replaces unusual characters in organization and
+	// repository labels
+	normalizeLabels bool
+
+	// namespaceLabel adds the namespace of the config to the labels of the
+	// metrics of each config
+	namespaceLabel bool
+
+	// normalizeScores exports scores on a 0-1 scale instead of 0-10
+	normalizeScores bool
+
 	// Mutex to protect metric updates
 	mu sync.RWMutex
 
-	// Track which metrics have been registered
-	registeredMetrics map[string]bool
+	// Track which metrics have been registered for each repository
+	registeredMetrics map[string]repositoryMetrics
+
+	// Documentation URL exported for each check
+	checkDocumentation map[string]string
+
+	// Names of the configs counted by watchedConfigs
+	watched map[string]struct{}
+}
+
+// repositoryMetrics describes the metrics exported for a repository
+type repositoryMetrics struct {
+	// checks are the names of the exported checks
+	checks []string
+
+	// source is the source label of the exported scores
+	source string
+}
+
+// Option configures a Collector
+type Option func(*options)
+
+// options holds the settings applied when creating a Collector
+type options struct {
+	registry        prometheus.Registerer
+	constLabels     prometheus.Labels
+	normalizeLabels bool
+	namespaceLabel  bool
+	normalizeScores bool
+}
+
+// WithRegistry registers the metrics with the given registry instead of
+// controller-runtime's global metrics registry
+func WithRegistry(registry prometheus.Registerer) Option {
+	return func(o *options) {
+		o.registry = registry
+	}
+}
+
+// WithConstLabels adds the given labels, with constant values, to all metrics,
+// e.g. to tell apart the metrics of several clusters in one Prometheus
+func WithConstLabels(labels prometheus.Labels) Option {
+	return func(o *options) {
+		o.constLabels = labels
+	}
+}
+
+// WithLabelNormalization replaces characters other than ASCII letters, digits,
+// '-' and '_' in organization and repository labels with '_'
+func WithLabelNormalization(enabled bool) Option {
+	return func(o *options) {
+		o.normalizeLabels = enabled
+	}
+}
+
+// WithNamespaceLabel adds a namespace label, the namespace of the ConfigMap or
+// ScorecardTarget, to the metrics of each config, so that they can be told
+// apart by tenant without parsing the config label
+func WithNamespaceLabel(enabled bool) Option {
+	return func(o *options) {
+		o.namespaceLabel = enabled
+	}
+}
+
+// WithScoreNormalization exports overall, check and average scores divided by
+// 10, on a 0-1 scale. Negative scores marking unavailable data are kept.
+func WithScoreNormalization(enabled bool) Option {
+	return func(o *options) {
+		o.normalizeScores = enabled
+	}
 }
 
 // NewCollector creates a new metrics collector and registers metrics
-func NewCollector() *Collector {
+func NewCollector(opts ...Option) *Collector {
+	o := &options{
+		registry: metrics.Registry,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	// Scores range from 0 to 10, or from 0 to 1 when normalized
+	scoreRange, scoreBuckets := "0-10", prometheus.LinearBuckets(0, 1, 11)
+	if o.normalizeScores {
+		scoreRange, scoreBuckets = "0-1", prometheus.LinearBuckets(0, 0.1, 11)
+	}
+
+	// The metrics of each config are labeled with the config, and optionally
+	// its namespace
+	configLabelNames := func(names ...string) []string {
+		labels := []string{"config"}
+		if o.namespaceLabel {
+			labels = append(labels, "namespace")
+		}
+		return append(labels, names...)
+	}
+
 	c := &Collector{
 		overallScore: prometheus.NewGaugeVec(
 			prometheus.GaugeOpts{
 				Namespace: metricsNamespace,
 				Name:      "overall_score",
-				Help:      "Overall OpenSSF Scorecard score for a repository (0-10)",
+				Help:      "Overall OpenSSF Scorecard score for a repository (" + scoreRange + ")",
 			},
-			[]string{"config", "organization", "repository"},
+			configLabelNames("host", "organization", "repository", "source"),
 		),
 		checkScore: prometheus.NewGaugeVec(
 			prometheus.GaugeOpts{
 				Namespace: metricsNamespace,
 				Name:      "check_score",
-				Help:      "Score for individual OpenSSF Scorecard check (0-10, -1 for unavailable)",
+				Help:      "Score for individual OpenSSF Scorecard check (" + scoreRange + ", -1 for unavailable)",
 			},
-			[]string{"config", "organization", "repository", "check"},
+			configLabelNames("host", "organization", "repository", "check", "source"),
 		),
 		checkStatus: prometheus.NewGaugeVec(
 			prometheus.GaugeOpts{
 				Namespace: metricsNamespace,
 				Name:      "check_status",
-				Help:      "Status of individual OpenSSF Scorecard check (1=pass, 0=fail, -1=unavailable)",
+				Help:      "Status of individual OpenSSF Scorecard check (1=pass, 0=fail, -1=unavailable, -2=inconclusive)",
 			},
-			[]string{"config", "organization", "repository", "check"},
+			configLabelNames("host", "organization", "repository", "check"),
 		),
 		lastUpdate: prometheus.NewGaugeVec(
 			prometheus.GaugeOpts{
@@ -83,66 +286,679 @@ func NewCollector() *Collector {
 				Name:      "last_update_timestamp",
 				Help:      "Unix timestamp of the last scorecard data update",
 			},
-			[]string{"config", "organization", "repository"},
+			configLabelNames("host", "organization", "repository"),
+		),
+		dataAge: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: metricsNamespace,
+				Name:      "data_age_seconds",
+				Help:      "Age in seconds of the scorecard report when it was last fetched",
+			},
+			configLabelNames("host", "organization", "repository"),
 		),
-		registeredMetrics: make(map[string]bool),
+		commitInfo: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: metricsNamespace,
+				Name:      "commit_info",
+				Help:      "Commit the scorecard report of a repository was computed for (always 1)",
+			},
+			configLabelNames("host", "organization", "repository", "commit"),
+		),
+		checkInfo: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: metricsNamespace,
+				Name:      "check_info",
+				Help:      "Reason given for a failing OpenSSF Scorecard check (always 1)",
+			},
+			configLabelNames("host", "organization", "repository", "check", "reason"),
+		),
+		scoreUpdates: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: metricsNamespace,
+				Name:      "score_updates_total",
+				Help:      "Number of times the overall score of a repository was exported, with the scanned commit as exemplar",
+			},
+			configLabelNames("host", "organization", "repository"),
+		),
+		worstCheckScore: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: metricsNamespace,
+				Name:      "worst_check_score",
+				Help:      "Lowest OpenSSF Scorecard check score of a repository (" + scoreRange + ")",
+			},
+			configLabelNames("host", "organization", "repository"),
+		),
+		worstCheckInfo: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: metricsNamespace,
+				Name:      "worst_check_info",
+				Help:      "Lowest scoring OpenSSF Scorecard check of a repository (always 1)",
+			},
+			configLabelNames("host", "organization", "repository", "check"),
+		),
+		checksPassing: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: metricsNamespace,
+				Name:      "checks_passing",
+				Help:      "Number of OpenSSF Scorecard checks of a repository scoring at least the pass threshold",
+			},
+			configLabelNames("host", "organization", "repository"),
+		),
+		checksFailing: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: metricsNamespace,
+				Name:      "checks_failing",
+				Help:      "Number of OpenSSF Scorecard checks of a repository scoring below the pass threshold",
+			},
+			configLabelNames("host", "organization", "repository"),
+		),
+		checksUnavailable: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: metricsNamespace,
+				Name:      "checks_unavailable",
+				Help:      "Number of OpenSSF Scorecard checks of a repository without a score, such as inconclusive checks",
+			},
+			configLabelNames("host", "organization", "repository"),
+		),
+		customOverallScore: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: metricsNamespace,
+				Name:      "custom_overall_score",
+				Help:      "Overall score of a repository recomputed from the configured check weights (" + scoreRange + ")",
+			},
+			configLabelNames("host", "organization", "repository"),
+		),
+		checkDocumentationInfo: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: metricsNamespace,
+				Name:      "check_documentation_info",
+				Help:      "Documentation URL of an OpenSSF Scorecard check (always 1)",
+			},
+			[]string{"check", "documentation_url"},
+		),
+		checkDetails: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: metricsNamespace,
+				Name:      "check_details",
+				Help:      "Number of findings behind an OpenSSF Scorecard check score, by level",
+			},
+			configLabelNames("host", "organization", "repository", "check", "level"),
+		),
+		repositoryInfo: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: metricsNamespace,
+				Name:      "repository_info",
+				Help:      "Metadata of a repository as reported by the VCS provider (always 1)",
+			},
+			configLabelNames("host", "organization", "repository", "default_branch", "visibility", "archived", "fork"),
+		),
+		reposExcluded: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: metricsNamespace,
+				Name:      "repos_excluded_total",
+				Help:      "Total number of times a repository was excluded from scanning, by reason",
+			},
+			configLabelNames("organization", "reason"),
+		),
+		reposTruncated: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: metricsNamespace,
+				Name:      "repos_truncated_total",
+				Help:      "Total number of reconciles that skipped repositories because of the maxRepositories limit",
+			},
+			configLabelNames("organization"),
+		),
+		authFailures: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: metricsNamespace,
+				Name:      "vcs_auth_failures_total",
+				Help:      "Total number of reconciles that failed because the VCS API rejected the credentials",
+			},
+			configLabelNames("organization"),
+		),
+		partialReconciles: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: metricsNamespace,
+				Name:      "partial_reconciles_total",
+				Help:      "Total number of reconciles that ran out of their time budget before exporting all repositories",
+			},
+			configLabelNames(),
+		),
+		authenticated: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: metricsNamespace,
+				Name:      "authenticated",
+				Help:      "Whether a config accesses the VCS with a token or GitHub App credentials (1) or anonymously (0)",
+			},
+			configLabelNames(),
+		),
+		configLastSuccess: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: metricsNamespace,
+				Name:      "config_last_success_timestamp",
+				Help:      "Unix timestamp of the last reconcile that exported the scorecard data of all repositories of a config",
+			},
+			configLabelNames(),
+		),
+		configErrors: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: metricsNamespace,
+				Name:      "config_errors_total",
+				Help:      "Total number of reconciles that skipped a config because of a configuration error",
+			},
+			configLabelNames("reason"),
+		),
+		watchedConfigs: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Namespace: metricsNamespace,
+				Name:      "watched_configs",
+				Help:      "Number of scorecard ConfigMaps and ScorecardTargets tracked by the controller",
+			},
+		),
+		repositories: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: metricsNamespace,
+				Name:      "repositories",
+				Help:      "Number of repositories discovered for a config after filtering",
+			},
+			configLabelNames("organization"),
+		),
+		repositoriesWithData: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: metricsNamespace,
+				Name:      "repositories_with_data",
+				Help:      "Number of repositories of a config with scorecard data available",
+			},
+			configLabelNames("organization"),
+		),
+		unavailableRepositories: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: metricsNamespace,
+				Name:      "unavailable_repositories",
+				Help:      "Number of repositories of a config for which no scorecard data was found as of the last reconcile",
+			},
+			configLabelNames("organization"),
+		),
+		coverageRatio: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: metricsNamespace,
+				Name:      "coverage_ratio",
+				Help:      "Fraction of the repositories listed for a config before filtering that are scanned",
+			},
+			configLabelNames("organization"),
+		),
+		scoreDistribution: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: metricsNamespace,
+				Name:      "score_distribution",
+				Help:      "Distribution of the overall scores of the repositories of a config as of the last reconcile",
+				Buckets:   scoreBuckets,
+			},
+			configLabelNames("organization"),
+		),
+		organizationAverageScore: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: metricsNamespace,
+				Name:      "organization_average_score",
+				Help:      "Mean overall score of the repositories of a config with scorecard data available",
+			},
+			configLabelNames("organization"),
+		),
+		apiRequestDuration: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: metricsNamespace,
+				Name:      "api_request_duration_seconds",
+				Help:      "Duration of requests to the OpenSSF Scorecard API, by HTTP status code",
+				Buckets:   prometheus.DefBuckets,
+			},
+			[]string{"status_code"},
+		),
+		normalizeLabels:    o.normalizeLabels,
+		namespaceLabel:     o.namespaceLabel,
+		normalizeScores:    o.normalizeScores,
+		registeredMetrics:  make(map[string]repositoryMetrics),
+		checkDocumentation: make(map[string]string),
+		watched:            make(map[string]struct{}),
 	}
 
-	// Register metrics with controller-runtime's metrics registry
-	metrics.Registry.MustRegister(
+	c.collectors = []prometheus.Collector{
 		c.overallScore,
 		c.checkScore,
 		c.checkStatus,
 		c.lastUpdate,
-	)
+		c.dataAge,
+		c.commitInfo,
+		c.checkInfo,
+		c.scoreUpdates,
+		c.worstCheckScore,
+		c.worstCheckInfo,
+		c.checksPassing,
+		c.checksFailing,
+		c.checksUnavailable,
+		c.customOverallScore,
+		c.checkDocumentationInfo,
+		c.checkDetails,
+		c.repositoryInfo,
+		c.reposExcluded,
+		c.reposTruncated,
+		c.authFailures,
+		c.partialReconciles,
+		c.authenticated,
+		c.configLastSuccess,
+		c.configErrors,
+		c.watchedConfigs,
+		c.repositories,
+		c.repositoriesWithData,
+		c.unavailableRepositories,
+		c.coverageRatio,
+		c.scoreDistribution,
+		c.organizationAverageScore,
+		c.apiRequestDuration,
+	}
+
+	// Register metrics with the configured registry, controller-runtime's by
+	// default, with the constant labels if any
+	registry := o.registry
+	if len(o.constLabels) > 0 {
+		registry = prometheus.WrapRegistererWith(o.constLabels, registry)
+	}
+	registry.MustRegister(c.collectors...)
+	c.constLabels = o.constLabels
 
 	return c
 }
 
 // UpdateMetrics updates Prometheus metrics based on scorecard data
-func (c *Collector) UpdateMetrics(configName, organization, repository string, data *scorecard.ScorecardData) {
+// for a repository on the VCS instance with the given host
+func (c *Collector) UpdateMetrics(configName, host, organization, repository string, data *scorecard.ScorecardData) {
+	organization, repository = c.sanitize(organization, repository)
+
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	labels := prometheus.Labels{
-		"config":       configName,
-		"organization": organization,
-		"repository":   repository,
+	labels := c.repositoryLabels(configName, host, organization, repository)
+
+	// Replace the scores of a repository whose data now comes from another source
+	metricKey := configName + "/" + host + "/" + organization + "/" + repository
+	if previous, ok := c.registeredMetrics[metricKey]; ok && previous.source != data.Source {
+		c.overallScore.DeletePartialMatch(labels)
+		c.checkScore.DeletePartialMatch(labels)
 	}
 
 	// Update overall score
-	c.overallScore.With(labels).Set(data.Score)
+	c.overallScore.With(withLabels(labels, "source", data.Source)).Set(c.score(data.Score))
+
+	// Link the score to the scanned commit through an exemplar
+	if data.Commit != "" {
+		c.scoreUpdates.With(labels).(prometheus.ExemplarAdder).AddWithExemplar(1, prometheus.Labels{"commit": data.Commit})
+	} else {
+		c.scoreUpdates.With(labels).Inc()
+	}
+
+	// Reasons are only kept for checks that currently fail, and details are
+	// replaced with the ones of the new report
+	c.checkInfo.DeletePartialMatch(labels)
+	c.checkDetails.DeletePartialMatch(labels)
 
 	// Update individual check scores and statuses
 	for _, check := range data.Checks {
-		checkLabels := prometheus.Labels{
-			"config":       configName,
-			"organization": organization,
-			"repository":   repository,
-			"check":        check.Name,
-		}
+		checkLabels := withLabels(labels, "check", check.Name)
 
-		c.checkScore.With(checkLabels).Set(float64(check.Score))
+		c.checkScore.With(withLabels(checkLabels, "source", data.Source)).Set(c.score(float64(check.Score)))
 
 		// Convert status to numeric value
 		var statusValue float64
 		switch check.Status {
-		case "Pass":
+		case scorecard.CheckStatusPass:
 			statusValue = 1
-		case "Fail":
+		case scorecard.CheckStatusFail:
 			statusValue = 0
+		case scorecard.CheckStatusInconclusive:
+			statusValue = -2 // the check could not run
 		default:
 			statusValue = -1 // unavailable or unknown
 		}
 		c.checkStatus.With(checkLabels).Set(statusValue)
+
+		if check.Status == scorecard.CheckStatusFail {
+			c.checkInfo.With(withLabels(checkLabels, "reason", truncate(check.Reason, maxReasonLength))).Set(1)
+		}
+
+		levels := make(map[string]int)
+		for _, detail := range check.Details {
+			level := detail.Level
+			if level == "" {
+				level = "Unknown"
+			}
+			levels[level]++
+		}
+		for level, count := range levels {
+			c.checkDetails.With(withLabels(checkLabels, "level", level)).Set(float64(count))
+		}
+
+		// Documentation is exported once per check, replacing a changed URL
+		if check.DocumentationURL != "" && c.checkDocumentation[check.Name] != check.DocumentationURL {
+			c.checkDocumentationInfo.DeletePartialMatch(prometheus.Labels{"check": check.Name})
+			c.checkDocumentationInfo.With(prometheus.Labels{
+				"check":             check.Name,
+				"documentation_url": check.DocumentationURL,
+			}).Set(1)
+			c.checkDocumentation[check.Name] = check.DocumentationURL
+		}
+	}
+
+	// Replace the worst check, left out when no check has a score
+	c.worstCheckInfo.DeletePartialMatch(labels)
+	if worst, ok := worstCheck(data.Checks); ok {
+		c.worstCheckScore.With(labels).Set(c.score(float64(worst.Score)))
+		c.worstCheckInfo.With(withLabels(labels, "check", worst.Name)).Set(1)
+	} else {
+		c.worstCheckScore.Delete(labels)
+	}
+
+	// Count the checks by status, left out without checks
+	if len(data.Checks) > 0 {
+		passing, failing, unavailable := countChecks(data.Checks)
+		c.checksPassing.With(labels).Set(float64(passing))
+		c.checksFailing.With(labels).Set(float64(failing))
+		c.checksUnavailable.With(labels).Set(float64(unavailable))
+	} else {
+		c.checksPassing.Delete(labels)
+		c.checksFailing.Delete(labels)
+		c.checksUnavailable.Delete(labels)
 	}
 
 	// Update last update timestamp
 	c.lastUpdate.With(labels).Set(float64(data.Timestamp.Unix()))
 
-	// Track this metric set
-	metricKey := configName + "/" + organization + "/" + repository
-	c.registeredMetrics[metricKey] = true
+	// Update data age, computed here so scrapers don't need to agree on the clock
+	c.dataAge.With(labels).Set(time.Since(data.Timestamp).Seconds())
+
+	// Replace the commit info, which changes with every new report
+	c.commitInfo.DeletePartialMatch(labels)
+	if data.Commit != "" {
+		c.commitInfo.With(withLabels(labels, "commit", data.Commit)).Set(1)
+	}
+
+	// Remove checks that are no longer reported, e.g. because they were
+	// filtered out, and track this metric set
+	checks := make([]string, 0, len(data.Checks))
+	for _, check := range data.Checks {
+		checks = append(checks, check.Name)
+	}
+	for _, name := range c.registeredMetrics[metricKey].checks {
+		if !slices.Contains(checks, name) {
+			checkLabels := withLabels(labels, "check", name)
+			c.checkScore.DeletePartialMatch(checkLabels)
+			c.checkStatus.Delete(checkLabels)
+		}
+	}
+	c.registeredMetrics[metricKey] = repositoryMetrics{checks: checks, source: data.Source}
+}
+
+// RemoveRepositoryMetrics removes the scorecard metrics of a repository on the
+// VCS instance with the given host, keeping its repository metadata
+func (c *Collector) RemoveRepositoryMetrics(configName, host, organization, repository string) {
+	organization, repository = c.sanitize(organization, repository)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	labels := c.repositoryLabels(configName, host, organization, repository)
+	for _, vec := range []*prometheus.GaugeVec{
+		c.overallScore,
+		c.checkScore,
+		c.checkStatus,
+		c.lastUpdate,
+		c.dataAge,
+		c.commitInfo,
+		c.checkInfo,
+		c.checkDetails,
+		c.worstCheckScore,
+		c.worstCheckInfo,
+		c.checksPassing,
+		c.checksFailing,
+		c.checksUnavailable,
+		c.customOverallScore,
+	} {
+		vec.DeletePartialMatch(labels)
+	}
+	c.scoreUpdates.DeletePartialMatch(labels)
+
+	delete(c.registeredMetrics, configName+"/"+host+"/"+organization+"/"+repository)
+}
+
+// UpdateCustomOverallScore records the overall score of a repository
+// recomputed from the check weights of its config
+func (c *Collector) UpdateCustomOverallScore(configName, host, organization, repository string, score float64) {
+	organization, repository = c.sanitize(organization, repository)
+	c.customOverallScore.With(c.repositoryLabels(configName, host, organization, repository)).Set(c.score(score))
+}
+
+// RemoveCustomOverallScore removes the custom overall score of a repository
+func (c *Collector) RemoveCustomOverallScore(configName, host, organization, repository string) {
+	organization, repository = c.sanitize(organization, repository)
+	c.customOverallScore.Delete(c.repositoryLabels(configName, host, organization, repository))
+}
+
+// UpdateRepositoryInfo exports the metadata of a repository, replacing any
+// previously exported metadata
+func (c *Collector) UpdateRepositoryInfo(configName, host, organization, repository string, details *vcs.Repository) {
+	organization, repository = c.sanitize(organization, repository)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	labels := c.repositoryLabels(configName, host, organization, repository)
+	c.repositoryInfo.DeletePartialMatch(labels)
+
+	visibility := "public"
+	if details.IsPrivate {
+		visibility = "private"
+	}
+	c.repositoryInfo.With(withLabels(labels,
+		"default_branch", details.DefaultBranch,
+		"visibility", visibility,
+		"archived", strconv.FormatBool(details.IsArchived),
+		"fork", strconv.FormatBool(details.IsFork),
+	)).Set(1)
+}
+
+// RepositoryExcluded records that a repository was excluded from scanning
+func (c *Collector) RepositoryExcluded(configName, organization, reason string) {
+	organization = sanitizeLabel(organization, c.normalizeLabels)
+	c.reposExcluded.With(c.configLabels(configName, "organization", organization, "reason", reason)).Inc()
+}
+
+// RepositoriesTruncated records that a reconcile skipped repositories of a
+// config because of the repository limit
+func (c *Collector) RepositoriesTruncated(configName, organization string) {
+	organization = sanitizeLabel(organization, c.normalizeLabels)
+	c.reposTruncated.With(c.configLabels(configName, "organization", organization)).Inc()
+}
+
+// VCSAuthFailed records that the VCS API rejected the credentials of a config
+func (c *Collector) VCSAuthFailed(configName, organization string) {
+	organization = sanitizeLabel(organization, c.normalizeLabels)
+	c.authFailures.With(c.configLabels(configName, "organization", organization)).Inc()
+}
+
+// PartialReconcile records that a reconcile of a config ran out of its time
+// budget and left repositories for the next one
+func (c *Collector) PartialReconcile(configName string) {
+	c.partialReconciles.With(c.configLabels(configName)).Inc()
+}
+
+// SetAuthenticated records whether a config accesses the VCS with credentials
+func (c *Collector) SetAuthenticated(configName string, authenticated bool) {
+	value := 0.0
+	if authenticated {
+		value = 1
+	}
+	c.authenticated.With(c.configLabels(configName)).Set(value)
+}
+
+// ConfigSucceeded records that a reconcile of a config exported the scorecard
+// data of all its repositories
+func (c *Collector) ConfigSucceeded(configName string) {
+	c.configLastSuccess.With(c.configLabels(configName)).SetToCurrentTime()
+}
+
+// ConfigError records that a config was skipped because of a configuration
+// error, such as ConfigErrorMissingOrganization
+func (c *Collector) ConfigError(configName, reason string) {
+	c.configErrors.With(c.configLabels(configName, "reason", reason)).Inc()
+}
+
+// WatchConfig counts a config as tracked by the controller until UnwatchConfig
+func (c *Collector) WatchConfig(configName string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.watched[configName] = struct{}{}
+	c.watchedConfigs.Set(float64(len(c.watched)))
+}
+
+// UnwatchConfig stops counting a deleted or unlabeled config as tracked
+func (c *Collector) UnwatchConfig(configName string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.watched, configName)
+	c.watchedConfigs.Set(float64(len(c.watched)))
+}
+
+// UpdateRepositoryCounts records how many repositories were discovered for a
+// config, how many of them have scorecard data, and how many have none yet.
+// The counts of another organization the config previously monitored are
+// removed.
+func (c *Collector) UpdateRepositoryCounts(configName, organization string, total, withData, unavailable int) {
+	labels := c.configLabels(configName, "organization", sanitizeLabel(organization, c.normalizeLabels))
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for vec, count := range map[*prometheus.GaugeVec]int{
+		c.repositories:            total,
+		c.repositoriesWithData:    withData,
+		c.unavailableRepositories: unavailable,
+	} {
+		vec.DeletePartialMatch(c.configLabels(configName))
+		vec.With(labels).Set(float64(count))
+	}
+}
+
+// UpdateCoverageRatio records the fraction of the repositories listed for a
+// config before filtering that are scanned. Organizations without any
+// repositories are fully covered.
+func (c *Collector) UpdateCoverageRatio(configName, organization string, scanned, listed int) {
+	ratio := 1.0
+	if listed > 0 {
+		ratio = float64(scanned) / float64(listed)
+	}
+	organization = sanitizeLabel(organization, c.normalizeLabels)
+	c.coverageRatio.With(c.configLabels(configName, "organization", organization)).Set(ratio)
+}
+
+// UpdateScoreDistribution replaces the score distribution of a config with
+// the overall scores of its repositories. The histogram is rebuilt on every
+// reconcile rather than accumulated, so each repository is counted once.
+func (c *Collector) UpdateScoreDistribution(configName, organization string, scores []float64) {
+	labels := c.configLabels(configName, "organization", sanitizeLabel(organization, c.normalizeLabels))
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.scoreDistribution.Delete(labels)
+	histogram := c.scoreDistribution.With(labels)
+	for _, score := range scores {
+		histogram.Observe(c.score(score))
+	}
+}
+
+// UpdateOrganizationAverageScore records the mean overall score of the
+// repositories of a config
+func (c *Collector) UpdateOrganizationAverageScore(configName, organization string, score float64) {
+	labels := c.configLabels(configName, "organization", sanitizeLabel(organization, c.normalizeLabels))
+	c.organizationAverageScore.With(labels).Set(c.score(score))
+}
+
+// RemoveOrganizationAverageScore removes the mean overall score of a config
+func (c *Collector) RemoveOrganizationAverageScore(configName, organization string) {
+	c.organizationAverageScore.Delete(c.configLabels(configName, "organization", sanitizeLabel(organization, c.normalizeLabels)))
+}
+
+// worstCheck returns the check with the lowest score, ignoring checks
+// without a score. Ties are broken by the alphabetical order of check names.
+func worstCheck(checks []scorecard.Check) (scorecard.Check, bool) {
+	var worst scorecard.Check
+	found := false
+	for _, check := range checks {
+		if check.Score < 0 {
+			continue
+		}
+		if !found || check.Score < worst.Score || (check.Score == worst.Score && check.Name < worst.Name) {
+			worst = check
+			found = true
+		}
+	}
+	return worst, found
+}
+
+// countChecks counts the passing and failing checks by their status, and the
+// unavailable ones, which are inconclusive or have an unknown status
+func countChecks(checks []scorecard.Check) (passing, failing, unavailable int) {
+	for _, check := range checks {
+		switch check.Status {
+		case scorecard.CheckStatusPass:
+			passing++
+		case scorecard.CheckStatusFail:
+			failing++
+		default:
+			unavailable++
+		}
+	}
+	return passing, failing, unavailable
+}
+
+// ObserveScorecardAPIRequest records the duration of a request to the
+// scorecard API. A zero status code is recorded as "error", for requests
+// that received no response.
+func (c *Collector) ObserveScorecardAPIRequest(statusCode int, duration time.Duration) {
+	status := "error"
+	if statusCode != 0 {
+		status = strconv.Itoa(statusCode)
+	}
+	c.apiRequestDuration.WithLabelValues(status).Observe(duration.Seconds())
+}
+
+// score returns the exported value of a score, scaled to 0-1 if scores are
+// normalized. Negative and NaN scores marking unavailable data are kept.
+func (c *Collector) score(score float64) float64 {
+	if c.normalizeScores && score > 0 {
+		return score / 10
+	}
+	return score
+}
+
+// RepositoryLabel returns the label value a repository is exported with, which
+// distinct names share when normalization maps them to the same value
+func (c *Collector) RepositoryLabel(repository string) string {
+	return sanitizeLabel(repository, c.normalizeLabels)
+}
+
+// sanitize prepares organization and repository names for use as label values
+func (c *Collector) sanitize(organization, repository string) (string, string) {
+	return sanitizeLabel(organization, c.normalizeLabels), sanitizeLabel(repository, c.normalizeLabels)
+}
+
+// truncate shortens s to at most maxLen runes, marking truncation with an ellipsis
+func truncate(s string, maxLen int) string {
+	runes := []rune(s)
+	if len(runes) <= maxLen {
+		return s
+	}
+	return string(runes[:maxLen-1]) + "…"
 }
 
 // RemoveMetricsForConfig removes all metrics associated with a config
@@ -150,13 +966,45 @@ func (c *Collector) RemoveMetricsForConfig(configName string) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	// Remove metrics for all repositories in this config
-	for key := range c.registeredMetrics {
-		// Simple prefix match - in production you might want more sophisticated tracking
-		delete(c.registeredMetrics, key)
+	labels := prometheus.Labels{"config": configName}
+	for _, vec := range []*prometheus.GaugeVec{
+		c.overallScore,
+		c.checkScore,
+		c.checkStatus,
+		c.lastUpdate,
+		c.dataAge,
+		c.commitInfo,
+		c.checkInfo,
+		c.checkDetails,
+		c.worstCheckScore,
+		c.worstCheckInfo,
+		c.checksPassing,
+		c.checksFailing,
+		c.checksUnavailable,
+		c.customOverallScore,
+		c.repositoryInfo,
+		c.repositories,
+		c.repositoriesWithData,
+		c.unavailableRepositories,
+		c.coverageRatio,
+		c.organizationAverageScore,
+		c.authenticated,
+		c.configLastSuccess,
+	} {
+		vec.DeletePartialMatch(labels)
 	}
+	c.scoreUpdates.DeletePartialMatch(labels)
+	c.reposExcluded.DeletePartialMatch(labels)
+	c.reposTruncated.DeletePartialMatch(labels)
+	c.authFailures.DeletePartialMatch(labels)
+	c.configErrors.DeletePartialMatch(labels)
+	c.partialReconciles.DeletePartialMatch(labels)
+	c.scoreDistribution.DeletePartialMatch(labels)
 
-	// Note: Prometheus client doesn't have a built-in way to delete specific metric labels
-	// The metrics will naturally be updated or expire based on scrape intervals
-	// For more control, you could use DeleteLabelValues with specific label combinations
+	// Remove tracking for all repositories in this config
+	for key := range c.registeredMetrics {
+		if strings.HasPrefix(key, configName+"/") {
+			delete(c.registeredMetrics, key)
+		}
+	}
 }
@@ -0,0 +1,971 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"math"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+
+	"github.com/giantswarm/openssf-scorecard-exporter/internal/scorecard"
+	"github.com/giantswarm/openssf-scorecard-exporter/internal/vcs"
+)
+
+// newTestCollector creates a collector registered with a private registry
+func newTestCollector() *Collector {
+	return NewCollector(WithRegistry(prometheus.NewRegistry()))
+}
+
+func TestUpdateMetrics_DataAge(t *testing.T) {
+	c := newTestCollector()
+
+	c.UpdateMetrics("default/config", "github.com", "org", "repo", &scorecard.ScorecardData{
+		Score:     7,
+		Timestamp: time.Now().Add(-48 * time.Hour),
+	})
+
+	age := testutil.ToFloat64(c.dataAge.WithLabelValues("default/config", "github.com", "org", "repo"))
+	expected := (48 * time.Hour).Seconds()
+	if age < expected || age > expected+60 {
+		t.Errorf("data_age_seconds = %v, want approximately %v", age, expected)
+	}
+}
+
+func TestUpdateMetrics_CommitInfo(t *testing.T) {
+	c := newTestCollector()
+
+	c.UpdateMetrics("default/config", "github.com", "org", "repo", &scorecard.ScorecardData{Score: 7, Commit: "abc123"})
+	c.UpdateMetrics("default/config", "github.com", "org", "repo", &scorecard.ScorecardData{Score: 8, Commit: "def456"})
+
+	if count := testutil.CollectAndCount(c.commitInfo); count != 1 {
+		t.Fatalf("commit_info series = %d, want 1", count)
+	}
+	if value := testutil.ToFloat64(c.commitInfo.WithLabelValues("default/config", "github.com", "org", "repo", "def456")); value != 1 {
+		t.Errorf("commit_info for def456 = %v, want 1", value)
+	}
+
+	// Unavailable data has no commit
+	c.UpdateMetrics("default/config", "github.com", "org", "repo", &scorecard.ScorecardData{Score: -1})
+	if count := testutil.CollectAndCount(c.commitInfo); count != 0 {
+		t.Errorf("commit_info series = %d, want 0", count)
+	}
+}
+
+func TestUpdateMetrics_ScoreUpdateExemplar(t *testing.T) {
+	c := newTestCollector()
+
+	c.UpdateMetrics("default/config", "github.com", "org", "repo", &scorecard.ScorecardData{Score: 7, Commit: "abc123"})
+	c.UpdateMetrics("default/config", "github.com", "org", "repo", &scorecard.ScorecardData{Score: 8, Commit: "def456"})
+
+	var m dto.Metric
+	if err := c.scoreUpdates.WithLabelValues("default/config", "github.com", "org", "repo").Write(&m); err != nil {
+		t.Fatalf("failed to write metric: %v", err)
+	}
+	if m.GetCounter().GetValue() != 2 {
+		t.Errorf("score_updates_total = %v, want 2", m.GetCounter().GetValue())
+	}
+	exemplar := m.GetCounter().GetExemplar()
+	if exemplar == nil {
+		t.Fatal("score_updates_total has no exemplar")
+	}
+	if labels := exemplar.GetLabel(); len(labels) != 1 || labels[0].GetName() != "commit" || labels[0].GetValue() != "def456" {
+		t.Errorf("exemplar labels = %v, want commit=def456", labels)
+	}
+
+	c.RemoveRepositoryMetrics("default/config", "github.com", "org", "repo")
+	if count := testutil.CollectAndCount(c.scoreUpdates); count != 0 {
+		t.Errorf("score_updates_total series = %d after removal, want 0", count)
+	}
+}
+
+func TestUpdateMetrics_NormalizedScores(t *testing.T) {
+	tests := []struct {
+		name          string
+		normalize     bool
+		expectOverall float64
+		expectCheck   float64
+		expectWorst   float64
+		expectAverage float64
+		expectRange   string
+	}{
+		{
+			name:          "raw",
+			expectOverall: 7.5,
+			expectCheck:   8,
+			expectWorst:   0,
+			expectAverage: 6,
+			expectRange:   "(0-10)",
+		},
+		{
+			name:          "normalized",
+			normalize:     true,
+			expectOverall: 0.75,
+			expectCheck:   0.8,
+			expectWorst:   0,
+			expectAverage: 0.6,
+			expectRange:   "(0-1)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := prometheus.NewRegistry()
+			c := NewCollector(WithRegistry(registry), WithScoreNormalization(tt.normalize))
+			c.UpdateMetrics("default/config", "github.com", "org", "repo", &scorecard.ScorecardData{
+				Score:  7.5,
+				Source: scorecard.DataSourceAPI,
+				Checks: []scorecard.Check{
+					{Name: "Code-Review", Score: 8, Status: "Pass"},
+					{Name: "Fuzzing", Score: 0, Status: "Fail"},
+					{Name: "Packaging", Score: -1, Status: "Inconclusive"},
+				},
+			})
+			c.UpdateMetrics("default/config", "github.com", "org", "missing", &scorecard.ScorecardData{
+				Score:  -1,
+				Source: scorecard.DataSourceAPI,
+			})
+			c.UpdateMetrics("default/config", "github.com", "org", "nan", &scorecard.ScorecardData{
+				Score:  math.NaN(),
+				Source: scorecard.DataSourceAPI,
+			})
+			c.UpdateOrganizationAverageScore("default/config", "org", 6)
+
+			overall := func(repository string) float64 {
+				return testutil.ToFloat64(c.overallScore.WithLabelValues("default/config", "github.com", "org", repository, "api"))
+			}
+			if value := overall("repo"); value != tt.expectOverall {
+				t.Errorf("overall_score = %v, want %v", value, tt.expectOverall)
+			}
+			check := func(name string) float64 {
+				return testutil.ToFloat64(c.checkScore.WithLabelValues("default/config", "github.com", "org", "repo", name, "api"))
+			}
+			if value := check("Code-Review"); value != tt.expectCheck {
+				t.Errorf("check_score = %v, want %v", value, tt.expectCheck)
+			}
+			if value := testutil.ToFloat64(c.worstCheckScore.WithLabelValues("default/config", "github.com", "org", "repo")); value != tt.expectWorst {
+				t.Errorf("worst_check_score = %v, want %v", value, tt.expectWorst)
+			}
+			if value := testutil.ToFloat64(c.organizationAverageScore.WithLabelValues("default/config", "org")); value != tt.expectAverage {
+				t.Errorf("organization_average_score = %v, want %v", value, tt.expectAverage)
+			}
+
+			// Values marking unavailable data are not scaled
+			if value := check("Packaging"); value != -1 {
+				t.Errorf("inconclusive check_score = %v, want -1", value)
+			}
+			if value := overall("missing"); value != -1 {
+				t.Errorf("unavailable overall_score = %v, want -1", value)
+			}
+			if value := overall("nan"); !math.IsNaN(value) {
+				t.Errorf("unavailable overall_score = %v, want NaN", value)
+			}
+
+			families, err := registry.Gather()
+			if err != nil {
+				t.Fatalf("failed to gather metrics: %v", err)
+			}
+			for _, family := range families {
+				if family.GetName() == "openssf_scorecard_overall_score" && !strings.Contains(family.GetHelp(), tt.expectRange) {
+					t.Errorf("overall_score help = %q, want range %s", family.GetHelp(), tt.expectRange)
+				}
+			}
+		})
+	}
+}
+
+func TestUpdateMetrics_CheckStatus(t *testing.T) {
+	c := newTestCollector()
+	c.UpdateMetrics("default/config", "github.com", "org", "repo", &scorecard.ScorecardData{
+		Checks: []scorecard.Check{
+			{Name: "Code-Review", Score: 8, Status: "Pass"},
+			{Name: "Fuzzing", Score: 0, Status: "Fail"},
+			{Name: "Packaging", Score: -1, Status: "Inconclusive"},
+			{Name: "Webhooks", Score: -1},
+		},
+	})
+
+	// Checks that could not run are told apart from unknown statuses
+	expected := map[string]float64{"Code-Review": 1, "Fuzzing": 0, "Packaging": -2, "Webhooks": -1}
+	for check, status := range expected {
+		value := testutil.ToFloat64(c.checkStatus.WithLabelValues("default/config", "github.com", "org", "repo", check))
+		if value != status {
+			t.Errorf("check_status{check=%q} = %v, want %v", check, value, status)
+		}
+	}
+}
+
+func TestUpdateMetrics_CheckDetails(t *testing.T) {
+	c := newTestCollector()
+	c.UpdateMetrics("default/config", "github.com", "org", "repo", &scorecard.ScorecardData{
+		Checks: []scorecard.Check{
+			{Name: "Code-Review", Score: 8, Status: "Pass"},
+			{Name: "Fuzzing", Score: 0, Status: "Fail", Details: []scorecard.CheckDetail{
+				{Level: scorecard.CheckDetailWarn, Message: "no fuzzer integrations found"},
+				{Level: scorecard.CheckDetailWarn, Message: "OSS-Fuzz not detected"},
+				{Level: scorecard.CheckDetailInfo, Message: "ClusterFuzzLite not detected"},
+				{Message: "unprefixed"},
+			}},
+		},
+	})
+
+	expected := map[string]float64{"Warn": 2, "Info": 1, "Unknown": 1}
+	for level, count := range expected {
+		value := testutil.ToFloat64(c.checkDetails.WithLabelValues("default/config", "github.com", "org", "repo", "Fuzzing", level))
+		if value != count {
+			t.Errorf("check_details{level=%q} = %v, want %v", level, value, count)
+		}
+	}
+	if count := testutil.CollectAndCount(c.checkDetails); count != len(expected) {
+		t.Errorf("check_details has %d series, want %d", count, len(expected))
+	}
+
+	// Details are replaced by the ones of the next report
+	c.UpdateMetrics("default/config", "github.com", "org", "repo", &scorecard.ScorecardData{
+		Checks: []scorecard.Check{{Name: "Fuzzing", Score: 10, Status: "Pass"}},
+	})
+	if count := testutil.CollectAndCount(c.checkDetails); count != 0 {
+		t.Errorf("check_details has %d series after a report without details, want 0", count)
+	}
+}
+
+func TestUpdateMetrics_CheckInfo(t *testing.T) {
+	longReason := strings.Repeat("x", 200)
+
+	tests := []struct {
+		name     string
+		checks   []scorecard.Check
+		expected map[string]string
+	}{
+		{
+			name: "only failing checks",
+			checks: []scorecard.Check{
+				{Name: "Code-Review", Score: 8, Status: "Pass", Reason: "reviewed"},
+				{Name: "Fuzzing", Score: 0, Status: "Fail", Reason: "project is not fuzzed"},
+				{Name: "Packaging", Score: -1, Status: "Inconclusive", Reason: "internal error"},
+			},
+			expected: map[string]string{"Fuzzing": "project is not fuzzed"},
+		},
+		{
+			name: "long reason truncated",
+			checks: []scorecard.Check{
+				{Name: "Fuzzing", Score: 0, Status: "Fail", Reason: longReason},
+			},
+			expected: map[string]string{"Fuzzing": longReason[:maxReasonLength-1] + "…"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestCollector()
+
+			// A previous report with a different reason must not leave stale series
+			c.UpdateMetrics("default/config", "github.com", "org", "repo", &scorecard.ScorecardData{
+				Checks: []scorecard.Check{{Name: "Fuzzing", Status: "Fail", Reason: "old reason"}},
+			})
+			c.UpdateMetrics("default/config", "github.com", "org", "repo", &scorecard.ScorecardData{Checks: tt.checks})
+
+			if count := testutil.CollectAndCount(c.checkInfo); count != len(tt.expected) {
+				t.Errorf("check_info series = %d, want %d", count, len(tt.expected))
+			}
+			for check, reason := range tt.expected {
+				value := testutil.ToFloat64(c.checkInfo.WithLabelValues("default/config", "github.com", "org", "repo", check, reason))
+				if value != 1 {
+					t.Errorf("check_info{check=%q} = %v, want 1", check, value)
+				}
+			}
+		})
+	}
+}
+
+func TestUpdateMetrics_WorstCheck(t *testing.T) {
+	tests := []struct {
+		name          string
+		checks        []scorecard.Check
+		expectedCheck string
+		expectedScore float64
+	}{
+		{
+			name: "lowest score",
+			checks: []scorecard.Check{
+				{Name: "Code-Review", Score: 8},
+				{Name: "Maintained", Score: 3},
+				{Name: "Fuzzing", Score: 5},
+			},
+			expectedCheck: "Maintained",
+			expectedScore: 3,
+		},
+		{
+			name: "ties broken alphabetically",
+			checks: []scorecard.Check{
+				{Name: "Token-Permissions", Score: 0},
+				{Name: "Fuzzing", Score: 0},
+				{Name: "SAST", Score: 0},
+			},
+			expectedCheck: "Fuzzing",
+			expectedScore: 0,
+		},
+		{
+			name: "inconclusive checks ignored",
+			checks: []scorecard.Check{
+				{Name: "Packaging", Score: -1},
+				{Name: "Code-Review", Score: 8},
+			},
+			expectedCheck: "Code-Review",
+			expectedScore: 8,
+		},
+		{
+			name:   "no scored check",
+			checks: []scorecard.Check{{Name: "Packaging", Score: -1}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestCollector()
+
+			// A previous worst check must not leave a stale series
+			c.UpdateMetrics("default/config", "github.com", "org", "repo", &scorecard.ScorecardData{
+				Checks: []scorecard.Check{{Name: "Binary-Artifacts", Score: 1}},
+			})
+			c.UpdateMetrics("default/config", "github.com", "org", "repo", &scorecard.ScorecardData{Checks: tt.checks})
+
+			if tt.expectedCheck == "" {
+				if count := testutil.CollectAndCount(c.worstCheckScore) + testutil.CollectAndCount(c.worstCheckInfo); count != 0 {
+					t.Errorf("worst check series = %d, want 0", count)
+				}
+				return
+			}
+			if count := testutil.CollectAndCount(c.worstCheckInfo); count != 1 {
+				t.Errorf("worst_check_info series = %d, want 1", count)
+			}
+			if value := testutil.ToFloat64(c.worstCheckInfo.WithLabelValues("default/config", "github.com", "org", "repo", tt.expectedCheck)); value != 1 {
+				t.Errorf("worst_check_info{check=%q} = %v, want 1", tt.expectedCheck, value)
+			}
+			if value := testutil.ToFloat64(c.worstCheckScore.WithLabelValues("default/config", "github.com", "org", "repo")); value != tt.expectedScore {
+				t.Errorf("worst_check_score = %v, want %v", value, tt.expectedScore)
+			}
+		})
+	}
+}
+
+func TestUpdateMetrics_ChecksByStatus(t *testing.T) {
+	tests := []struct {
+		name                string
+		checks              []scorecard.Check
+		expectedPassing     float64
+		expectedFailing     float64
+		expectedUnavailable float64
+	}{
+		{
+			name: "mixed statuses",
+			checks: []scorecard.Check{
+				{Name: "Code-Review", Score: 8, Status: "Pass"},
+				{Name: "Maintained", Score: 10, Status: "Pass"},
+				{Name: "Fuzzing", Score: 0, Status: "Fail"},
+				{Name: "SAST", Score: 3, Status: "Fail"},
+				{Name: "Token-Permissions", Score: 2, Status: "Fail"},
+				{Name: "Packaging", Score: -1, Status: "Inconclusive"},
+				{Name: "Signed-Releases", Score: -1, Status: "Unknown"},
+			},
+			expectedPassing:     2,
+			expectedFailing:     3,
+			expectedUnavailable: 2,
+		},
+		{
+			name:            "all passing",
+			checks:          []scorecard.Check{{Name: "Code-Review", Score: 8, Status: "Pass"}},
+			expectedPassing: 1,
+		},
+		{name: "no checks"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestCollector()
+
+			// A previous report must not leave stale counts
+			c.UpdateMetrics("default/config", "github.com", "org", "repo", &scorecard.ScorecardData{
+				Checks: []scorecard.Check{{Name: "Binary-Artifacts", Score: 1, Status: "Fail"}},
+			})
+			c.UpdateMetrics("default/config", "github.com", "org", "repo", &scorecard.ScorecardData{Checks: tt.checks})
+
+			if len(tt.checks) == 0 {
+				count := testutil.CollectAndCount(c.checksPassing) + testutil.CollectAndCount(c.checksFailing) +
+					testutil.CollectAndCount(c.checksUnavailable)
+				if count != 0 {
+					t.Errorf("checks by status series = %d, want 0", count)
+				}
+				return
+			}
+			for name, expected := range map[string]struct {
+				vec   *prometheus.GaugeVec
+				value float64
+			}{
+				"checks_passing":     {c.checksPassing, tt.expectedPassing},
+				"checks_failing":     {c.checksFailing, tt.expectedFailing},
+				"checks_unavailable": {c.checksUnavailable, tt.expectedUnavailable},
+			} {
+				if value := testutil.ToFloat64(expected.vec.WithLabelValues("default/config", "github.com", "org", "repo")); value != expected.value {
+					t.Errorf("%s = %v, want %v", name, value, expected.value)
+				}
+			}
+		})
+	}
+}
+
+func TestUpdateMetrics_CheckDocumentationInfo(t *testing.T) {
+	c := newTestCollector()
+
+	checks := []scorecard.Check{
+		{Name: "Code-Review", Score: 8, Status: "Pass", DocumentationURL: "https://example.com/checks#code-review"},
+		{Name: "Fuzzing", Score: 0, Status: "Fail", DocumentationURL: "https://example.com/checks#fuzzing-v1"},
+		{Name: "Packaging", Score: -1, Status: "Unknown"},
+	}
+	for _, repository := range []string{"repo-a", "repo-b"} {
+		c.UpdateMetrics("default/config", "github.com", "org", repository, &scorecard.ScorecardData{Checks: checks})
+	}
+	c.UpdateMetrics("other/config", "github.com", "other", "repo", &scorecard.ScorecardData{Checks: []scorecard.Check{
+		{Name: "Fuzzing", Score: 0, Status: "Fail", DocumentationURL: "https://example.com/checks#fuzzing"},
+	}})
+
+	// One series per distinct check, with the latest documentation URL
+	if count := testutil.CollectAndCount(c.checkDocumentationInfo); count != 2 {
+		t.Errorf("check_documentation_info series = %d, want 2", count)
+	}
+	expected := map[string]string{
+		"Code-Review": "https://example.com/checks#code-review",
+		"Fuzzing":     "https://example.com/checks#fuzzing",
+	}
+	for check, url := range expected {
+		if value := testutil.ToFloat64(c.checkDocumentationInfo.WithLabelValues(check, url)); value != 1 {
+			t.Errorf("check_documentation_info{check=%q} = %v, want 1", check, value)
+		}
+	}
+
+	// Documentation is not tied to a config
+	c.RemoveMetricsForConfig("default/config")
+	if count := testutil.CollectAndCount(c.checkDocumentationInfo); count != 2 {
+		t.Errorf("check_documentation_info series = %d after removing a config, want 2", count)
+	}
+}
+
+func TestUpdateRepositoryInfo(t *testing.T) {
+	c := newTestCollector()
+
+	c.UpdateRepositoryInfo("default/config", "github.com", "org", "repo", &vcs.Repository{
+		DefaultBranch: "master",
+		IsPrivate:     true,
+	})
+	c.UpdateRepositoryInfo("default/config", "github.com", "org", "repo-2", &vcs.Repository{DefaultBranch: "master"})
+
+	// Changed metadata replaces the series of the repository, and only its own
+	c.UpdateRepositoryInfo("default/config", "github.com", "org", "repo", &vcs.Repository{
+		DefaultBranch: "main",
+		IsArchived:    true,
+		IsFork:        true,
+	})
+
+	if count := testutil.CollectAndCount(c.repositoryInfo); count != 2 {
+		t.Fatalf("repository_info series = %d, want 2", count)
+	}
+	for _, labels := range [][]string{
+		{"default/config", "github.com", "org", "repo", "main", "public", "true", "true"},
+		{"default/config", "github.com", "org", "repo-2", "master", "public", "false", "false"},
+	} {
+		if value := testutil.ToFloat64(c.repositoryInfo.WithLabelValues(labels...)); value != 1 {
+			t.Errorf("repository_info%v = %v, want 1", labels, value)
+		}
+	}
+}
+
+func TestUpdateRepositoryCounts(t *testing.T) {
+	c := newTestCollector()
+
+	c.UpdateRepositoryCounts("default/config", "old-org", 3, 2, 1)
+	c.UpdateRepositoryCounts("default/other", "old-org", 1, 1, 0)
+
+	// A config monitoring another organization replaces its counts
+	c.UpdateRepositoryCounts("default/config", "new-org", 5, 4, 1)
+
+	for name, vec := range map[string]*prometheus.GaugeVec{
+		"repositories":             c.repositories,
+		"repositories_with_data":   c.repositoriesWithData,
+		"unavailable_repositories": c.unavailableRepositories,
+	} {
+		if count := testutil.CollectAndCount(vec); count != 2 {
+			t.Errorf("%s series = %d, want 2", name, count)
+		}
+	}
+	if value := testutil.ToFloat64(c.repositories.WithLabelValues("default/config", "new-org")); value != 5 {
+		t.Errorf("repositories = %v, want 5", value)
+	}
+	if value := testutil.ToFloat64(c.repositories.WithLabelValues("default/other", "old-org")); value != 1 {
+		t.Errorf("repositories of another config = %v, want 1", value)
+	}
+}
+
+func TestRemoveMetricsForConfig(t *testing.T) {
+	c := newTestCollector()
+
+	data := &scorecard.ScorecardData{
+		Score:  7,
+		Commit: "abc123",
+		Checks: []scorecard.Check{{Name: "Fuzzing", Score: 0, Status: "Fail", Reason: "not fuzzed"}},
+	}
+	c.UpdateMetrics("default/config", "github.com", "org", "repo", data)
+	c.UpdateMetrics("default/other", "github.com", "org", "repo", data)
+
+	c.RemoveMetricsForConfig("default/config")
+
+	for name, vec := range map[string]*prometheus.GaugeVec{
+		"overall_score":         c.overallScore,
+		"check_score":           c.checkScore,
+		"check_status":          c.checkStatus,
+		"commit_info":           c.commitInfo,
+		"check_info":            c.checkInfo,
+		"data_age_seconds":      c.dataAge,
+		"last_update_timestamp": c.lastUpdate,
+	} {
+		if count := testutil.CollectAndCount(vec); count != 1 {
+			t.Errorf("%s series = %d, want 1 for the remaining config", name, count)
+		}
+	}
+	if len(c.registeredMetrics) != 1 {
+		t.Errorf("registered metrics = %v, want only default/other", c.registeredMetrics)
+	}
+}
+
+func TestWatchConfig(t *testing.T) {
+	c := newTestCollector()
+
+	c.WatchConfig("default/a")
+	c.WatchConfig("default/b")
+	c.WatchConfig("default/a")
+	if value := testutil.ToFloat64(c.watchedConfigs); value != 2 {
+		t.Errorf("watched_configs = %v, want 2", value)
+	}
+
+	c.UnwatchConfig("default/a")
+	c.UnwatchConfig("default/unknown")
+	if value := testutil.ToFloat64(c.watchedConfigs); value != 1 {
+		t.Errorf("watched_configs = %v after unwatching, want 1", value)
+	}
+}
+
+func TestWithConstLabels(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	c := NewCollector(WithRegistry(registry), WithConstLabels(prometheus.Labels{"cluster": "prod"}))
+	c.UpdateMetrics("default/config", "github.com", "org", "repo", &scorecard.ScorecardData{
+		Score:  7.5,
+		Checks: []scorecard.Check{{Name: "Code-Review", Score: 8}},
+	})
+	c.UpdateRepositoryCounts("default/config", "org", 1, 1, 0)
+
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("Gather() error = %v", err)
+	}
+	if len(families) == 0 {
+		t.Fatal("Gather() returned no metrics")
+	}
+	for _, family := range families {
+		for _, metric := range family.GetMetric() {
+			found := false
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == "cluster" && label.GetValue() == "prod" {
+					found = true
+				}
+			}
+			if !found {
+				t.Errorf("%s series %v has no cluster=prod label", family.GetName(), metric.GetLabel())
+			}
+		}
+	}
+}
+
+func TestWithNamespaceLabel(t *testing.T) {
+	// Metrics that are not exported per config
+	unscoped := []string{
+		"openssf_scorecard_check_documentation_info",
+		"openssf_scorecard_watched_configs",
+	}
+
+	for _, enabled := range []bool{true, false} {
+		registry := prometheus.NewRegistry()
+		c := NewCollector(WithRegistry(registry), WithNamespaceLabel(enabled))
+		config := "team-a/config"
+		c.UpdateMetrics(config, "github.com", "org", "repo", &scorecard.ScorecardData{
+			Score:  7.5,
+			Commit: "abc123",
+			Checks: []scorecard.Check{{
+				Name:             "Code-Review",
+				Score:            0,
+				Status:           scorecard.CheckStatusFail,
+				Reason:           "no reviews",
+				Details:          []scorecard.CheckDetail{{Level: "Warn", Message: "no reviews"}},
+				DocumentationURL: "https://example.com/code-review",
+			}},
+		})
+		c.UpdateCustomOverallScore(config, "github.com", "org", "repo", 5)
+		c.UpdateRepositoryInfo(config, "github.com", "org", "repo", &vcs.Repository{DefaultBranch: "main"})
+		c.RepositoryExcluded(config, "org", "archived")
+		c.RepositoriesTruncated(config, "org")
+		c.VCSAuthFailed(config, "org")
+		c.PartialReconcile(config)
+		c.SetAuthenticated(config, true)
+		c.ConfigSucceeded(config)
+		c.ConfigError(config, ConfigErrorMissingOrganization)
+		c.WatchConfig(config)
+		c.UpdateRepositoryCounts(config, "org", 1, 1, 0)
+		c.UpdateCoverageRatio(config, "org", 1, 2)
+		c.UpdateScoreDistribution(config, "org", []float64{7.5})
+		c.UpdateOrganizationAverageScore(config, "org", 7.5)
+
+		families, err := registry.Gather()
+		if err != nil {
+			t.Fatalf("Gather() error = %v", err)
+		}
+		for _, family := range families {
+			if slices.Contains(unscoped, family.GetName()) {
+				continue
+			}
+			for _, metric := range family.GetMetric() {
+				var namespace *string
+				for _, label := range metric.GetLabel() {
+					if label.GetName() == NamespaceLabel {
+						namespace = label.Value
+					}
+				}
+				switch {
+				case enabled && (namespace == nil || *namespace != "team-a"):
+					t.Errorf("%s series %v has no namespace=team-a label", family.GetName(), metric.GetLabel())
+				case !enabled && namespace != nil:
+					t.Errorf("%s series %v has a namespace label, want none when disabled", family.GetName(), metric.GetLabel())
+				}
+			}
+		}
+
+		// The series of the config are still removed together
+		c.RemoveCustomOverallScore(config, "github.com", "org", "repo")
+		c.RemoveOrganizationAverageScore(config, "org")
+		if got := testutil.CollectAndCount(c.customOverallScore) + testutil.CollectAndCount(c.organizationAverageScore); got != 0 {
+			t.Errorf("custom overall and average score series = %d after removal, want 0", got)
+		}
+		c.RemoveMetricsForConfig(config)
+		if got := testutil.CollectAndCount(c.overallScore) + testutil.CollectAndCount(c.configErrors); got != 0 {
+			t.Errorf("series = %d after RemoveMetricsForConfig, want 0", got)
+		}
+	}
+}
+
+func TestUpdateCustomOverallScore(t *testing.T) {
+	c := newTestCollector()
+	c.UpdateCustomOverallScore("default/config", "github.com", "org", "repo", 6.5)
+	if got := testutil.ToFloat64(c.customOverallScore.WithLabelValues("default/config", "github.com", "org", "repo")); got != 6.5 {
+		t.Errorf("custom_overall_score = %v, want 6.5", got)
+	}
+
+	c.RemoveCustomOverallScore("default/config", "github.com", "org", "repo")
+	if count := testutil.CollectAndCount(c.customOverallScore); count != 0 {
+		t.Errorf("custom_overall_score series = %d after removal, want 0", count)
+	}
+
+	normalized := NewCollector(WithRegistry(prometheus.NewRegistry()), WithScoreNormalization(true))
+	normalized.UpdateCustomOverallScore("default/config", "github.com", "org", "repo", 6.5)
+	if got := testutil.ToFloat64(normalized.customOverallScore.WithLabelValues("default/config", "github.com", "org", "repo")); got != 0.65 {
+		t.Errorf("normalized custom_overall_score = %v, want 0.65", got)
+	}
+}
+
+func TestUpdateCoverageRatio(t *testing.T) {
+	tests := []struct {
+		name     string
+		scanned  int
+		listed   int
+		expected float64
+	}{
+		{name: "partially covered", scanned: 30, listed: 120, expected: 0.25},
+		{name: "fully covered", scanned: 7, listed: 7, expected: 1},
+		{name: "nothing scanned", scanned: 0, listed: 5, expected: 0},
+		{name: "no repositories", scanned: 0, listed: 0, expected: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestCollector()
+			c.UpdateCoverageRatio("default/config", "org", tt.scanned, tt.listed)
+			if got := testutil.ToFloat64(c.coverageRatio.WithLabelValues("default/config", "org")); got != tt.expected {
+				t.Errorf("coverage_ratio = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSetAuthenticated(t *testing.T) {
+	c := newTestCollector()
+
+	c.SetAuthenticated("default/tokened", true)
+	c.SetAuthenticated("default/anonymous", false)
+	expected := map[string]float64{"default/tokened": 1, "default/anonymous": 0}
+	for config, value := range expected {
+		if got := testutil.ToFloat64(c.authenticated.WithLabelValues(config)); got != value {
+			t.Errorf("authenticated{config=%q} = %v, want %v", config, got, value)
+		}
+	}
+
+	c.RemoveMetricsForConfig("default/tokened")
+	if count := testutil.CollectAndCount(c.authenticated); count != 1 {
+		t.Errorf("authenticated series = %d after removing a config, want 1", count)
+	}
+}
+
+func TestConfigSucceeded(t *testing.T) {
+	c := newTestCollector()
+
+	before := float64(time.Now().Unix())
+	c.ConfigSucceeded("default/config")
+	if got := testutil.ToFloat64(c.configLastSuccess.WithLabelValues("default/config")); got < before {
+		t.Errorf("config_last_success_timestamp = %v, want at least %v", got, before)
+	}
+
+	c.RemoveMetricsForConfig("default/config")
+	if count := testutil.CollectAndCount(c.configLastSuccess); count != 0 {
+		t.Errorf("config_last_success_timestamp series = %d after removing the config, want 0", count)
+	}
+}
+
+func TestRepositoryExcluded(t *testing.T) {
+	c := newTestCollector()
+
+	for _, reason := range []string{"fork", "private", "fork", "archived", "fork"} {
+		c.RepositoryExcluded("default/config", "org", reason)
+	}
+
+	expected := map[string]float64{"fork": 3, "private": 1, "archived": 1}
+	for reason, count := range expected {
+		if got := testutil.ToFloat64(c.reposExcluded.WithLabelValues("default/config", "org", reason)); got != count {
+			t.Errorf("repos_excluded_total{reason=%q} = %v, want %v", reason, got, count)
+		}
+	}
+}
+
+func TestUpdateScoreDistribution(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	c := NewCollector(WithRegistry(registry))
+
+	// A previous reconcile must not be counted again
+	c.UpdateScoreDistribution("default/config", "org", []float64{1, 2, 3})
+	c.UpdateScoreDistribution("default/config", "org", []float64{0, 2.5, 7.5, 7.5, 10})
+
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("failed to gather metrics: %v", err)
+	}
+	var histogram *dto.Histogram
+	for _, family := range families {
+		if family.GetName() == "openssf_scorecard_score_distribution" {
+			if len(family.GetMetric()) != 1 {
+				t.Fatalf("score_distribution series = %d, want 1", len(family.GetMetric()))
+			}
+			histogram = family.GetMetric()[0].GetHistogram()
+		}
+	}
+	if histogram == nil {
+		t.Fatal("score_distribution not exported")
+	}
+
+	if histogram.GetSampleCount() != 5 || histogram.GetSampleSum() != 27.5 {
+		t.Errorf("score_distribution count = %d, sum = %v, want 5 and 27.5",
+			histogram.GetSampleCount(), histogram.GetSampleSum())
+	}
+	expected := map[float64]uint64{0: 1, 1: 1, 2: 1, 3: 2, 7: 2, 8: 4, 9: 4, 10: 5}
+	for _, bucket := range histogram.GetBucket() {
+		if want, ok := expected[bucket.GetUpperBound()]; ok && bucket.GetCumulativeCount() != want {
+			t.Errorf("score_distribution bucket le=%v = %d, want %d",
+				bucket.GetUpperBound(), bucket.GetCumulativeCount(), want)
+		}
+	}
+
+	c.RemoveMetricsForConfig("default/config")
+	if count := testutil.CollectAndCount(c.scoreDistribution); count != 0 {
+		t.Errorf("score_distribution series = %d after removing the config, want 0", count)
+	}
+}
+
+func TestRemoveRepositoryMetrics(t *testing.T) {
+	c := newTestCollector()
+
+	data := &scorecard.ScorecardData{
+		Score:  7,
+		Commit: "abc123",
+		Checks: []scorecard.Check{{Name: "Fuzzing", Score: 0, Status: "Fail", Reason: "not fuzzed"}},
+	}
+	c.UpdateMetrics("default/config", "github.com", "org", "repo", data)
+	c.UpdateMetrics("default/config", "github.com", "org", "other", data)
+	c.UpdateRepositoryInfo("default/config", "github.com", "org", "repo", &vcs.Repository{DefaultBranch: "main"})
+
+	c.RemoveRepositoryMetrics("default/config", "github.com", "org", "repo")
+
+	for name, vec := range map[string]*prometheus.GaugeVec{
+		"overall_score":   c.overallScore,
+		"check_score":     c.checkScore,
+		"check_info":      c.checkInfo,
+		"commit_info":     c.commitInfo,
+		"worst_check":     c.worstCheckScore,
+		"checks_passing":  c.checksPassing,
+		"repository_info": c.repositoryInfo,
+	} {
+		if count := testutil.CollectAndCount(vec); count != 1 {
+			t.Errorf("%s series = %d, want 1", name, count)
+		}
+	}
+	if len(c.registeredMetrics) != 1 {
+		t.Errorf("registered metrics = %v, want only the other repository", c.registeredMetrics)
+	}
+}
+
+func TestUpdateMetrics_SanitizedLabels(t *testing.T) {
+	long := strings.Repeat("r", 200)
+
+	tests := []struct {
+		name       string
+		opts       []Option
+		repository string
+		expected   string
+	}{
+		{
+			name:       "names kept by default",
+			repository: "my.repo",
+			expected:   "my.repo",
+		},
+		{
+			name:       "names normalized",
+			opts:       []Option{WithLabelNormalization(true)},
+			repository: "my.repo",
+			expected:   "my_repo",
+		},
+		{
+			name:       "long names truncated",
+			repository: long,
+			expected:   sanitizeLabel(long, false),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCollector(append(tt.opts, WithRegistry(prometheus.NewRegistry()))...)
+			c.UpdateMetrics("default/config", "github.com", "org", tt.repository, &scorecard.ScorecardData{Score: 5})
+
+			if value := testutil.ToFloat64(c.overallScore.WithLabelValues("default/config", "github.com", "org", tt.expected, "")); value != 5 {
+				t.Errorf("overall_score{repository=%q} = %v, want 5", tt.expected, value)
+			}
+
+			// Removal sanitizes the same way
+			c.RemoveRepositoryMetrics("default/config", "github.com", "org", tt.repository)
+			if count := testutil.CollectAndCount(c.overallScore); count != 0 {
+				t.Errorf("overall_score series = %d after removal, want 0", count)
+			}
+		})
+	}
+}
+
+func TestUpdateMetrics_Source(t *testing.T) {
+	c := newTestCollector()
+
+	for _, source := range []string{scorecard.DataSourceAPI, scorecard.DataSourceLocal} {
+		c.UpdateMetrics("default/config", "github.com", "org", "repo", &scorecard.ScorecardData{
+			Score:  7,
+			Checks: []scorecard.Check{{Name: "Fuzzing", Score: 3, Status: "Fail"}},
+			Source: source,
+		})
+	}
+
+	// Scores of the previous source are replaced
+	if value := testutil.ToFloat64(c.overallScore.WithLabelValues("default/config", "github.com", "org", "repo", "local")); value != 7 {
+		t.Errorf("overall_score{source=\"local\"} = %v, want 7", value)
+	}
+	if value := testutil.ToFloat64(c.checkScore.WithLabelValues("default/config", "github.com", "org", "repo", "Fuzzing", "local")); value != 3 {
+		t.Errorf("check_score{source=\"local\"} = %v, want 3", value)
+	}
+	for name, vec := range map[string]*prometheus.GaugeVec{
+		"overall_score": c.overallScore,
+		"check_score":   c.checkScore,
+	} {
+		if count := testutil.CollectAndCount(vec); count != 1 {
+			t.Errorf("%s series = %d, want 1 for the current source", name, count)
+		}
+	}
+}
+
+func TestUpdateMetrics_RemovedChecks(t *testing.T) {
+	c := newTestCollector()
+
+	c.UpdateMetrics("default/config", "github.com", "org", "repo", &scorecard.ScorecardData{
+		Score: 7,
+		Checks: []scorecard.Check{
+			{Name: "Branch-Protection", Score: 8, Status: "Pass"},
+			{Name: "Fuzzing", Score: 0, Status: "Fail"},
+		},
+	})
+	c.UpdateMetrics("default/config", "github.com", "org", "repo", &scorecard.ScorecardData{
+		Score:  7,
+		Checks: []scorecard.Check{{Name: "Branch-Protection", Score: 9, Status: "Pass"}},
+	})
+
+	for name, vec := range map[string]*prometheus.GaugeVec{
+		"check_score":  c.checkScore,
+		"check_status": c.checkStatus,
+	} {
+		if count := testutil.CollectAndCount(vec); count != 1 {
+			t.Errorf("%s series = %d, want 1 for the remaining check", name, count)
+		}
+	}
+}
+
+func TestObserveScorecardAPIRequest(t *testing.T) {
+	c := newTestCollector()
+
+	c.ObserveScorecardAPIRequest(200, 100*time.Millisecond)
+	c.ObserveScorecardAPIRequest(200, 300*time.Millisecond)
+	c.ObserveScorecardAPIRequest(404, 50*time.Millisecond)
+	c.ObserveScorecardAPIRequest(0, time.Second)
+
+	expected := map[string]uint64{"200": 2, "404": 1, "error": 1}
+	metrics := make(chan prometheus.Metric, 10)
+	c.apiRequestDuration.Collect(metrics)
+	close(metrics)
+	for metric := range metrics {
+		var m dto.Metric
+		if err := metric.Write(&m); err != nil {
+			t.Fatalf("failed to write metric: %v", err)
+		}
+		status := m.GetLabel()[0].GetValue()
+		if got := m.GetHistogram().GetSampleCount(); got != expected[status] {
+			t.Errorf("api_request_duration_seconds{status_code=%q} count = %d, want %d", status, got, expected[status])
+		}
+		delete(expected, status)
+	}
+	if len(expected) != 0 {
+		t.Errorf("missing api_request_duration_seconds series: %v", expected)
+	}
+}
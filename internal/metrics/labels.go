@@ -0,0 +1,135 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/model"
+)
+
+const (
+	// maxLabelLength bounds the length of organization and repository labels
+	maxLabelLength = 128
+
+	// labelHashLength is the number of hex digits of the hash suffix that
+	// keeps truncated labels unique
+	labelHashLength = 8
+)
+
+// metricLabelNames are the labels of the exported metrics, which constant
+// labels must not override
+var metricLabelNames = []string{
+	"archived", "check", "commit", "config", "default_branch", "documentation_url", "fork", "host",
+	"level", "organization", "reason", "repository", "source", "status_code", "visibility",
+}
+
+// NamespaceLabel is the label WithNamespaceLabel adds, which constant labels
+// must not override when it is enabled
+const NamespaceLabel = "namespace"
+
+// ParseConstLabels parses a comma-separated list of key=value pairs, e.g.
+// "cluster=prod,region=eu", into constant labels for WithConstLabels. Empty
+// entries are ignored.
+func ParseConstLabels(value string) (prometheus.Labels, error) {
+	labels := prometheus.Labels{}
+	for pair := range strings.SplitSeq(value, ",") {
+		if pair = strings.TrimSpace(pair); pair == "" {
+			continue
+		}
+
+		name, labelValue, ok := strings.Cut(pair, "=")
+		name = strings.TrimSpace(name)
+		switch {
+		case !ok:
+			return nil, fmt.Errorf("invalid label %q: expected key=value", pair)
+		case !model.LegacyValidation.IsValidLabelName(name) || strings.HasPrefix(name, "__"):
+			return nil, fmt.Errorf("invalid label name %q", name)
+		case slices.Contains(metricLabelNames, name):
+			return nil, fmt.Errorf("label name %q is already used by the exported metrics", name)
+		}
+		if _, ok := labels[name]; ok {
+			return nil, fmt.Errorf("duplicate label name %q", name)
+		}
+		labels[name] = strings.TrimSpace(labelValue)
+	}
+	return labels, nil
+}
+
+// configLabels returns the labels of the metrics of a config, with the given
+// name/value pairs added
+func (c *Collector) configLabels(configName string, pairs ...string) prometheus.Labels {
+	labels := prometheus.Labels{"config": configName}
+	if c.namespaceLabel {
+		// Config names are the namespaced names of ConfigMaps and ScorecardTargets
+		namespace, _, ok := strings.Cut(configName, "/")
+		if !ok {
+			namespace = ""
+		}
+		labels[NamespaceLabel] = namespace
+	}
+	return withLabels(labels, pairs...)
+}
+
+// repositoryLabels returns the labels of the metrics of a repository of a
+// config on the VCS instance with the given host
+func (c *Collector) repositoryLabels(configName, host, organization, repository string) prometheus.Labels {
+	return c.configLabels(configName, "host", host, "organization", organization, "repository", repository)
+}
+
+// withLabels returns a copy of labels with the given name/value pairs added
+func withLabels(labels prometheus.Labels, pairs ...string) prometheus.Labels {
+	extended := make(prometheus.Labels, len(labels)+len(pairs)/2)
+	maps.Copy(extended, labels)
+	for i := 0; i+1 < len(pairs); i += 2 {
+		extended[pairs[i]] = pairs[i+1]
+	}
+	return extended
+}
+
+// sanitizeLabel prepares an organization or repository name for use as a
+// label value. Names longer than maxLabelLength runes are truncated and
+// suffixed with a short hash of the full name, so distinct names sharing a
+// long prefix keep distinct labels. With normalize, characters other than
+// ASCII letters, digits, '-' and '_' are replaced with '_'.
+func sanitizeLabel(value string, normalize bool) string {
+	sanitized := value
+	if normalize {
+		sanitized = strings.Map(func(r rune) rune {
+			switch {
+			case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_':
+				return r
+			}
+			return '_'
+		}, sanitized)
+	}
+
+	runes := []rune(sanitized)
+	if len(runes) <= maxLabelLength {
+		return sanitized
+	}
+
+	sum := sha256.Sum256([]byte(value))
+	suffix := hex.EncodeToString(sum[:])[:labelHashLength]
+	return string(runes[:maxLabelLength-labelHashLength-1]) + "-" + suffix
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"maps"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestSanitizeLabel(t *testing.T) {
+	long := strings.Repeat("a", 200)
+
+	tests := []struct {
+		name      string
+		value     string
+		normalize bool
+		expected  string
+	}{
+		{
+			name:     "short name unchanged",
+			value:    "my.repo",
+			expected: "my.repo",
+		},
+		{
+			name:      "normalized",
+			value:     "my.repo-ünïcode_1",
+			normalize: true,
+			expected:  "my_repo-_n_code_1",
+		},
+		{
+			name:     "name at the limit unchanged",
+			value:    long[:maxLabelLength],
+			expected: long[:maxLabelLength],
+		},
+		{
+			name:     "long name truncated with hash suffix",
+			value:    long,
+			expected: long[:maxLabelLength-labelHashLength-1] + "-c2a908d9",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sanitizeLabel(tt.value, tt.normalize); got != tt.expected {
+				t.Errorf("sanitizeLabel() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSanitizeLabel_Truncation(t *testing.T) {
+	prefix := strings.Repeat("ü", 150)
+	a := sanitizeLabel(prefix+"-a", false)
+	b := sanitizeLabel(prefix+"-b", false)
+
+	for _, label := range []string{a, b} {
+		if n := utf8.RuneCountInString(label); n != maxLabelLength {
+			t.Errorf("sanitizeLabel() length = %d runes, want %d", n, maxLabelLength)
+		}
+		if !utf8.ValidString(label) {
+			t.Errorf("sanitizeLabel() = %q, want valid UTF-8", label)
+		}
+	}
+	if a == b {
+		t.Errorf("sanitizeLabel() = %q for distinct names sharing a long prefix, want distinct labels", a)
+	}
+	if sanitizeLabel(prefix+"-a", false) != a {
+		t.Error("sanitizeLabel() is not deterministic")
+	}
+}
+
+func TestRepositoryLabel(t *testing.T) {
+	normalized := NewCollector(WithRegistry(prometheus.NewRegistry()), WithLabelNormalization(true))
+	if a, b := normalized.RepositoryLabel("my.repo"), normalized.RepositoryLabel("my_repo"); a != b {
+		t.Errorf("RepositoryLabel() = %q and %q, want the normalized names to collide", a, b)
+	}
+
+	plain := NewCollector(WithRegistry(prometheus.NewRegistry()))
+	if a, b := plain.RepositoryLabel("my.repo"), plain.RepositoryLabel("my_repo"); a == b {
+		t.Errorf("RepositoryLabel() = %q for distinct names without normalization, want distinct labels", a)
+	}
+}
+
+func TestParseConstLabels(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     string
+		expected  prometheus.Labels
+		expectErr bool
+	}{
+		{name: "empty", value: "", expected: prometheus.Labels{}},
+		{name: "single label", value: "cluster=prod", expected: prometheus.Labels{"cluster": "prod"}},
+		{
+			name:     "several labels with spaces",
+			value:    " cluster = prod , region=eu,",
+			expected: prometheus.Labels{"cluster": "prod", "region": "eu"},
+		},
+		{name: "empty value", value: "cluster=", expected: prometheus.Labels{"cluster": ""}},
+		{name: "missing value", value: "cluster", expectErr: true},
+		{name: "invalid name", value: "my-cluster=prod", expectErr: true},
+		{name: "reserved name", value: "__name__=prod", expectErr: true},
+		{name: "metric label", value: "organization=prod", expectErr: true},
+		{name: "duplicate name", value: "cluster=prod,cluster=dev", expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			labels, err := ParseConstLabels(tt.value)
+			if (err != nil) != tt.expectErr {
+				t.Fatalf("ParseConstLabels() error = %v, expectErr %v", err, tt.expectErr)
+			}
+			if !tt.expectErr && !maps.Equal(labels, tt.expected) {
+				t.Errorf("ParseConstLabels() = %v, want %v", labels, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+)
+
+// OpenMetricsPath is the path of the metrics endpoint serving the OpenMetrics
+// format, see NewOpenMetricsHandler
+const OpenMetricsPath = "/metrics/openmetrics"
+
+// metricUnits are the units declared in the OpenMetrics exposition for every
+// metric whose name ends with one, including those of controller-runtime.
+// Timestamps are left out, as OpenMetrics would require a _seconds suffix
+// their names don't have.
+var metricUnits = []string{"seconds", "ratio", "bytes"}
+
+// metricUnit returns the unit of a metric family out of metricUnits, empty if
+// its name, without the _total suffix of counters, ends with none
+func metricUnit(family *dto.MetricFamily) string {
+	name := family.GetName()
+	if family.GetType() == dto.MetricType_COUNTER {
+		name = strings.TrimSuffix(name, "_total")
+	}
+	for _, unit := range metricUnits {
+		if strings.HasSuffix(name, "_"+unit) {
+			return unit
+		}
+	}
+	return ""
+}
+
+// NewOpenMetricsHandler serves the metrics gathered by gatherer in the
+// OpenMetrics text format, regardless of the Accept header. Unlike the
+// Prometheus text format, it declares the units of the metrics in metricUnits
+// and includes the exemplars of openssf_scorecard_score_updates_total.
+func NewOpenMetricsHandler(gatherer prometheus.Gatherer) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		families, err := gatherer.Gather()
+		if err != nil {
+			http.Error(w, fmt.Sprintf("failed to gather metrics: %v", err), http.StatusInternalServerError)
+			return
+		}
+
+		format := expfmt.NewFormat(expfmt.TypeOpenMetrics)
+		w.Header().Set("Content-Type", string(format))
+		encoder := expfmt.NewEncoder(w, format, expfmt.WithUnit())
+		for _, family := range families {
+			if unit := metricUnit(family); unit != "" {
+				family.Unit = &unit
+			}
+			if err := encoder.Encode(family); err != nil {
+				return
+			}
+		}
+		if closer, ok := encoder.(expfmt.Closer); ok {
+			_ = closer.Close()
+		}
+	})
+}
@@ -0,0 +1,189 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+
+	"github.com/giantswarm/openssf-scorecard-exporter/internal/scorecard"
+	"github.com/giantswarm/openssf-scorecard-exporter/internal/vcs"
+)
+
+func TestOpenMetricsHandler(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	c := NewCollector(WithRegistry(registry))
+	c.UpdateMetrics("default/config", "github.com", "org", "repo", &scorecard.ScorecardData{
+		Score:     7.5,
+		Commit:    "abc123",
+		Timestamp: time.Now().Add(-time.Hour),
+		Checks:    []scorecard.Check{{Name: "Code-Review", Score: 8, Status: "Pass"}},
+	})
+	c.UpdateCoverageRatio("default/config", "org", 1, 2)
+	c.ObserveScorecardAPIRequest(http.StatusOK, time.Second)
+
+	recorder := httptest.NewRecorder()
+	NewOpenMetricsHandler(registry).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, OpenMetricsPath, nil))
+
+	if contentType := recorder.Header().Get("Content-Type"); !strings.HasPrefix(contentType, "application/openmetrics-text") {
+		t.Errorf("Content-Type = %q, want application/openmetrics-text", contentType)
+	}
+	body := recorder.Body.String()
+	for _, line := range []string{
+		"# TYPE openssf_scorecard_data_age_seconds gauge",
+		"# UNIT openssf_scorecard_data_age_seconds seconds",
+		"# UNIT openssf_scorecard_coverage_ratio ratio",
+		"# TYPE openssf_scorecard_api_request_duration_seconds histogram",
+		"# UNIT openssf_scorecard_api_request_duration_seconds seconds",
+		"# TYPE openssf_scorecard_score_updates counter",
+		"# TYPE openssf_scorecard_commit_info gauge",
+		`# {commit="abc123"} 1`,
+		"# EOF",
+	} {
+		if !strings.Contains(body, line) {
+			t.Errorf("OpenMetrics output does not contain %q:\n%s", line, body)
+		}
+	}
+	if strings.Contains(body, "# UNIT openssf_scorecard_last_update_timestamp") {
+		t.Error("OpenMetrics output declares a unit for a metric whose name does not end with it")
+	}
+}
+
+func TestOpenMetricsHandler_Units(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	c := NewCollector(WithRegistry(registry))
+	c.UpdateMetrics("default/config", "github.com", "org", "repo", &scorecard.ScorecardData{
+		Score:     7.5,
+		Timestamp: time.Now().Add(-time.Hour),
+	})
+	c.UpdateRepositoryCounts("default/config", "org", 2, 1, 1)
+	c.UpdateCoverageRatio("default/config", "org", 1, 2)
+	c.ConfigSucceeded("default/config")
+	c.ObserveScorecardAPIRequest(http.StatusOK, time.Second)
+
+	// Metrics registered by others, such as controller-runtime
+	cpu := prometheus.NewCounter(prometheus.CounterOpts{Name: "process_cpu_seconds_total", Help: "CPU time"})
+	cpu.Add(1)
+	memory := prometheus.NewGauge(prometheus.GaugeOpts{Name: "process_resident_memory_bytes", Help: "Memory"})
+	registry.MustRegister(cpu, memory)
+
+	recorder := httptest.NewRecorder()
+	NewOpenMetricsHandler(registry).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, OpenMetricsPath, nil))
+	body := recorder.Body.String()
+
+	tests := []struct {
+		name     string
+		metadata string
+		unit     string
+	}{
+		{name: "openssf_scorecard_data_age_seconds", metadata: "gauge", unit: "seconds"},
+		{name: "openssf_scorecard_coverage_ratio", metadata: "gauge", unit: "ratio"},
+		{name: "openssf_scorecard_api_request_duration_seconds", metadata: "histogram", unit: "seconds"},
+		{name: "process_cpu_seconds", metadata: "counter", unit: "seconds"},
+		{name: "process_resident_memory_bytes", metadata: "gauge", unit: "bytes"},
+		{name: "openssf_scorecard_repositories", metadata: "gauge"},
+		{name: "openssf_scorecard_last_update_timestamp", metadata: "gauge"},
+		{name: "openssf_scorecard_config_last_success_timestamp", metadata: "gauge"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var metadata []string
+			for _, line := range strings.Split(body, "\n") {
+				fields := strings.Fields(line)
+				if len(fields) == 4 && (fields[1] == "TYPE" || fields[1] == "UNIT") && fields[2] == tt.name {
+					metadata = append(metadata, line)
+				}
+			}
+
+			expected := []string{"# TYPE " + tt.name + " " + tt.metadata}
+			if tt.unit != "" {
+				expected = append(expected, "# UNIT "+tt.name+" "+tt.unit)
+			}
+			if !slices.Equal(metadata, expected) {
+				t.Errorf("metadata of %s = %q, want %q", tt.name, metadata, expected)
+			}
+		})
+	}
+}
+
+func TestMetricUnit(t *testing.T) {
+	tests := []struct {
+		name       string
+		metricType dto.MetricType
+		expected   string
+	}{
+		{name: "openssf_scorecard_data_age_seconds", metricType: dto.MetricType_GAUGE, expected: "seconds"},
+		{name: "openssf_scorecard_coverage_ratio", metricType: dto.MetricType_GAUGE, expected: "ratio"},
+		{name: "process_cpu_seconds_total", metricType: dto.MetricType_COUNTER, expected: "seconds"},
+		{name: "openssf_scorecard_seconds_total", metricType: dto.MetricType_GAUGE},
+		{name: "openssf_scorecard_last_update_timestamp", metricType: dto.MetricType_GAUGE},
+		{name: "openssf_scorecard_score_updates_total", metricType: dto.MetricType_COUNTER},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			family := &dto.MetricFamily{Name: &tt.name, Type: &tt.metricType}
+			if got := metricUnit(family); got != tt.expected {
+				t.Errorf("metricUnit() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestInfoMetrics(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	c := NewCollector(WithRegistry(registry))
+	c.UpdateMetrics("default/config", "github.com", "org", "repo", &scorecard.ScorecardData{
+		Score:  7.5,
+		Commit: "abc123",
+		Checks: []scorecard.Check{
+			{Name: "Code-Review", Score: 2, Status: "Fail", Reason: "not reviewed", DocumentationURL: "https://example.com"},
+		},
+	})
+	c.UpdateRepositoryInfo("default/config", "github.com", "org", "repo", &vcs.Repository{Name: "repo", DefaultBranch: "main"})
+
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("failed to gather metrics: %v", err)
+	}
+	var infos int
+	for _, family := range families {
+		if !strings.HasSuffix(family.GetName(), "_info") {
+			continue
+		}
+		infos++
+		if family.GetType() != dto.MetricType_GAUGE {
+			t.Errorf("%s is a %v, want a gauge", family.GetName(), family.GetType())
+		}
+		for _, metric := range family.GetMetric() {
+			if value := metric.GetGauge().GetValue(); value != 1 {
+				t.Errorf("%s = %v, want 1", family.GetName(), value)
+			}
+		}
+	}
+	if infos != 5 {
+		t.Errorf("info metrics = %d, want 5", infos)
+	}
+}
@@ -0,0 +1,54 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/push"
+)
+
+// DefaultPushTimeout bounds the time spent pushing metrics to a Pushgateway
+const DefaultPushTimeout = 10 * time.Second
+
+// Pusher pushes the metrics of a Collector to a Prometheus Pushgateway, for
+// deployments that may not be scraped before they terminate
+type Pusher struct {
+	pusher *push.Pusher
+}
+
+// NewPusher creates a Pusher sending the metrics of the collector to the
+// Pushgateway at url, grouped under the given job. Each push replaces the
+// metrics previously pushed for the job.
+func NewPusher(collector *Collector, url, job string) *Pusher {
+	registry := prometheus.NewRegistry()
+	prometheus.WrapRegistererWith(collector.constLabels, registry).MustRegister(collector.collectors...)
+
+	return &Pusher{
+		pusher: push.New(url, job).
+			Gatherer(registry).
+			Client(&http.Client{Timeout: DefaultPushTimeout}),
+	}
+}
+
+// Push sends the current metrics to the Pushgateway
+func (p *Pusher) Push(ctx context.Context) error {
+	return p.pusher.PushContext(ctx)
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+
+	"github.com/giantswarm/openssf-scorecard-exporter/internal/scorecard"
+)
+
+// stubPushgateway records the metric families pushed to it
+type stubPushgateway struct {
+	mu       sync.Mutex
+	method   string
+	path     string
+	families map[string]*dto.MetricFamily
+}
+
+func (s *stubPushgateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.method = r.Method
+	s.path = r.URL.Path
+	s.families = make(map[string]*dto.MetricFamily)
+	decoder := expfmt.NewDecoder(r.Body, expfmt.ResponseFormat(r.Header))
+	for {
+		var family dto.MetricFamily
+		if err := decoder.Decode(&family); err != nil {
+			if !errors.Is(err, io.EOF) {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			break
+		}
+		s.families[family.GetName()] = &family
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func TestPusher_Push(t *testing.T) {
+	gateway := &stubPushgateway{}
+	server := httptest.NewServer(gateway)
+	defer server.Close()
+
+	c := NewCollector(WithRegistry(prometheus.NewRegistry()), WithConstLabels(prometheus.Labels{"cluster": "prod"}))
+	c.UpdateMetrics("default/config", "github.com", "org", "repo", &scorecard.ScorecardData{
+		Score:  7.5,
+		Checks: []scorecard.Check{{Name: "Code-Review", Score: 8}},
+	})
+
+	if err := NewPusher(c, server.URL, "scorecard").Push(context.Background()); err != nil {
+		t.Fatalf("Push() error = %v", err)
+	}
+
+	gateway.mu.Lock()
+	defer gateway.mu.Unlock()
+
+	if gateway.method != http.MethodPut {
+		t.Errorf("method = %s, want %s", gateway.method, http.MethodPut)
+	}
+	if gateway.path != "/metrics/job/scorecard" {
+		t.Errorf("path = %s, want /metrics/job/scorecard", gateway.path)
+	}
+
+	tests := []struct {
+		name  string
+		value float64
+	}{
+		{name: "openssf_scorecard_overall_score", value: 7.5},
+		{name: "openssf_scorecard_check_score", value: 8},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			family, ok := gateway.families[tt.name]
+			if !ok {
+				t.Fatalf("%s was not pushed", tt.name)
+			}
+			if len(family.GetMetric()) != 1 {
+				t.Fatalf("%s series = %d, want 1", tt.name, len(family.GetMetric()))
+			}
+			metric := family.GetMetric()[0]
+			if got := metric.GetGauge().GetValue(); got != tt.value {
+				t.Errorf("%s = %v, want %v", tt.name, got, tt.value)
+			}
+			labels := make(map[string]string)
+			for _, label := range metric.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+			if labels["config"] != "default/config" || labels["repository"] != "repo" || labels["cluster"] != "prod" {
+				t.Errorf("%s labels = %v, want config default/config, repository repo and cluster prod", tt.name, labels)
+			}
+		})
+	}
+}
+
+func TestPusher_PushError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	if err := NewPusher(newTestCollector(), server.URL, "scorecard").Push(context.Background()); err == nil {
+		t.Error("Push() error = nil, want error for a failing Pushgateway")
+	}
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/giantswarm/openssf-scorecard-exporter/internal/scorecard"
+)
+
+// reportOutput is the JSON representation of the scorecard data of a repository
+type reportOutput struct {
+	Host         string        `json:"host"`
+	Organization string        `json:"organization"`
+	Repository   string        `json:"repository"`
+	Commit       string        `json:"commit,omitempty"`
+	Date         time.Time     `json:"date"`
+	Score        float64       `json:"score"`
+	Source       string        `json:"source,omitempty"`
+	Checks       []checkOutput `json:"checks"`
+}
+
+// checkOutput is the JSON representation of a check
+type checkOutput struct {
+	Name   string `json:"name"`
+	Score  int    `json:"score"`
+	Status string `json:"status"`
+	Reason string `json:"reason,omitempty"`
+
+	DocumentationURL string `json:"documentation_url,omitempty"`
+
+	Details []detailOutput `json:"details,omitempty"`
+}
+
+// detailOutput is the JSON representation of a check detail
+type detailOutput struct {
+	Level   string `json:"level,omitempty"`
+	Message string `json:"message"`
+}
+
+// NewHandler returns a handler serving the scorecard data of the store as
+// JSON at /scorecards/{host}/{organization}/{repository}, and 404 for
+// repositories without scorecard data. The organization may span several
+// path segments, such as GitLab subgroups.
+func NewHandler(store *Store) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /scorecards/{path...}", func(w http.ResponseWriter, r *http.Request) {
+		path := r.PathValue("path")
+		host, organization, repository, ok := splitPath(path)
+		var data *scorecard.ScorecardData
+		if ok {
+			data, ok = store.Get(host, organization, repository)
+		}
+		if !ok {
+			http.Error(w, "no scorecard data for "+path, http.StatusNotFound)
+			return
+		}
+
+		out := reportOutput{
+			Host:         host,
+			Organization: organization,
+			Repository:   repository,
+			Commit:       data.Commit,
+			Date:         data.Timestamp,
+			Score:        data.Score,
+			Source:       data.Source,
+			Checks:       make([]checkOutput, 0, len(data.Checks)),
+		}
+		for _, check := range data.Checks {
+			checkOut := checkOutput{
+				Name:   check.Name,
+				Score:  check.Score,
+				Status: check.Status,
+				Reason: check.Reason,
+
+				DocumentationURL: check.DocumentationURL,
+			}
+			for _, detail := range check.Details {
+				checkOut.Details = append(checkOut.Details, detailOutput(detail))
+			}
+			out.Checks = append(out.Checks, checkOut)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(out)
+	})
+	return mux
+}
+
+// splitPath splits the path of a repository into its host, organization and
+// repository name, and reports whether it has all three
+func splitPath(path string) (host, organization, repository string, ok bool) {
+	host, rest, _ := strings.Cut(path, "/")
+	i := strings.LastIndex(rest, "/")
+	if host == "" || i <= 0 || i == len(rest)-1 {
+		return "", "", "", false
+	}
+	return host, rest[:i], rest[i+1:], true
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/giantswarm/openssf-scorecard-exporter/internal/scorecard"
+)
+
+func TestHandler(t *testing.T) {
+	store := NewStore()
+	store.Set("default/scorecard-config", "gitlab.com", "group/subgroup", "repo", &scorecard.ScorecardData{
+		Score:      5,
+		Repository: "gitlab.com/group/subgroup/repo",
+		Timestamp:  time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC),
+		Source:     scorecard.DataSourceAPI,
+	})
+	store.Set("default/scorecard-config", "github.com", "org", "repo", &scorecard.ScorecardData{
+		Score:      7.5,
+		Repository: "github.com/org/repo",
+		Commit:     "abc123",
+		Timestamp:  time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC),
+		Source:     scorecard.DataSourceAPI,
+		Checks: []scorecard.Check{
+			{Name: "Code-Review", Score: 8, Status: "Pass", Reason: "reviewed"},
+			{Name: "Fuzzing", Score: 0, Status: "Fail", Details: []scorecard.CheckDetail{{Level: "Warn", Message: "not fuzzed"}}},
+		},
+	})
+	handler := NewHandler(store)
+
+	tests := []struct {
+		name           string
+		path           string
+		expectedStatus int
+		expected       *reportOutput
+	}{
+		{
+			name:           "stored repository",
+			path:           "/scorecards/github.com/org/repo",
+			expectedStatus: http.StatusOK,
+			expected: &reportOutput{
+				Host:         "github.com",
+				Organization: "org",
+				Repository:   "repo",
+				Commit:       "abc123",
+				Date:         time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC),
+				Score:        7.5,
+				Source:       scorecard.DataSourceAPI,
+				Checks: []checkOutput{
+					{Name: "Code-Review", Score: 8, Status: "Pass", Reason: "reviewed"},
+					{Name: "Fuzzing", Score: 0, Status: "Fail", Details: []detailOutput{{Level: "Warn", Message: "not fuzzed"}}},
+				},
+			},
+		},
+		{
+			name:           "organization with subgroups",
+			path:           "/scorecards/gitlab.com/group/subgroup/repo",
+			expectedStatus: http.StatusOK,
+			expected: &reportOutput{
+				Host:         "gitlab.com",
+				Organization: "group/subgroup",
+				Repository:   "repo",
+				Date:         time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC),
+				Score:        5,
+				Source:       scorecard.DataSourceAPI,
+				Checks:       []checkOutput{},
+			},
+		},
+		{
+			name:           "unknown repository",
+			path:           "/scorecards/github.com/org/unknown",
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "unknown organization",
+			path:           "/scorecards/github.com/other/repo",
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "unknown host",
+			path:           "/scorecards/github.example.com/org/repo",
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "without host",
+			path:           "/scorecards/org/repo",
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			if recorder.Code != tt.expectedStatus {
+				t.Fatalf("GET %s status = %d, want %d", tt.path, recorder.Code, tt.expectedStatus)
+			}
+			if tt.expected == nil {
+				return
+			}
+			if contentType := recorder.Header().Get("Content-Type"); contentType != "application/json" {
+				t.Errorf("Content-Type = %q, want application/json", contentType)
+			}
+			var out reportOutput
+			if err := json.NewDecoder(recorder.Body).Decode(&out); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if !reflect.DeepEqual(out, *tt.expected) {
+				t.Errorf("GET %s = %+v, want %+v", tt.path, out, *tt.expected)
+			}
+		})
+	}
+}
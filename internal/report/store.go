@@ -0,0 +1,104 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"sync"
+
+	"github.com/giantswarm/openssf-scorecard-exporter/internal/scorecard"
+)
+
+// Store keeps the latest scorecard data of each repository, keyed by VCS host,
+// organization and repository name, so that it can be served as JSON. When
+// several configs export the same repository, the last update wins. A nil
+// Store holds no data and ignores updates. It is safe for concurrent use.
+type Store struct {
+	mu sync.RWMutex
+
+	// reports holds the scorecard data of each repository
+	reports map[string]entry
+}
+
+// entry is the scorecard data of a repository along with the config that
+// exported it
+type entry struct {
+	config string
+	data   *scorecard.ScorecardData
+}
+
+// NewStore creates an empty Store
+func NewStore() *Store {
+	return &Store{reports: make(map[string]entry)}
+}
+
+// key returns the key of a repository, the path it is served at, e.g.
+// github.com/org/repo
+func key(host, organization, repository string) string {
+	return host + "/" + organization + "/" + repository
+}
+
+// Set records the scorecard data of a repository exported by a config
+func (s *Store) Set(configName, host, organization, repository string, data *scorecard.ScorecardData) {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.reports[key(host, organization, repository)] = entry{config: configName, data: data}
+}
+
+// Get returns the scorecard data of a repository, if any
+func (s *Store) Get(host, organization, repository string) (*scorecard.ScorecardData, bool) {
+	if s == nil {
+		return nil, false
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	e, ok := s.reports[key(host, organization, repository)]
+	return e.data, ok
+}
+
+// Remove drops the scorecard data of a repository exported by a config
+func (s *Store) Remove(configName, host, organization, repository string) {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if e, ok := s.reports[key(host, organization, repository)]; ok && e.config == configName {
+		delete(s.reports, key(host, organization, repository))
+	}
+}
+
+// RemoveConfig drops the scorecard data of all repositories exported by a config
+func (s *Store) RemoveConfig(configName string) {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for k, e := range s.reports {
+		if e.config == configName {
+			delete(s.reports, k)
+		}
+	}
+}
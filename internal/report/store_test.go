@@ -0,0 +1,65 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"testing"
+
+	"github.com/giantswarm/openssf-scorecard-exporter/internal/scorecard"
+)
+
+func TestStore(t *testing.T) {
+	store := NewStore()
+	store.Set("default/a", "github.com", "org", "repo", &scorecard.ScorecardData{Score: 5})
+	store.Set("default/a", "github.com", "org", "other", &scorecard.ScorecardData{Score: 6})
+	store.Set("default/b", "github.com", "org", "shared", &scorecard.ScorecardData{Score: 7})
+	store.Set("default/a", "github.com", "org", "shared", &scorecard.ScorecardData{Score: 8})
+	store.Set("default/c", "github.example.com", "org", "shared", &scorecard.ScorecardData{Score: 9})
+
+	// The last update of a repository wins
+	if data, ok := store.Get("github.com", "org", "shared"); !ok || data.Score != 8 {
+		t.Errorf("Get(github.com, org, shared) = %v, %v, want the score of the last update", data, ok)
+	}
+
+	// Repositories of the same name on other hosts are kept apart
+	if data, ok := store.Get("github.example.com", "org", "shared"); !ok || data.Score != 9 {
+		t.Errorf("Get(github.example.com, org, shared) = %v, %v, want the score of its own host", data, ok)
+	}
+
+	// Configs only remove the repositories they exported
+	store.Remove("default/b", "github.com", "org", "shared")
+	if _, ok := store.Get("github.com", "org", "shared"); !ok {
+		t.Error("Remove() dropped a repository last exported by another config")
+	}
+	store.Remove("default/a", "github.com", "org", "repo")
+	if _, ok := store.Get("github.com", "org", "repo"); ok {
+		t.Error("Remove() kept the repository")
+	}
+
+	store.RemoveConfig("default/a")
+	store.RemoveConfig("default/c")
+	if len(store.reports) != 0 {
+		t.Errorf("reports = %v, want none after RemoveConfig", store.reports)
+	}
+
+	// A nil store holds nothing
+	var disabled *Store
+	disabled.Set("default/a", "github.com", "org", "repo", &scorecard.ScorecardData{})
+	if _, ok := disabled.Get("github.com", "org", "repo"); ok {
+		t.Error("nil Store returned scorecard data")
+	}
+}
@@ -0,0 +1,231 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scorecard
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// batchProject stands for the project in the path template of the route of
+// scorecard APIs and mirrors that return the reports of several projects in
+// one request, i.e. /projects/batch by default. It accepts a POST of a
+// batchRequest and answers with the reports found, in the format of the
+// single project route, leaving out projects without a report.
+const batchProject = "batch"
+
+// BatchSource is a Source that can fetch the scorecard data of several
+// repositories at once
+type BatchSource interface {
+	Source
+
+	// GetScorecardDataBatch returns the scorecard data of the repositories
+	// with scorecard data, keyed by vcsPath. Repositories without scorecard
+	// data are left out.
+	GetScorecardDataBatch(ctx context.Context, vcsPaths []string, token string, opts ...FetchOption) (map[string]*ScorecardData, error)
+}
+
+// GetScorecardDataBatch returns the scorecard data of several repositories,
+// keyed by vcsPath, in as few requests as the source allows. Sources that do
+// not implement BatchSource are queried one repository at a time.
+// Repositories without scorecard data are left out.
+func GetScorecardDataBatch(
+	ctx context.Context, source Source, vcsPaths []string, token string, opts ...FetchOption,
+) (map[string]*ScorecardData, error) {
+	if batch, ok := source.(BatchSource); ok {
+		return batch.GetScorecardDataBatch(ctx, vcsPaths, token, opts...)
+	}
+	return fetchEach(ctx, source, vcsPaths, token, opts...)
+}
+
+// fetchEach fetches the scorecard data of repositories one at a time, leaving
+// out those without scorecard data
+func fetchEach(
+	ctx context.Context, source Source, vcsPaths []string, token string, opts ...FetchOption,
+) (map[string]*ScorecardData, error) {
+	results := make(map[string]*ScorecardData, len(vcsPaths))
+	for _, vcsPath := range vcsPaths {
+		data, err := source.GetScorecardData(ctx, vcsPath, token, opts...)
+		switch {
+		case errors.Is(err, ErrNotFound):
+		case err != nil:
+			return nil, err
+		default:
+			results[vcsPath] = data
+		}
+	}
+	return results, nil
+}
+
+// batchRequest is the body of a request to the batch route
+type batchRequest struct {
+	Projects []string `json:"projects"`
+	Commit   string   `json:"commit,omitempty"`
+}
+
+// errBatchUnsupported indicates that the API does not serve the batch route
+var errBatchUnsupported = errors.New("batch requests are not supported")
+
+// GetScorecardDataBatch implements BatchSource. Cached results are reused and
+// the remaining repositories are fetched in a single request. APIs without the
+// batch route are queried one repository at a time instead, which is
+// remembered for later batches.
+func (c *Client) GetScorecardDataBatch(
+	ctx context.Context, vcsPaths []string, token string, opts ...FetchOption,
+) (map[string]*ScorecardData, error) {
+	o := newFetchOptions(opts)
+	results := make(map[string]*ScorecardData, len(vcsPaths))
+	var pending []string
+	for _, vcsPath := range vcsPaths {
+		if c.cache != nil && !o.refresh {
+			if data, err, ok := c.cache.get(o.key(vcsPath)); ok {
+				if err == nil {
+					results[vcsPath] = data
+				}
+				continue
+			}
+		}
+		if c.fresh != nil && !o.refresh {
+			if entry, fresh, _ := c.fresh.get(o.key(vcsPath)); fresh {
+				results[vcsPath] = entry.data
+				continue
+			}
+		}
+		pending = append(pending, vcsPath)
+	}
+	if len(pending) == 0 {
+		return results, nil
+	}
+
+	if c.batchUnsupported.Load() {
+		return c.fetchRest(ctx, results, pending, token, opts)
+	}
+
+	fetched, err := c.fetchBatch(ctx, pending, token, o)
+	if errors.Is(err, errBatchUnsupported) {
+		c.batchUnsupported.Store(true)
+		return c.fetchRest(ctx, results, pending, token, opts)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, vcsPath := range pending {
+		data, ok := fetched[vcsPath]
+		if c.cache != nil {
+			if ok {
+				c.cache.setData(o.key(vcsPath), data)
+			} else {
+				c.cache.setUnavailable(o.key(vcsPath), fmt.Errorf("%w for %s", ErrNotFound, vcsPath))
+			}
+		}
+		if ok {
+			results[vcsPath] = data
+		}
+	}
+	return results, nil
+}
+
+// fetchRest adds the scorecard data of the pending repositories, fetched one
+// at a time, to results
+func (c *Client) fetchRest(
+	ctx context.Context, results map[string]*ScorecardData, pending []string, token string, opts []FetchOption,
+) (map[string]*ScorecardData, error) {
+	rest, err := fetchEach(ctx, c, pending, token, opts...)
+	if err != nil {
+		return nil, err
+	}
+	for vcsPath, data := range rest {
+		results[vcsPath] = data
+	}
+	return results, nil
+}
+
+// fetchBatch fetches the scorecard data of several repositories from the
+// batch route, keyed by the requested VCS path. The API may spell the
+// repository of a report differently, so reports are matched to the requested
+// paths case-insensitively, and reports of repositories not requested are
+// dropped.
+func (c *Client) fetchBatch(
+	ctx context.Context, vcsPaths []string, token string, o fetchOptions,
+) (map[string]*ScorecardData, error) {
+	endpoint := c.apiEndpoint
+	if o.endpoint != "" {
+		endpoint = o.endpoint
+	}
+
+	body, err := json.Marshal(batchRequest{Projects: vcsPaths, Commit: o.commit})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode batch request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint+c.projectPath(batchProject), bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.send(req, endpoint, token)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := rateLimitError(resp); err != nil {
+		return nil, err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		return nil, errBatchUnsupported
+	default:
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var apiResponses []APIResponse
+	if err := json.NewDecoder(resp.Body).Decode(&apiResponses); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	requested := make(map[string]string, len(vcsPaths))
+	for _, vcsPath := range vcsPaths {
+		requested[strings.ToLower(vcsPath)] = vcsPath
+	}
+
+	results := make(map[string]*ScorecardData, len(apiResponses))
+	for i := range apiResponses {
+		data := convertAPIResponse(&apiResponses[i], c.details)
+		vcsPath, ok := requested[strings.ToLower(data.Repository)]
+		if !ok {
+			continue
+		}
+		data.Source = EndpointDataSource(endpoint)
+		results[vcsPath] = data
+		if c.fresh != nil {
+			_, dated := reportDate(&apiResponses[i])
+			c.fresh.set(o.key(vcsPath), freshEntry{data: data, dated: dated})
+		}
+	}
+	return results, nil
+}
@@ -0,0 +1,250 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scorecard
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newBatchServer starts a scorecard API stub serving the batch route, with a
+// report for github.com/org/repo only, and records the requested batches
+func newBatchServer(t *testing.T) (*httptest.Server, *[]batchRequest) {
+	t.Helper()
+
+	var batches []batchRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/projects/batch" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			http.NotFound(w, r)
+			return
+		}
+		var batch batchRequest
+		if err := json.NewDecoder(r.Body).Decode(&batch); err != nil {
+			t.Errorf("failed to decode batch request: %v", err)
+		}
+		batches = append(batches, batch)
+
+		var body []json.RawMessage
+		if slices.Contains(batch.Projects, "github.com/org/repo") {
+			body = append(body, json.RawMessage(testResponse))
+		}
+		_ = json.NewEncoder(w).Encode(body)
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &batches
+}
+
+func TestGetScorecardDataBatch(t *testing.T) {
+	server, batches := newBatchServer(t)
+	client := NewClient(WithAPIEndpoint(server.URL))
+
+	vcsPaths := []string{"github.com/org/repo", "github.com/org/missing"}
+	results, err := client.GetScorecardDataBatch(context.Background(), vcsPaths, "", AtCommit("abc123"))
+	if err != nil {
+		t.Fatalf("GetScorecardDataBatch() unexpected error: %v", err)
+	}
+
+	if keys := slices.Collect(maps.Keys(results)); !slices.Equal(keys, []string{"github.com/org/repo"}) {
+		t.Errorf("GetScorecardDataBatch() repositories = %v, want only github.com/org/repo", keys)
+	}
+	if data := results["github.com/org/repo"]; data == nil || data.Score != 7.5 {
+		t.Errorf("GetScorecardDataBatch() data = %+v, want a score of 7.5", data)
+	}
+	expected := []batchRequest{{Projects: vcsPaths, Commit: "abc123"}}
+	if len(*batches) != 1 || !slices.Equal((*batches)[0].Projects, expected[0].Projects) || (*batches)[0].Commit != "abc123" {
+		t.Errorf("batch requests = %+v, want %+v", *batches, expected)
+	}
+}
+
+func TestGetScorecardDataBatch_RepositoryCase(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The API spells the repository in lower case and adds one that was
+		// not requested
+		other := strings.Replace(testResponse, "github.com/org/repo", "github.com/org/other", 1)
+		_ = json.NewEncoder(w).Encode([]json.RawMessage{json.RawMessage(testResponse), json.RawMessage(other)})
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewClient(WithAPIEndpoint(server.URL), WithFreshnessWindow(24*time.Hour))
+	results, err := client.GetScorecardDataBatch(context.Background(), []string{"github.com/Org/Repo"}, "")
+	if err != nil {
+		t.Fatalf("GetScorecardDataBatch() unexpected error: %v", err)
+	}
+
+	if keys := slices.Collect(maps.Keys(results)); !slices.Equal(keys, []string{"github.com/Org/Repo"}) {
+		t.Errorf("GetScorecardDataBatch() repositories = %v, want only the requested github.com/Org/Repo", keys)
+	}
+	if _, _, known := client.fresh.get(newFetchOptions(nil).key("github.com/Org/Repo")); !known {
+		t.Error("the report is not remembered under the requested path")
+	}
+}
+
+func TestGetScorecardDataBatch_PathTemplate(t *testing.T) {
+	var path string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		_, _ = w.Write([]byte("[" + testResponse + "]"))
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewClient(WithAPIEndpoint(server.URL), WithPathTemplate("/v2/projects/%s"))
+	results, err := client.GetScorecardDataBatch(context.Background(), []string{"github.com/org/repo"}, "")
+	if err != nil {
+		t.Fatalf("GetScorecardDataBatch() unexpected error: %v", err)
+	}
+	if path != "/v2/projects/batch" {
+		t.Errorf("batch request path = %q, want /v2/projects/batch", path)
+	}
+	if len(results) != 1 {
+		t.Errorf("GetScorecardDataBatch() = %v, want one result", results)
+	}
+}
+
+func TestGetScorecardDataBatch_Cache(t *testing.T) {
+	server, batches := newBatchServer(t)
+	client := NewClient(WithAPIEndpoint(server.URL), WithCache(time.Hour, time.Hour))
+
+	vcsPaths := []string{"github.com/org/repo", "github.com/org/missing"}
+	for range 2 {
+		results, err := client.GetScorecardDataBatch(context.Background(), vcsPaths, "")
+		if err != nil {
+			t.Fatalf("GetScorecardDataBatch() unexpected error: %v", err)
+		}
+		if len(results) != 1 {
+			t.Errorf("GetScorecardDataBatch() = %v, want one result", results)
+		}
+	}
+
+	// Both the report and the missing report are served from the cache
+	if len(*batches) != 1 {
+		t.Errorf("batch requests = %d, want 1", len(*batches))
+	}
+	if _, err := client.GetScorecardData(context.Background(), "github.com/org/repo", ""); err != nil {
+		t.Errorf("GetScorecardData() unexpected error: %v", err)
+	}
+	if len(*batches) != 1 {
+		t.Errorf("batch requests = %d, want 1 after a cached single request", len(*batches))
+	}
+}
+
+func TestGetScorecardDataBatch_Freshness(t *testing.T) {
+	server, batches := newBatchServer(t)
+	client := NewClient(WithAPIEndpoint(server.URL), WithFreshnessWindow(24*time.Hour))
+	now := time.Date(2025, 1, 1, 1, 0, 0, 0, time.UTC)
+	client.fresh.now = func() time.Time { return now }
+
+	vcsPaths := []string{"github.com/org/repo", "github.com/org/missing"}
+	for range 2 {
+		results, err := client.GetScorecardDataBatch(context.Background(), vcsPaths, "")
+		if err != nil {
+			t.Fatalf("GetScorecardDataBatch() unexpected error: %v", err)
+		}
+		if len(results) != 1 {
+			t.Errorf("GetScorecardDataBatch() = %v, want one result", results)
+		}
+	}
+
+	// The fresh report is skipped, only the missing one is asked for again
+	if len(*batches) != 2 || !slices.Equal((*batches)[1].Projects, []string{"github.com/org/missing"}) {
+		t.Errorf("batch requests = %+v, want a second one for github.com/org/missing only", *batches)
+	}
+}
+
+func TestGetScorecardDataBatch_Refresh(t *testing.T) {
+	server, batches := newBatchServer(t)
+	client := NewClient(WithAPIEndpoint(server.URL), WithCache(time.Hour, time.Hour))
+
+	vcsPaths := []string{"github.com/org/repo", "github.com/org/missing"}
+	for _, opts := range [][]FetchOption{nil, {Refresh()}} {
+		if _, err := client.GetScorecardDataBatch(context.Background(), vcsPaths, "", opts...); err != nil {
+			t.Fatalf("GetScorecardDataBatch() unexpected error: %v", err)
+		}
+	}
+
+	// The cached reports are asked for again
+	if len(*batches) != 2 || !slices.Equal((*batches)[1].Projects, vcsPaths) {
+		t.Errorf("batch requests = %+v, want a second one for both repositories", *batches)
+	}
+}
+
+func TestGetScorecardDataBatch_Fallback(t *testing.T) {
+	// The test server serves single projects only and 404s the batch route
+	server, requests := newTestServer(t)
+	client := NewClient(WithAPIEndpoint(server.URL))
+
+	vcsPaths := []string{"github.com/org/repo", "github.com/org/missing"}
+	for range 2 {
+		results, err := client.GetScorecardDataBatch(context.Background(), vcsPaths, "")
+		if err != nil {
+			t.Fatalf("GetScorecardDataBatch() unexpected error: %v", err)
+		}
+		if keys := slices.Collect(maps.Keys(results)); !slices.Equal(keys, []string{"github.com/org/repo"}) {
+			t.Errorf("GetScorecardDataBatch() repositories = %v, want only github.com/org/repo", keys)
+		}
+	}
+
+	// The batch route is tried once, then each repository is fetched alone
+	if got := requests.Load(); got != 5 {
+		t.Errorf("API requests = %d, want 5", got)
+	}
+}
+
+// countingSource is a Source without batch support that counts requests
+type countingSource struct {
+	requests atomic.Int32
+}
+
+func (s *countingSource) GetScorecardData(_ context.Context, vcsPath, _ string, _ ...FetchOption) (*ScorecardData, error) {
+	s.requests.Add(1)
+	if vcsPath != "github.com/org/repo" {
+		return nil, ErrNotFound
+	}
+	return &ScorecardData{Repository: vcsPath, Score: 5}, nil
+}
+
+func TestGetScorecardDataBatch_Source(t *testing.T) {
+	for name, wrap := range map[string]func(Source) Source{
+		"plain source":        func(s Source) Source { return s },
+		"deduplicated source": func(s Source) Source { return Deduplicate(s, time.Minute) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			source := &countingSource{}
+			vcsPaths := []string{"github.com/org/repo", "github.com/org/missing"}
+
+			results, err := GetScorecardDataBatch(context.Background(), wrap(source), vcsPaths, "")
+			if err != nil {
+				t.Fatalf("GetScorecardDataBatch() unexpected error: %v", err)
+			}
+			if len(results) != 1 || results["github.com/org/repo"] == nil {
+				t.Errorf("GetScorecardDataBatch() = %v, want github.com/org/repo only", results)
+			}
+			if got := source.requests.Load(); got != 2 {
+				t.Errorf("requests = %d, want 2", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scorecard
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultCircuitBreakerThreshold is the default number of consecutive
+	// failures after which requests to the scorecard API are suspended
+	DefaultCircuitBreakerThreshold = 5
+
+	// DefaultCircuitBreakerCooldown is the default time requests to the
+	// scorecard API are suspended for once the circuit breaker opens
+	DefaultCircuitBreakerCooldown = time.Minute
+)
+
+// ErrCircuitOpen indicates that a request was not sent because the scorecard
+// API failed repeatedly and the circuit breaker is open
+var ErrCircuitOpen = errors.New("scorecard API circuit breaker open")
+
+// CircuitOpenError is returned instead of querying the scorecard API while
+// the circuit breaker is open
+type CircuitOpenError struct {
+	// RetryAfter is the time until the circuit breaker lets a request through
+	RetryAfter time.Duration
+}
+
+// Error implements the error interface
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("%v, retry after %v", ErrCircuitOpen, e.RetryAfter)
+}
+
+// Is reports whether target is ErrCircuitOpen
+func (e *CircuitOpenError) Is(target error) bool {
+	return target == ErrCircuitOpen
+}
+
+// circuitState is the state of a circuit breaker
+type circuitState int
+
+const (
+	// circuitClosed lets all requests through
+	circuitClosed circuitState = iota
+	// circuitOpen rejects all requests until the cooldown has passed
+	circuitOpen
+	// circuitHalfOpen lets a single probe request through to test recovery
+	circuitHalfOpen
+)
+
+// circuitBreaker suspends requests after a number of consecutive failures.
+// Once the cooldown has passed, a single probe request is let through: its
+// success closes the circuit again, while its failure restarts the cooldown.
+// It is safe for concurrent use.
+type circuitBreaker struct {
+	mu sync.Mutex
+
+	threshold int
+	cooldown  time.Duration
+	state     circuitState
+	failures  int
+	openedAt  time.Time
+
+	// now returns the current time, replaceable for testing
+	now func() time.Time
+}
+
+// newCircuitBreaker creates a circuit breaker that opens after threshold
+// consecutive failures and stays open for cooldown
+func newCircuitBreaker(threshold int, cooldown time.Duration) *circuitBreaker {
+	return &circuitBreaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		now:       time.Now,
+	}
+}
+
+// allow returns nil if a request may be sent, or a CircuitOpenError if the
+// circuit is open or a probe request is already in flight
+func (b *circuitBreaker) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case circuitOpen:
+		if remaining := b.openedAt.Add(b.cooldown).Sub(b.now()); remaining > 0 {
+			return &CircuitOpenError{RetryAfter: remaining}
+		}
+		b.state = circuitHalfOpen
+		return nil
+	case circuitHalfOpen:
+		return &CircuitOpenError{RetryAfter: b.cooldown}
+	}
+	return nil
+}
+
+// record updates the circuit with the outcome of a request let through by allow
+func (b *circuitBreaker) record(success bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if success {
+		b.state = circuitClosed
+		b.failures = 0
+		return
+	}
+
+	b.failures++
+	if b.state == circuitHalfOpen || b.failures >= b.threshold {
+		b.state = circuitOpen
+		b.openedAt = b.now()
+	}
+}
+
+// release ends a request let through by allow without an outcome, e.g. because
+// the caller gave up on it. A released probe lets the next request probe again.
+func (b *circuitBreaker) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == circuitHalfOpen {
+		b.state = circuitOpen
+	}
+}
+
+// circuitBreakers holds a circuit breaker per API endpoint, so that a failing
+// endpoint does not suspend requests to the others. It is safe for concurrent
+// use.
+type circuitBreakers struct {
+	mu sync.Mutex
+
+	threshold int
+	cooldown  time.Duration
+	breakers  map[string]*circuitBreaker
+
+	// now returns the current time, replaceable for testing
+	now func() time.Time
+}
+
+// newCircuitBreakers creates circuit breakers that open after threshold
+// consecutive failures of their endpoint and stay open for cooldown
+func newCircuitBreakers(threshold int, cooldown time.Duration) *circuitBreakers {
+	return &circuitBreakers{
+		threshold: threshold,
+		cooldown:  cooldown,
+		breakers:  make(map[string]*circuitBreaker),
+		now:       time.Now,
+	}
+}
+
+// get returns the circuit breaker of an endpoint, creating it on first use
+func (b *circuitBreakers) get(endpoint string) *circuitBreaker {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	breaker, ok := b.breakers[endpoint]
+	if !ok {
+		breaker = newCircuitBreaker(b.threshold, b.cooldown)
+		breaker.now = func() time.Time { return b.now() }
+		b.breakers[endpoint] = breaker
+	}
+	return breaker
+}
@@ -0,0 +1,215 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scorecard
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	now := time.Now()
+	b := newCircuitBreaker(3, time.Minute)
+	b.now = func() time.Time { return now }
+
+	// Closed: failures below the threshold let requests through
+	for range 2 {
+		if err := b.allow(); err != nil {
+			t.Fatalf("allow() in closed state unexpected error: %v", err)
+		}
+		b.record(false)
+	}
+	if b.state != circuitClosed {
+		t.Fatalf("state = %v after 2 failures, want closed", b.state)
+	}
+
+	// Open: the threshold is reached and requests fail fast
+	if err := b.allow(); err != nil {
+		t.Fatalf("allow() unexpected error: %v", err)
+	}
+	b.record(false)
+	now = now.Add(20 * time.Second)
+	var circuitErr *CircuitOpenError
+	if err := b.allow(); !errors.As(err, &circuitErr) || circuitErr.RetryAfter != 40*time.Second {
+		t.Fatalf("allow() in open state error = %v, want circuit open error retrying after 40s", err)
+	}
+
+	// Half-open: after the cooldown a single probe is let through, and its
+	// failure reopens the circuit
+	now = now.Add(40 * time.Second)
+	if err := b.allow(); err != nil {
+		t.Fatalf("allow() after cooldown unexpected error: %v", err)
+	}
+	if err := b.allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("allow() during probe error = %v, want ErrCircuitOpen", err)
+	}
+	b.record(false)
+	if err := b.allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("allow() after failed probe error = %v, want ErrCircuitOpen", err)
+	}
+
+	// Closed: a successful probe restores traffic and resets the failure count
+	now = now.Add(time.Minute)
+	if err := b.allow(); err != nil {
+		t.Fatalf("allow() after cooldown unexpected error: %v", err)
+	}
+	b.record(true)
+	for range 2 {
+		if err := b.allow(); err != nil {
+			t.Fatalf("allow() after recovery unexpected error: %v", err)
+		}
+		b.record(false)
+	}
+	if b.state != circuitClosed {
+		t.Errorf("state = %v after recovery and 2 failures, want closed", b.state)
+	}
+}
+
+func TestCircuitBreakerRelease(t *testing.T) {
+	now := time.Now()
+	b := newCircuitBreaker(1, time.Minute)
+	b.now = func() time.Time { return now }
+
+	if err := b.allow(); err != nil {
+		t.Fatalf("allow() unexpected error: %v", err)
+	}
+	b.record(false)
+
+	// A released probe is neither a failure nor a success, and the next
+	// request probes again
+	now = now.Add(time.Minute)
+	if err := b.allow(); err != nil {
+		t.Fatalf("allow() after cooldown unexpected error: %v", err)
+	}
+	b.release()
+	if err := b.allow(); err != nil {
+		t.Fatalf("allow() after a released probe unexpected error: %v", err)
+	}
+	if b.state != circuitHalfOpen {
+		t.Errorf("state = %v after a released probe, want half-open", b.state)
+	}
+
+	// A released request in the closed state leaves the failure count alone
+	b.record(true)
+	_ = b.allow()
+	b.release()
+	if b.state != circuitClosed || b.failures != 0 {
+		t.Errorf("state = %v with %d failures after a released request, want closed without failures", b.state, b.failures)
+	}
+}
+
+func TestGetScorecardData_CircuitBreakerCanceled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewClient(WithAPIEndpoint(server.URL), WithCircuitBreaker(1, time.Minute))
+
+	// Requests the caller gives up on do not open the circuit
+	for range 2 {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		_, err := client.GetScorecardData(ctx, "github.com/org/repo", "")
+		cancel()
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("GetScorecardData() error = %v, want context.DeadlineExceeded", err)
+		}
+	}
+}
+
+func TestGetScorecardData_CircuitBreaker(t *testing.T) {
+	var healthy atomic.Bool
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		switch {
+		case r.URL.Path == "/projects/github.com/org/missing":
+			http.NotFound(w, r)
+		case !healthy.Load():
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+		default:
+			_, _ = w.Write([]byte(testResponse))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	now := time.Now()
+	client := NewClient(WithAPIEndpoint(server.URL), WithCircuitBreaker(2, time.Minute))
+	client.breakers.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	// Not found responses do not count as failures
+	for range 3 {
+		if _, err := client.GetScorecardData(ctx, "github.com/org/missing", ""); !errors.Is(err, ErrNotFound) {
+			t.Fatalf("GetScorecardData() error = %v, want ErrNotFound", err)
+		}
+	}
+
+	// Server errors open the circuit, after which requests fail fast
+	for range 2 {
+		if _, err := client.GetScorecardData(ctx, "github.com/org/repo", ""); err == nil || errors.Is(err, ErrCircuitOpen) {
+			t.Fatalf("GetScorecardData() error = %v, want server error", err)
+		}
+	}
+	before := requests.Load()
+	if _, err := client.GetScorecardData(ctx, "github.com/org/repo", ""); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("GetScorecardData() error = %v, want ErrCircuitOpen", err)
+	}
+	if requests.Load() != before {
+		t.Error("GetScorecardData() queried the API while the circuit was open")
+	}
+
+	// Once the API recovers, the probe after the cooldown closes the circuit
+	healthy.Store(true)
+	now = now.Add(time.Minute)
+	for range 2 {
+		if _, err := client.GetScorecardData(ctx, "github.com/org/repo", ""); err != nil {
+			t.Fatalf("GetScorecardData() after recovery unexpected error: %v", err)
+		}
+	}
+}
+
+func TestGetScorecardData_CircuitBreakerPerEndpoint(t *testing.T) {
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(failing.Close)
+	healthy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(testResponse))
+	}))
+	t.Cleanup(healthy.Close)
+
+	client := NewClient(WithAPIEndpoint(healthy.URL), WithCircuitBreaker(1, time.Minute))
+	ctx := context.Background()
+
+	if _, err := client.GetScorecardData(ctx, "github.com/org/repo", "", FromEndpoint(failing.URL)); err == nil {
+		t.Fatal("GetScorecardData() from the failing endpoint expected error")
+	}
+	if _, err := client.GetScorecardData(ctx, "github.com/org/repo", "", FromEndpoint(failing.URL)); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("GetScorecardData() from the failing endpoint error = %v, want ErrCircuitOpen", err)
+	}
+
+	// The circuit of the failing endpoint leaves the other endpoint alone
+	if _, err := client.GetScorecardData(ctx, "github.com/org/repo", ""); err != nil {
+		t.Errorf("GetScorecardData() from the healthy endpoint unexpected error: %v", err)
+	}
+}
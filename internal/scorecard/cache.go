@@ -0,0 +1,107 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scorecard
+
+import (
+	"sync"
+	"time"
+)
+
+// responseCache is an in-memory TTL cache of scorecard responses keyed by VCS path.
+// Unavailable (not found) results are cached separately with their own TTL, so that
+// newly published reports are picked up sooner than refreshed ones.
+// It is safe for concurrent use.
+type responseCache struct {
+	mu sync.Mutex
+
+	ttl            time.Duration
+	unavailableTTL time.Duration
+	entries        map[string]cacheEntry
+	lastSweep      time.Time
+
+	// now returns the current time, replaceable for testing
+	now func() time.Time
+}
+
+// cacheEntry is a cached scorecard result, either data or a not found error
+type cacheEntry struct {
+	data    *ScorecardData
+	err     error
+	expires time.Time
+}
+
+// newResponseCache creates a cache with the given TTLs. A zero TTL disables
+// caching for that kind of result.
+func newResponseCache(ttl, unavailableTTL time.Duration) *responseCache {
+	return &responseCache{
+		ttl:            ttl,
+		unavailableTTL: unavailableTTL,
+		entries:        make(map[string]cacheEntry),
+		now:            time.Now,
+	}
+}
+
+// get returns the cached result for a VCS path, if present and not expired
+func (c *responseCache) get(vcsPath string) (*ScorecardData, error, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[vcsPath]
+	if !ok {
+		return nil, nil, false
+	}
+	if !c.now().Before(entry.expires) {
+		delete(c.entries, vcsPath)
+		return nil, nil, false
+	}
+	return entry.data, entry.err, true
+}
+
+// setData caches scorecard data for a VCS path
+func (c *responseCache) setData(vcsPath string, data *ScorecardData) {
+	c.set(vcsPath, cacheEntry{data: data}, c.ttl)
+}
+
+// setUnavailable caches a not found result for a VCS path
+func (c *responseCache) setUnavailable(vcsPath string, err error) {
+	c.set(vcsPath, cacheEntry{err: err}, c.unavailableTTL)
+}
+
+func (c *responseCache) set(vcsPath string, entry cacheEntry, ttl time.Duration) {
+	if ttl <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	entry.expires = now.Add(ttl)
+	c.entries[vcsPath] = entry
+
+	// Periodically drop expired entries so repositories that are no longer
+	// fetched don't accumulate
+	if now.Sub(c.lastSweep) < ttl {
+		return
+	}
+	c.lastSweep = now
+	for key, e := range c.entries {
+		if !now.Before(e.expires) {
+			delete(c.entries, key)
+		}
+	}
+}
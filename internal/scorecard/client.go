@@ -19,57 +19,321 @@ package scorecard
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strings"
+	"sync/atomic"
 	"time"
 )
 
 const (
 	// DefaultAPIEndpoint is the default OpenSSF Scorecard API endpoint
 	DefaultAPIEndpoint = "https://api.securityscorecards.dev"
+
+	// DefaultTimeout is the default timeout for requests to the scorecard API
+	DefaultTimeout = 30 * time.Second
+
+	// DefaultPathTemplate is the path of the scorecard data of a project
+	// relative to the API endpoint, with %s standing for the project
+	DefaultPathTemplate = "/projects/%s"
 )
 
+// ErrNotFound indicates that no scorecard data is available for a repository
+var ErrNotFound = errors.New("scorecard data not found")
+
+// Source provides scorecard data for repositories
+type Source interface {
+	// GetScorecardData returns scorecard data for a repository. The vcsPath
+	// is in the format used by the scorecard API (e.g., "github.com/org/repo").
+	GetScorecardData(ctx context.Context, vcsPath, token string, opts ...FetchOption) (*ScorecardData, error)
+}
+
+// FetchOption customizes a request for scorecard data
+type FetchOption func(*fetchOptions)
+
+// fetchOptions holds the settings of a request for scorecard data
+type fetchOptions struct {
+	commit   string
+	endpoint string
+	refresh  bool
+}
+
+// AtCommit requests the scorecard report for a specific commit instead of
+// the latest report for the default branch
+func AtCommit(commit string) FetchOption {
+	return func(o *fetchOptions) {
+		o.commit = commit
+	}
+}
+
+// FromEndpoint requests the scorecard report from a specific scorecard API
+// endpoint instead of the endpoint of the client. Sources that do not query
+// the API ignore it. Requests to another endpoint are never authenticated.
+func FromEndpoint(endpoint string) FetchOption {
+	return func(o *fetchOptions) {
+		o.endpoint = strings.TrimSuffix(endpoint, "/")
+	}
+}
+
+// Refresh requests the report from the API even when the cache or the
+// freshness window holds it. The fetched report still updates both.
+func Refresh() FetchOption {
+	return func(o *fetchOptions) {
+		o.refresh = true
+	}
+}
+
+// newFetchOptions applies opts to the default fetch options
+func newFetchOptions(opts []FetchOption) fetchOptions {
+	var o fetchOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// key identifies the report requested for a repository, for caching and
+// deduplication
+func (o fetchOptions) key(vcsPath string) string {
+	key := vcsPath
+	if o.commit != "" {
+		key += "@" + o.commit
+	}
+	if o.endpoint != "" {
+		key = o.endpoint + "/" + key
+	}
+	return key
+}
+
 // Client is a client for interacting with OpenSSF Scorecard API
 type Client struct {
 	httpClient  *http.Client
 	apiEndpoint string
+
+	// pathTemplate is the path of the scorecard data of a project, see
+	// WithPathTemplate
+	pathTemplate string
+
+	// cache holds recent responses, nil when caching is disabled
+	cache *responseCache
+
+	// fresh remembers the last report of each project, nil when skipping
+	// fresh reports is disabled
+	fresh *freshReports
+
+	// breakers suspend requests to an endpoint while it is failing, nil when
+	// disabled
+	breakers *circuitBreakers
+
+	// observer is notified of every API request, nil when not set
+	observer RequestObserver
+
+	// details keeps the findings behind check scores
+	details bool
+
+	// batchUnsupported is set once the API has rejected the batch route, so
+	// that later batches are fetched one repository at a time right away
+	batchUnsupported atomic.Bool
+}
+
+// RequestObserver is notified of the outcome of every request to the scorecard
+// API, with the HTTP status code, or 0 if no response was received
+type RequestObserver func(statusCode int, duration time.Duration)
+
+// Option configures a Client
+type Option func(*Client)
+
+// WithAPIEndpoint overrides the OpenSSF Scorecard API endpoint
+func WithAPIEndpoint(endpoint string) Option {
+	return func(c *Client) {
+		c.apiEndpoint = strings.TrimSuffix(endpoint, "/")
+	}
+}
+
+// WithPathTemplate overrides the path of the scorecard data of a project
+// relative to the API endpoint, e.g. "/v2/projects/%s" for mirrors with a
+// different routing. The %s stands for the project, e.g. github.com/org/repo,
+// and the template must pass ValidatePathTemplate.
+func WithPathTemplate(template string) Option {
+	return func(c *Client) {
+		c.pathTemplate = template
+	}
+}
+
+// ValidatePathTemplate checks that a path template starts with a slash and
+// contains exactly one %s, and no other formatting verb
+func ValidatePathTemplate(template string) error {
+	if !strings.HasPrefix(template, "/") {
+		return fmt.Errorf("invalid path template %q: must start with /", template)
+	}
+	if strings.Count(template, "%") != 1 || strings.Count(template, "%s") != 1 {
+		return fmt.Errorf("invalid path template %q: must contain exactly one %%s", template)
+	}
+	return nil
+}
+
+// WithTimeout sets the timeout for requests to the scorecard API
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.httpClient.Timeout = timeout
+	}
+}
+
+// WithTransport sets the HTTP transport used for requests to the scorecard API
+func WithTransport(transport http.RoundTripper) Option {
+	return func(c *Client) {
+		c.httpClient.Transport = transport
+	}
+}
+
+// WithCache enables an in-memory cache of responses. Scorecard data is reused
+// for ttl, and "not found" results for unavailableTTL. A zero TTL disables
+// caching of that kind of result.
+func WithCache(ttl, unavailableTTL time.Duration) Option {
+	return func(c *Client) {
+		if ttl > 0 || unavailableTTL > 0 {
+			c.cache = newResponseCache(ttl, unavailableTTL)
+		}
+	}
+}
+
+// WithFreshnessWindow skips refetching a report whose report date is less than
+// window old, reusing the last one fetched instead. Older reports are
+// revalidated with a conditional request when the API returned an ETag or
+// Last-Modified header, and fetched in full otherwise. A zero window disables
+// it.
+func WithFreshnessWindow(window time.Duration) Option {
+	return func(c *Client) {
+		if window > 0 {
+			c.fresh = newFreshReports(window)
+		}
+	}
+}
+
+// WithCircuitBreaker suspends requests to the scorecard API for cooldown after
+// threshold consecutive failures, failing fast with a CircuitOpenError instead.
+// Server errors, rate limiting and network failures count as failures, while
+// "not found" responses do not. Each API endpoint has a circuit breaker of its
+// own. A zero threshold disables the circuit breaker.
+func WithCircuitBreaker(threshold int, cooldown time.Duration) Option {
+	return func(c *Client) {
+		if threshold > 0 {
+			c.breakers = newCircuitBreakers(threshold, cooldown)
+		}
+	}
+}
+
+// WithRequestObserver notifies observer of the status code and duration of
+// every request to the scorecard API
+func WithRequestObserver(observer RequestObserver) Option {
+	return func(c *Client) {
+		c.observer = observer
+	}
+}
+
+// WithDetails keeps the details of checks the API returns, the findings behind
+// their scores. They make up most of a response, so they are dropped by
+// default.
+func WithDetails(enabled bool) Option {
+	return func(c *Client) {
+		c.details = enabled
+	}
 }
 
 // NewClient creates a new OpenSSF Scorecard API client
-func NewClient() *Client {
-	return &Client{
+func NewClient(opts ...Option) *Client {
+	c := &Client{
 		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
+			Timeout: DefaultTimeout,
 		},
-		apiEndpoint: DefaultAPIEndpoint,
+		apiEndpoint:  DefaultAPIEndpoint,
+		pathTemplate: DefaultPathTemplate,
+	}
+
+	for _, opt := range opts {
+		opt(c)
 	}
+
+	return c
 }
 
 // GetScorecardData fetches scorecard data for a specific repository
 // The vcsPath should be in the format expected by the scorecard API (e.g., "github.com/org/repo")
-func (c *Client) GetScorecardData(ctx context.Context, vcsPath, token string) (*ScorecardData, error) {
+func (c *Client) GetScorecardData(
+	ctx context.Context, vcsPath, token string, opts ...FetchOption,
+) (*ScorecardData, error) {
+	o := newFetchOptions(opts)
+	if c.cache == nil {
+		return c.fetchScorecardData(ctx, vcsPath, token, o)
+	}
+
+	key := o.key(vcsPath)
+	if data, err, ok := c.cache.get(key); ok && !o.refresh {
+		return data, err
+	}
+
+	data, err := c.fetchScorecardData(ctx, vcsPath, token, o)
+	switch {
+	case err == nil:
+		c.cache.setData(key, data)
+	case errors.Is(err, ErrNotFound):
+		c.cache.setUnavailable(key, err)
+	}
+	return data, err
+}
+
+// fetchScorecardData fetches scorecard data for a repository from the API
+func (c *Client) fetchScorecardData(ctx context.Context, vcsPath, token string, o fetchOptions) (*ScorecardData, error) {
 	// OpenSSF Scorecard API endpoint format
-	url := fmt.Sprintf("%s/projects/%s", c.apiEndpoint, vcsPath)
+	endpoint := c.apiEndpoint
+	if o.endpoint != "" {
+		endpoint = o.endpoint
+	}
+	requestURL := endpoint + c.projectPath(vcsPath)
+	if o.commit != "" {
+		requestURL += "?commit=" + url.QueryEscape(o.commit)
+	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	// Add authentication if token provided
-	if token != "" {
-		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	var previous freshEntry
+	var known bool
+	if c.fresh != nil {
+		var fresh bool
+		previous, fresh, known = c.fresh.get(o.key(vcsPath))
+		if fresh && !o.refresh {
+			return previous.data, nil
+		}
+		if known {
+			previous.setConditional(req)
+		}
 	}
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.send(req, endpoint, token)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch scorecard data: %w", err)
+		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode == http.StatusNotFound {
-		return nil, fmt.Errorf("scorecard data not found for %s", vcsPath)
+		if c.fresh != nil {
+			c.fresh.remove(o.key(vcsPath))
+		}
+		return nil, fmt.Errorf("%w for %s", ErrNotFound, vcsPath)
+	}
+
+	if resp.StatusCode == http.StatusNotModified && known {
+		return previous.data, nil
+	}
+
+	if err := rateLimitError(resp); err != nil {
+		return nil, err
 	}
 
 	if resp.StatusCode != http.StatusOK {
@@ -83,9 +347,79 @@ func (c *Client) GetScorecardData(ctx context.Context, vcsPath, token string) (*
 		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
 
-	// Parse timestamp
-	timestamp, err := time.Parse(time.RFC3339, apiResponse.Date)
+	data := convertAPIResponse(&apiResponse, c.details)
+	data.Source = EndpointDataSource(endpoint)
+	if c.fresh != nil {
+		_, dated := reportDate(&apiResponse)
+		c.fresh.set(o.key(vcsPath), freshEntry{
+			data:         data,
+			dated:        dated,
+			etag:         resp.Header.Get("ETag"),
+			lastModified: resp.Header.Get("Last-Modified"),
+		})
+	}
+	return data, nil
+}
+
+// projectPath returns the path of the scorecard data of a project
+func (c *Client) projectPath(project string) string {
+	return strings.Replace(c.pathTemplate, "%s", project, 1)
+}
+
+// send sends a request to a scorecard API endpoint, authenticated with token
+// if set, subject to the circuit breaker of the endpoint and reported to the
+// observer
+func (c *Client) send(req *http.Request, endpoint, token string) (*http.Response, error) {
+	// Add authentication if token provided, only for the endpoint of the
+	// client: an endpoint chosen by a config could collect the token
+	if token != "" && endpoint == c.apiEndpoint {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	}
+
+	var breaker *circuitBreaker
+	if c.breakers != nil {
+		breaker = c.breakers.get(endpoint)
+		if err := breaker.allow(); err != nil {
+			return nil, err
+		}
+	}
+
+	start := time.Now()
+	resp, err := c.httpClient.Do(req)
+	if c.observer != nil {
+		statusCode := 0
+		if err == nil {
+			statusCode = resp.StatusCode
+		}
+		c.observer(statusCode, time.Since(start))
+	}
+	switch {
+	case breaker == nil:
+	case err != nil && req.Context().Err() != nil:
+		// The caller gave up on the request, which says nothing about the API
+		breaker.release()
+	default:
+		breaker.record(err == nil &&
+			resp.StatusCode < http.StatusInternalServerError && resp.StatusCode != http.StatusTooManyRequests)
+	}
 	if err != nil {
+		return nil, fmt.Errorf("failed to fetch scorecard data: %w", err)
+	}
+	return resp, nil
+}
+
+// reportDate returns the date of a scorecard result, and whether it is valid
+func reportDate(apiResponse *APIResponse) (time.Time, bool) {
+	timestamp, err := time.Parse(time.RFC3339, apiResponse.Date)
+	return timestamp, err == nil
+}
+
+// convertAPIResponse converts a scorecard result in the API JSON format to our
+// internal format, with check details if details is set. The scorecard CLI
+// emits the same format.
+func convertAPIResponse(apiResponse *APIResponse, details bool) *ScorecardData {
+	timestamp, ok := reportDate(apiResponse)
+	if !ok {
 		timestamp = time.Now() // fallback to current time
 	}
 
@@ -99,20 +433,22 @@ func (c *Client) GetScorecardData(ctx context.Context, vcsPath, token string) (*
 	}
 
 	for _, check := range apiResponse.Checks {
-		status := "Unknown"
-		if check.Score >= 0 && check.Score < 5 {
-			status = "Fail"
-		} else if check.Score >= 5 {
-			status = "Pass"
-		}
-
-		data.Checks = append(data.Checks, Check{
+		converted := Check{
 			Name:   check.Name,
 			Score:  check.Score,
-			Status: status,
+			Status: CheckStatus(check.Score, DefaultPassThreshold),
 			Reason: check.Reason,
-		})
+
+			DocumentationURL: check.Documentation.URL,
+		}
+		if details && len(check.Details) > 0 {
+			converted.Details = make([]CheckDetail, 0, len(check.Details))
+			for _, detail := range check.Details {
+				converted.Details = append(converted.Details, ParseCheckDetail(detail))
+			}
+		}
+		data.Checks = append(data.Checks, converted)
 	}
 
-	return data, nil
+	return data
 }
@@ -0,0 +1,696 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scorecard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/giantswarm/openssf-scorecard-exporter/internal/httpclient"
+)
+
+// testResponse is a minimal scorecard API response body
+const testResponse = `{
+	"date": "2025-01-01T00:00:00Z",
+	"repo": {"name": "github.com/org/repo", "commit": "abc123"},
+	"score": 7.5,
+	"checks": [
+		{"name": "Code-Review", "score": 8, "reason": "reviewed", "documentation": {"url": "https://example.com/checks#code-review"}},
+		{"name": "Fuzzing", "score": 0, "reason": "not fuzzed", "details": ["Warn: no fuzzer integrations found", "Info: OSS-Fuzz not detected", "unprefixed"]},
+		{"name": "Packaging", "score": -1, "reason": "internal error: failed to list workflows"}
+	]
+}`
+
+// newTestServer starts a scorecard API stub that serves testResponse for
+// github.com/org/repo, 404 for anything else, and counts requests
+func newTestServer(t *testing.T) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.URL.Path != "/projects/github.com/org/repo" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(testResponse))
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &requests
+}
+
+func TestGetScorecardData(t *testing.T) {
+	server, _ := newTestServer(t)
+	client := NewClient(WithAPIEndpoint(server.URL))
+
+	data, err := client.GetScorecardData(context.Background(), "github.com/org/repo", "")
+	if err != nil {
+		t.Fatalf("GetScorecardData() unexpected error: %v", err)
+	}
+	if data.Score != 7.5 || data.Commit != "abc123" || len(data.Checks) != 3 {
+		t.Fatalf("GetScorecardData() = %+v, unexpected data", data)
+	}
+	if data.Checks[0].Status != "Pass" || data.Checks[1].Status != "Fail" || data.Checks[2].Status != "Inconclusive" {
+		t.Errorf("GetScorecardData() check statuses = %s/%s/%s, want Pass/Fail/Inconclusive",
+			data.Checks[0].Status, data.Checks[1].Status, data.Checks[2].Status)
+	}
+	if data.Checks[2].Score != -1 {
+		t.Errorf("GetScorecardData() inconclusive check score = %d, want -1", data.Checks[2].Score)
+	}
+	if data.Checks[0].DocumentationURL != "https://example.com/checks#code-review" {
+		t.Errorf("GetScorecardData() documentation URL = %q, want the check documentation", data.Checks[0].DocumentationURL)
+	}
+
+	if data.Checks[1].Details != nil {
+		t.Errorf("GetScorecardData() details = %+v, want none without WithDetails", data.Checks[1].Details)
+	}
+
+	_, err = client.GetScorecardData(context.Background(), "github.com/org/missing", "")
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetScorecardData() error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestGetScorecardData_UserAgent(t *testing.T) {
+	var userAgent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgent = r.UserAgent()
+		_, _ = w.Write([]byte(testResponse))
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewClient(
+		WithAPIEndpoint(server.URL),
+		WithTransport(httpclient.NewUserAgentTransport(http.DefaultTransport, "openssf-scorecard-exporter/v1.2.3")),
+	)
+	if _, err := client.GetScorecardData(context.Background(), "github.com/org/repo", ""); err != nil {
+		t.Fatalf("GetScorecardData() unexpected error: %v", err)
+	}
+	if userAgent != "openssf-scorecard-exporter/v1.2.3" {
+		t.Errorf("User-Agent = %q, want openssf-scorecard-exporter/v1.2.3", userAgent)
+	}
+}
+
+func TestGetScorecardData_Details(t *testing.T) {
+	server, _ := newTestServer(t)
+	client := NewClient(WithAPIEndpoint(server.URL), WithDetails(true))
+
+	data, err := client.GetScorecardData(context.Background(), "github.com/org/repo", "")
+	if err != nil {
+		t.Fatalf("GetScorecardData() unexpected error: %v", err)
+	}
+	expected := []CheckDetail{
+		{Level: CheckDetailWarn, Message: "no fuzzer integrations found"},
+		{Level: CheckDetailInfo, Message: "OSS-Fuzz not detected"},
+		{Message: "unprefixed"},
+	}
+	if len(data.Checks) != 3 || !slices.Equal(data.Checks[1].Details, expected) {
+		t.Fatalf("GetScorecardData() checks = %+v, want details %+v", data.Checks, expected)
+	}
+	if data.Checks[0].Details != nil {
+		t.Errorf("GetScorecardData() details = %+v for a check without details, want none", data.Checks[0].Details)
+	}
+}
+
+func TestParseCheckDetail(t *testing.T) {
+	tests := []struct {
+		detail   string
+		expected CheckDetail
+	}{
+		{detail: "Warn: branch protection not enabled", expected: CheckDetail{Level: "Warn", Message: "branch protection not enabled"}},
+		{detail: "Info: SAST tool detected", expected: CheckDetail{Level: "Info", Message: "SAST tool detected"}},
+		{detail: "Debug: 3 commits checked", expected: CheckDetail{Level: "Debug", Message: "3 commits checked"}},
+		{detail: "Error: not a level", expected: CheckDetail{Message: "Error: not a level"}},
+		{detail: "Warn", expected: CheckDetail{Message: "Warn"}},
+		{detail: "", expected: CheckDetail{}},
+	}
+
+	for _, tt := range tests {
+		if got := ParseCheckDetail(tt.detail); got != tt.expected {
+			t.Errorf("ParseCheckDetail(%q) = %+v, want %+v", tt.detail, got, tt.expected)
+		}
+	}
+}
+
+func TestGetScorecardData_Commit(t *testing.T) {
+	tests := []struct {
+		name          string
+		opts          []FetchOption
+		expectedQuery string
+	}{
+		{
+			name:          "latest report",
+			expectedQuery: "",
+		},
+		{
+			name:          "specific commit",
+			opts:          []FetchOption{AtCommit("abc123")},
+			expectedQuery: "commit=abc123",
+		},
+		{
+			name:          "commit is escaped",
+			opts:          []FetchOption{AtCommit("a&b")},
+			expectedQuery: "commit=a%26b",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var query string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				query = r.URL.RawQuery
+				_, _ = w.Write([]byte(testResponse))
+			}))
+			t.Cleanup(server.Close)
+
+			client := NewClient(WithAPIEndpoint(server.URL))
+			if _, err := client.GetScorecardData(context.Background(), "github.com/org/repo", "", tt.opts...); err != nil {
+				t.Fatalf("GetScorecardData() unexpected error: %v", err)
+			}
+			if query != tt.expectedQuery {
+				t.Errorf("query = %q, want %q", query, tt.expectedQuery)
+			}
+		})
+	}
+}
+
+func TestGetScorecardData_PathTemplate(t *testing.T) {
+	tests := []struct {
+		name         string
+		template     string
+		expectedPath string
+		expectedURI  string
+	}{
+		{
+			name:         "default template",
+			expectedPath: "/projects/github.com/org/repo",
+			expectedURI:  "/projects/github.com/org/repo?commit=abc123",
+		},
+		{
+			name:         "versioned route",
+			template:     "/v2/projects/%s",
+			expectedPath: "/v2/projects/github.com/org/repo",
+			expectedURI:  "/v2/projects/github.com/org/repo?commit=abc123",
+		},
+		{
+			name:         "project in the middle of the path",
+			template:     "/api/%s/scorecard",
+			expectedPath: "/api/github.com/org/repo/scorecard",
+			expectedURI:  "/api/github.com/org/repo/scorecard?commit=abc123",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var uri string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				uri = r.URL.RequestURI()
+				if r.URL.Path != tt.expectedPath {
+					http.NotFound(w, r)
+					return
+				}
+				_, _ = w.Write([]byte(testResponse))
+			}))
+			t.Cleanup(server.Close)
+
+			opts := []Option{WithAPIEndpoint(server.URL)}
+			if tt.template != "" {
+				opts = append(opts, WithPathTemplate(tt.template))
+			}
+			client := NewClient(opts...)
+			if _, err := client.GetScorecardData(context.Background(), "github.com/org/repo", "", AtCommit("abc123")); err != nil {
+				t.Fatalf("GetScorecardData() unexpected error: %v", err)
+			}
+			if uri != tt.expectedURI {
+				t.Errorf("request URI = %q, want %q", uri, tt.expectedURI)
+			}
+		})
+	}
+}
+
+func TestValidatePathTemplate(t *testing.T) {
+	tests := []struct {
+		template  string
+		expectErr bool
+	}{
+		{template: DefaultPathTemplate},
+		{template: "/v2/projects/%s"},
+		{template: "v2/projects/%s", expectErr: true},
+		{template: "/v2/projects", expectErr: true},
+		{template: "/%s/projects/%s", expectErr: true},
+		{template: "/projects/%d", expectErr: true},
+		{template: "/projects/%s?format=%v", expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.template, func(t *testing.T) {
+			if err := ValidatePathTemplate(tt.template); (err != nil) != tt.expectErr {
+				t.Errorf("ValidatePathTemplate() error = %v, expectErr %v", err, tt.expectErr)
+			}
+		})
+	}
+}
+
+func TestGetScorecardData_CacheCommit(t *testing.T) {
+	server, requests := newTestServer(t)
+	client := NewClient(WithAPIEndpoint(server.URL), WithCache(time.Hour, time.Minute))
+
+	for _, opts := range [][]FetchOption{nil, {AtCommit("abc123")}, {AtCommit("abc123")}} {
+		if _, err := client.GetScorecardData(context.Background(), "github.com/org/repo", "", opts...); err != nil {
+			t.Fatalf("GetScorecardData() unexpected error: %v", err)
+		}
+	}
+
+	// The latest report and the commit's report are cached separately
+	if got := requests.Load(); got != 2 {
+		t.Errorf("API requests = %d, want 2", got)
+	}
+}
+
+func TestGetScorecardData_Endpoint(t *testing.T) {
+	server, requests := newTestServer(t)
+	other, otherRequests := newTestServer(t)
+	client := NewClient(WithAPIEndpoint(server.URL), WithCache(time.Hour, time.Minute))
+
+	for _, opts := range [][]FetchOption{nil, {FromEndpoint(other.URL + "/")}, {FromEndpoint(other.URL)}} {
+		data, err := client.GetScorecardData(context.Background(), "github.com/org/repo", "", opts...)
+		if err != nil {
+			t.Fatalf("GetScorecardData() unexpected error: %v", err)
+		}
+		if data.Score != 7.5 {
+			t.Errorf("GetScorecardData() score = %v, want 7.5", data.Score)
+		}
+		if data.Source != DataSourceMirror {
+			t.Errorf("GetScorecardData() source = %q, want %q", data.Source, DataSourceMirror)
+		}
+	}
+
+	// Reports from different endpoints are cached separately
+	if got := requests.Load(); got != 1 {
+		t.Errorf("client endpoint requests = %d, want 1", got)
+	}
+	if got := otherRequests.Load(); got != 1 {
+		t.Errorf("explicit endpoint requests = %d, want 1", got)
+	}
+}
+
+func TestGetScorecardData_Authorization(t *testing.T) {
+	var authorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		_, _ = w.Write([]byte(testResponse))
+	}))
+	t.Cleanup(server.Close)
+	other := httptest.NewServer(server.Config.Handler)
+	t.Cleanup(other.Close)
+
+	tests := []struct {
+		name     string
+		opts     []FetchOption
+		expected string
+	}{
+		{
+			name:     "client endpoint",
+			expected: "Bearer token",
+		},
+		{
+			name: "explicit endpoint",
+			opts: []FetchOption{FromEndpoint(other.URL)},
+		},
+		{
+			name:     "client endpoint set explicitly",
+			opts:     []FetchOption{FromEndpoint(server.URL)},
+			expected: "Bearer token",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := NewClient(WithAPIEndpoint(server.URL))
+			authorization = ""
+			if _, err := client.GetScorecardData(context.Background(), "github.com/org/repo", "token", tt.opts...); err != nil {
+				t.Fatalf("GetScorecardData() unexpected error: %v", err)
+			}
+			if authorization != tt.expected {
+				t.Errorf("Authorization header = %q, want %q", authorization, tt.expected)
+			}
+		})
+	}
+}
+
+func TestEndpointDataSource(t *testing.T) {
+	tests := []struct {
+		endpoint string
+		expected string
+	}{
+		{endpoint: "", expected: DataSourceAPI},
+		{endpoint: DefaultAPIEndpoint, expected: DataSourceAPI},
+		{endpoint: DefaultAPIEndpoint + "/", expected: DataSourceAPI},
+		{endpoint: "https://scorecard.example.com", expected: DataSourceMirror},
+	}
+
+	for _, tt := range tests {
+		if got := EndpointDataSource(tt.endpoint); got != tt.expected {
+			t.Errorf("EndpointDataSource(%q) = %q, want %q", tt.endpoint, got, tt.expected)
+		}
+	}
+}
+
+func TestCheckStatus(t *testing.T) {
+	tests := []struct {
+		score     int
+		threshold int
+		expected  string
+	}{
+		{score: -1, threshold: DefaultPassThreshold, expected: "Inconclusive"},
+		{score: 0, threshold: DefaultPassThreshold, expected: "Fail"},
+		{score: 4, threshold: DefaultPassThreshold, expected: "Fail"},
+		{score: 5, threshold: DefaultPassThreshold, expected: "Pass"},
+		{score: 10, threshold: DefaultPassThreshold, expected: "Pass"},
+		{score: 6, threshold: 7, expected: "Fail"},
+		{score: 7, threshold: 7, expected: "Pass"},
+		{score: 9, threshold: 10, expected: "Fail"},
+		{score: 10, threshold: 10, expected: "Pass"},
+		{score: 1, threshold: 1, expected: "Pass"},
+		{score: -1, threshold: 1, expected: "Inconclusive"},
+	}
+
+	for _, tt := range tests {
+		if got := CheckStatus(tt.score, tt.threshold); got != tt.expected {
+			t.Errorf("CheckStatus(%d, %d) = %q, want %q", tt.score, tt.threshold, got, tt.expected)
+		}
+	}
+}
+
+func TestGetScorecardData_Cache(t *testing.T) {
+	tests := []struct {
+		name             string
+		vcsPath          string
+		ttl              time.Duration
+		unavailableTTL   time.Duration
+		advance          time.Duration
+		expectedRequests int32
+	}{
+		{
+			name:             "data reused within TTL",
+			vcsPath:          "github.com/org/repo",
+			ttl:              time.Hour,
+			unavailableTTL:   time.Minute,
+			advance:          30 * time.Minute,
+			expectedRequests: 1,
+		},
+		{
+			name:             "data refetched after TTL",
+			vcsPath:          "github.com/org/repo",
+			ttl:              time.Hour,
+			unavailableTTL:   time.Minute,
+			advance:          2 * time.Hour,
+			expectedRequests: 2,
+		},
+		{
+			name:             "not found reused within unavailable TTL",
+			vcsPath:          "github.com/org/missing",
+			ttl:              time.Hour,
+			unavailableTTL:   time.Minute,
+			advance:          30 * time.Second,
+			expectedRequests: 1,
+		},
+		{
+			name:             "not found refetched after unavailable TTL",
+			vcsPath:          "github.com/org/missing",
+			ttl:              time.Hour,
+			unavailableTTL:   time.Minute,
+			advance:          30 * time.Minute,
+			expectedRequests: 2,
+		},
+		{
+			name:             "not found not cached without unavailable TTL",
+			vcsPath:          "github.com/org/missing",
+			ttl:              time.Hour,
+			expectedRequests: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, requests := newTestServer(t)
+			client := NewClient(WithAPIEndpoint(server.URL), WithCache(tt.ttl, tt.unavailableTTL))
+
+			now := time.Now()
+			client.cache.now = func() time.Time { return now }
+
+			first, firstErr := client.GetScorecardData(context.Background(), tt.vcsPath, "")
+			now = now.Add(tt.advance)
+			second, secondErr := client.GetScorecardData(context.Background(), tt.vcsPath, "")
+
+			if got := requests.Load(); got != tt.expectedRequests {
+				t.Errorf("API requests = %d, want %d", got, tt.expectedRequests)
+			}
+			if (firstErr == nil) != (secondErr == nil) {
+				t.Errorf("cached error = %v, want %v", secondErr, firstErr)
+			}
+			if first != nil && second != nil && first.Score != second.Score {
+				t.Errorf("cached score = %v, want %v", second.Score, first.Score)
+			}
+		})
+	}
+}
+
+func TestGetScorecardData_Freshness(t *testing.T) {
+	reportDate := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name                string
+		response            string
+		etag                string
+		lastModified        string
+		advance             time.Duration
+		expectedRequests    int32
+		expectedConditional bool
+	}{
+		{
+			name:             "fresh report reused",
+			response:         testResponse,
+			advance:          time.Hour,
+			expectedRequests: 1,
+		},
+		{
+			name:             "stale report refetched",
+			response:         testResponse,
+			advance:          48 * time.Hour,
+			expectedRequests: 2,
+		},
+		{
+			name:                "stale report revalidated with its ETag",
+			response:            testResponse,
+			etag:                `"v1"`,
+			advance:             48 * time.Hour,
+			expectedRequests:    2,
+			expectedConditional: true,
+		},
+		{
+			name:                "stale report revalidated with its modification date",
+			response:            testResponse,
+			lastModified:        "Wed, 01 Jan 2025 00:00:00 GMT",
+			advance:             48 * time.Hour,
+			expectedRequests:    2,
+			expectedConditional: true,
+		},
+		{
+			name:             "undated report refetched",
+			response:         `{"repo": {"name": "github.com/org/repo"}, "score": 7.5}`,
+			advance:          time.Hour,
+			expectedRequests: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests atomic.Int32
+			var conditional atomic.Bool
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests.Add(1)
+				if (tt.etag != "" && r.Header.Get("If-None-Match") == tt.etag) ||
+					(tt.lastModified != "" && r.Header.Get("If-Modified-Since") == tt.lastModified) {
+					conditional.Store(true)
+					w.WriteHeader(http.StatusNotModified)
+					return
+				}
+				if tt.etag != "" {
+					w.Header().Set("ETag", tt.etag)
+				}
+				if tt.lastModified != "" {
+					w.Header().Set("Last-Modified", tt.lastModified)
+				}
+				_, _ = w.Write([]byte(tt.response))
+			}))
+			t.Cleanup(server.Close)
+
+			client := NewClient(WithAPIEndpoint(server.URL), WithFreshnessWindow(24*time.Hour))
+			now := reportDate
+			client.fresh.now = func() time.Time { return now }
+
+			first, err := client.GetScorecardData(context.Background(), "github.com/org/repo", "")
+			if err != nil {
+				t.Fatalf("GetScorecardData() unexpected error: %v", err)
+			}
+			now = now.Add(tt.advance)
+			second, err := client.GetScorecardData(context.Background(), "github.com/org/repo", "")
+			if err != nil {
+				t.Fatalf("GetScorecardData() unexpected error: %v", err)
+			}
+
+			if got := requests.Load(); got != tt.expectedRequests {
+				t.Errorf("API requests = %d, want %d", got, tt.expectedRequests)
+			}
+			if got := conditional.Load(); got != tt.expectedConditional {
+				t.Errorf("conditional request = %v, want %v", got, tt.expectedConditional)
+			}
+			if reused := second == first; reused != (tt.expectedRequests == 1 || tt.expectedConditional) {
+				t.Errorf("last report reused = %v", reused)
+			}
+		})
+	}
+}
+
+func TestGetScorecardData_Refresh(t *testing.T) {
+	tests := []struct {
+		name    string
+		options []Option
+	}{
+		{
+			name:    "cached report",
+			options: []Option{WithCache(time.Hour, time.Hour)},
+		},
+		{
+			name:    "fresh report",
+			options: []Option{WithFreshnessWindow(24 * time.Hour)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Every request returns a higher score
+			var requests atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				score := requests.Add(1)
+				_, _ = fmt.Fprintf(w, `{"date": "2025-01-01T00:00:00Z", "repo": {"name": "github.com/org/repo"}, "score": %d}`, score)
+			}))
+			t.Cleanup(server.Close)
+			client := NewClient(append([]Option{WithAPIEndpoint(server.URL)}, tt.options...)...)
+			if client.fresh != nil {
+				client.fresh.now = func() time.Time { return time.Date(2025, 1, 1, 1, 0, 0, 0, time.UTC) }
+			}
+
+			ctx := context.Background()
+			if _, err := client.GetScorecardData(ctx, "github.com/org/repo", ""); err != nil {
+				t.Fatalf("GetScorecardData() unexpected error: %v", err)
+			}
+			refreshed, err := client.GetScorecardData(ctx, "github.com/org/repo", "", Refresh())
+			if err != nil {
+				t.Fatalf("GetScorecardData() unexpected error: %v", err)
+			}
+			if refreshed.Score != 2 {
+				t.Errorf("refreshed score = %v, want 2", refreshed.Score)
+			}
+
+			// The refreshed report replaces the previous one
+			reused, err := client.GetScorecardData(ctx, "github.com/org/repo", "")
+			if err != nil {
+				t.Fatalf("GetScorecardData() unexpected error: %v", err)
+			}
+			if reused.Score != 2 || requests.Load() != 2 {
+				t.Errorf("score = %v after %d requests, want 2 after 2", reused.Score, requests.Load())
+			}
+		})
+	}
+}
+
+func TestGetScorecardData_FreshnessNotFound(t *testing.T) {
+	var missing atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if missing.Load() {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write([]byte(testResponse))
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewClient(WithAPIEndpoint(server.URL), WithFreshnessWindow(time.Hour))
+	if _, err := client.GetScorecardData(context.Background(), "github.com/org/repo", ""); err != nil {
+		t.Fatalf("GetScorecardData() unexpected error: %v", err)
+	}
+
+	// The report dates from 2025, so it is stale and revalidated, and a
+	// report the API no longer has is not served from the last one fetched
+	missing.Store(true)
+	if _, err := client.GetScorecardData(context.Background(), "github.com/org/repo", ""); !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetScorecardData() error = %v, want ErrNotFound", err)
+	}
+	if _, _, known := client.fresh.get("github.com/org/repo"); known {
+		t.Error("report of a missing repository still remembered")
+	}
+}
+
+func TestGetScorecardData_Timeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(2 * time.Second):
+		case <-r.Context().Done():
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewClient(WithAPIEndpoint(server.URL), WithTimeout(50*time.Millisecond))
+
+	start := time.Now()
+	_, err := client.GetScorecardData(context.Background(), "github.com/org/repo", "")
+	if err == nil {
+		t.Fatal("GetScorecardData() expected timeout error")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("GetScorecardData() took %v, want it to abort at the timeout", elapsed)
+	}
+}
+
+func TestGetScorecardData_RequestObserver(t *testing.T) {
+	server, _ := newTestServer(t)
+
+	var statusCodes []int
+	client := NewClient(WithAPIEndpoint(server.URL), WithRequestObserver(func(statusCode int, duration time.Duration) {
+		if duration <= 0 {
+			t.Errorf("observed duration = %v, want positive", duration)
+		}
+		statusCodes = append(statusCodes, statusCode)
+	}))
+
+	_, _ = client.GetScorecardData(context.Background(), "github.com/org/repo", "")
+	_, _ = client.GetScorecardData(context.Background(), "github.com/org/missing", "")
+
+	if len(statusCodes) != 2 || statusCodes[0] != http.StatusOK || statusCodes[1] != http.StatusNotFound {
+		t.Errorf("observed status codes = %v, want [200 404]", statusCodes)
+	}
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scorecard
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"maps"
+	"strings"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// deduplicatingSource shares in-flight requests for the same repository
+// between concurrent callers
+type deduplicatingSource struct {
+	source  Source
+	timeout time.Duration
+	group   singleflight.Group
+}
+
+// Deduplicate wraps a source so that concurrent requests for the same
+// repository, commit and endpoint with the same token, e.g. from ConfigMaps
+// covering overlapping organizations, share a single underlying request. The
+// shared request is not cancelled with the context of the caller that started
+// it, so that it completes for the others, and is limited to timeout instead;
+// a zero timeout disables the limit. Callers whose context ends stop waiting
+// for it. The returned source serves batches if source does.
+func Deduplicate(source Source, timeout time.Duration) Source {
+	d := &deduplicatingSource{source: source, timeout: timeout}
+	if _, ok := source.(BatchSource); ok {
+		return &deduplicatingBatchSource{d}
+	}
+	return d
+}
+
+// deduplicatingBatchSource is a deduplicatingSource of a source that serves
+// batches
+type deduplicatingBatchSource struct {
+	*deduplicatingSource
+}
+
+// GetScorecardData implements Source
+func (d *deduplicatingSource) GetScorecardData(
+	ctx context.Context, vcsPath, token string, opts ...FetchOption,
+) (*ScorecardData, error) {
+	key := newFetchOptions(opts).key(vcsPath) + tokenKey(token)
+	result, err := d.share(ctx, key, func(sharedCtx context.Context) (interface{}, error) {
+		return d.source.GetScorecardData(sharedCtx, vcsPath, token, opts...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*ScorecardData), nil
+}
+
+// share runs fetch once for all concurrent callers with the same key, under a
+// context that keeps the values of ctx but not its cancellation, and returns
+// its result unless ctx ends first
+func (d *deduplicatingSource) share(
+	ctx context.Context, key string, fetch func(context.Context) (interface{}, error),
+) (interface{}, error) {
+	results := d.group.DoChan(key, func() (interface{}, error) {
+		sharedCtx := context.WithoutCancel(ctx)
+		if d.timeout > 0 {
+			var cancel context.CancelFunc
+			sharedCtx, cancel = context.WithTimeout(sharedCtx, d.timeout)
+			defer cancel()
+		}
+		return fetch(sharedCtx)
+	})
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case result := <-results:
+		return result.Val, result.Err
+	}
+}
+
+// tokenKey sets the deduplication keys of requests with different tokens
+// apart, without keeping the token itself
+func tokenKey(token string) string {
+	if token == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(token))
+	return "#" + hex.EncodeToString(sum[:8])
+}
+
+// GetScorecardDataBatch implements BatchSource. Concurrent requests for the
+// same batch share a single request to the source, like single repositories.
+func (d *deduplicatingBatchSource) GetScorecardDataBatch(
+	ctx context.Context, vcsPaths []string, token string, opts ...FetchOption,
+) (map[string]*ScorecardData, error) {
+	key := newFetchOptions(opts).key(batchProject+"/"+strings.Join(vcsPaths, ",")) + tokenKey(token)
+	result, err := d.share(ctx, key, func(sharedCtx context.Context) (interface{}, error) {
+		return d.source.(BatchSource).GetScorecardDataBatch(sharedCtx, vcsPaths, token, opts...)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Callers may add to their results
+	return maps.Clone(result.(map[string]*ScorecardData)), nil
+}
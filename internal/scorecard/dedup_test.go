@@ -0,0 +1,261 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scorecard
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDeduplicate(t *testing.T) {
+	tests := []struct {
+		name             string
+		opts             [][]FetchOption
+		tokens           []string
+		expectedRequests int32
+	}{
+		{
+			name:             "identical requests share one call",
+			opts:             [][]FetchOption{nil, nil, nil, nil},
+			expectedRequests: 1,
+		},
+		{
+			name:             "different commits are fetched separately",
+			opts:             [][]FetchOption{nil, {AtCommit("abc123")}, {AtCommit("abc123")}, {AtCommit("def456")}},
+			expectedRequests: 3,
+		},
+		{
+			name:             "different tokens are fetched separately",
+			opts:             [][]FetchOption{nil, nil, nil, nil},
+			tokens:           []string{"", "token-a", "token-a", "token-b"},
+			expectedRequests: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Hold responses until all callers are waiting so their requests overlap
+			release := make(chan struct{})
+			var requests atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests.Add(1)
+				<-release
+				_, _ = w.Write([]byte(testResponse))
+			}))
+			t.Cleanup(server.Close)
+
+			source := Deduplicate(NewClient(WithAPIEndpoint(server.URL)), time.Minute)
+
+			var wg sync.WaitGroup
+			errs := make(chan error, len(tt.opts))
+			for i, opts := range tt.opts {
+				token := ""
+				if tt.tokens != nil {
+					token = tt.tokens[i]
+				}
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					data, err := source.GetScorecardData(context.Background(), "github.com/org/repo", token, opts...)
+					if err == nil && data.Score != 7.5 {
+						t.Errorf("GetScorecardData() score = %v, want 7.5", data.Score)
+					}
+					errs <- err
+				}()
+			}
+
+			// Wait until the expected requests have arrived, and give the remaining
+			// callers time to join them, before releasing the responses
+			for requests.Load() < tt.expectedRequests {
+				runtime.Gosched()
+			}
+			time.Sleep(100 * time.Millisecond)
+			close(release)
+			wg.Wait()
+			close(errs)
+
+			for err := range errs {
+				if err != nil {
+					t.Errorf("GetScorecardData() unexpected error: %v", err)
+				}
+			}
+			if got := requests.Load(); got != tt.expectedRequests {
+				t.Errorf("API requests = %d, want %d", got, tt.expectedRequests)
+			}
+		})
+	}
+}
+
+func TestDeduplicate_Cancel(t *testing.T) {
+	// The first caller's request never completes on its own
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	t.Cleanup(server.Close)
+
+	// The shared request outlives the callers below until its own timeout
+	source := Deduplicate(NewClient(WithAPIEndpoint(server.URL)), 500*time.Millisecond)
+
+	// The first caller outlives the test, keeping the shared request in flight
+	first, cancelFirst := context.WithCancel(context.Background())
+	t.Cleanup(cancelFirst)
+	go func() {
+		_, _ = source.GetScorecardData(first, "github.com/org/repo", "")
+	}()
+	time.Sleep(50 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := source.GetScorecardData(ctx, "github.com/org/repo", "")
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("GetScorecardData() took %v, want it to return when its context ends", elapsed)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("GetScorecardData() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestDeduplicate_FirstCallerCancels(t *testing.T) {
+	release := make(chan struct{})
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		<-release
+		_, _ = w.Write([]byte(testResponse))
+	}))
+	t.Cleanup(server.Close)
+
+	source := Deduplicate(NewClient(WithAPIEndpoint(server.URL)), time.Minute)
+
+	// The first caller starts the shared request and gives up on it
+	first, cancelFirst := context.WithCancel(context.Background())
+	firstErr := make(chan error, 1)
+	go func() {
+		_, err := source.GetScorecardData(first, "github.com/org/repo", "")
+		firstErr <- err
+	}()
+	for requests.Load() < 1 {
+		runtime.Gosched()
+	}
+
+	second := make(chan error, 1)
+	go func() {
+		data, err := source.GetScorecardData(context.Background(), "github.com/org/repo", "")
+		if err == nil && data.Score != 7.5 {
+			t.Errorf("GetScorecardData() score = %v, want 7.5", data.Score)
+		}
+		second <- err
+	}()
+	time.Sleep(50 * time.Millisecond)
+	cancelFirst()
+	if err := <-firstErr; !errors.Is(err, context.Canceled) {
+		t.Errorf("GetScorecardData() of the first caller error = %v, want %v", err, context.Canceled)
+	}
+
+	// The shared request completes for the caller still waiting
+	close(release)
+	if err := <-second; err != nil {
+		t.Errorf("GetScorecardData() of the second caller unexpected error: %v", err)
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("API requests = %d, want 1", got)
+	}
+}
+
+func TestDeduplicate_Timeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	t.Cleanup(server.Close)
+
+	source := Deduplicate(NewClient(WithAPIEndpoint(server.URL)), 50*time.Millisecond)
+
+	// The shared request is limited by its own timeout
+	_, err := source.GetScorecardData(context.Background(), "github.com/org/repo", "")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("GetScorecardData() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestDeduplicate_Batch(t *testing.T) {
+	release := make(chan struct{})
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		<-release
+		_ = json.NewEncoder(w).Encode([]json.RawMessage{json.RawMessage(testResponse)})
+	}))
+	t.Cleanup(server.Close)
+
+	source, ok := Deduplicate(NewClient(WithAPIEndpoint(server.URL)), time.Minute).(BatchSource)
+	if !ok {
+		t.Fatal("Deduplicate() of a client does not serve batches")
+	}
+	vcsPaths := []string{"github.com/org/repo", "github.com/org/missing"}
+
+	// The first caller starts the shared batch and gives up on it, while the
+	// others keep waiting for it
+	first, cancelFirst := context.WithCancel(context.Background())
+	firstErr := make(chan error, 1)
+	go func() {
+		_, err := source.GetScorecardDataBatch(first, vcsPaths, "token")
+		firstErr <- err
+	}()
+	for requests.Load() < 1 {
+		runtime.Gosched()
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 2)
+	for range 2 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results, err := source.GetScorecardDataBatch(context.Background(), vcsPaths, "token")
+			if err == nil && len(results) != 1 {
+				t.Errorf("GetScorecardDataBatch() = %v, want github.com/org/repo only", results)
+			}
+			errs <- err
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+	cancelFirst()
+	if err := <-firstErr; !errors.Is(err, context.Canceled) {
+		t.Errorf("GetScorecardDataBatch() of the first caller error = %v, want %v", err, context.Canceled)
+	}
+
+	close(release)
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Errorf("GetScorecardDataBatch() unexpected error: %v", err)
+		}
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("API requests = %d, want 1", got)
+	}
+}
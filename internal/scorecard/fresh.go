@@ -0,0 +1,118 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scorecard
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+// freshReports remembers the last report fetched for each project, to skip
+// refetching reports that are still fresh and to revalidate older ones with a
+// conditional request. It is safe for concurrent use.
+type freshReports struct {
+	mu sync.Mutex
+
+	window    time.Duration
+	entries   map[string]freshEntry
+	lastSweep time.Time
+
+	// now returns the current time, replaceable for testing
+	now func() time.Time
+}
+
+// freshEntry is the last report fetched for a project, with the validators of
+// the response it came from
+type freshEntry struct {
+	data *ScorecardData
+
+	// dated is set when the report carried a valid date, as opposed to the
+	// time it was fetched
+	dated bool
+
+	etag         string
+	lastModified string
+	used         time.Time
+}
+
+// newFreshReports creates a store of reports considered fresh for window
+// after their report date
+func newFreshReports(window time.Duration) *freshReports {
+	return &freshReports{
+		window:  window,
+		entries: make(map[string]freshEntry),
+		now:     time.Now,
+	}
+}
+
+// get returns the last report fetched for a key, and whether it is still fresh
+// enough to be used without asking the API
+func (f *freshReports) get(key string) (freshEntry, bool, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	entry, ok := f.entries[key]
+	if !ok {
+		return freshEntry{}, false, false
+	}
+	now := f.now()
+	entry.used = now
+	f.entries[key] = entry
+	return entry, entry.dated && now.Sub(entry.data.Timestamp) < f.window, true
+}
+
+// set records the report fetched for a key
+func (f *freshReports) set(key string, entry freshEntry) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	now := f.now()
+	entry.used = now
+	f.entries[key] = entry
+
+	// Periodically drop reports that haven't been asked for in a while, so
+	// repositories that are no longer fetched don't accumulate
+	if now.Sub(f.lastSweep) < f.window {
+		return
+	}
+	f.lastSweep = now
+	for k, e := range f.entries {
+		if now.Sub(e.used) >= f.window {
+			delete(f.entries, k)
+		}
+	}
+}
+
+// remove forgets the report of a key, e.g. once the API no longer has it
+func (f *freshReports) remove(key string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	delete(f.entries, key)
+}
+
+// setConditional adds the validators of a previous response for the report to
+// a request, so the API can answer 304 Not Modified if it hasn't changed
+func (e freshEntry) setConditional(req *http.Request) {
+	if e.etag != "" {
+		req.Header.Set("If-None-Match", e.etag)
+	}
+	if e.lastModified != "" {
+		req.Header.Set("If-Modified-Since", e.lastModified)
+	}
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scorecard
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultHealthCheckInterval is how long a health check result is reused
+	DefaultHealthCheckInterval = time.Minute
+
+	// healthCheckTimeout bounds a single request made by the health check
+	healthCheckTimeout = 5 * time.Second
+
+	// healthCheckFailureThreshold is the number of consecutive failed requests
+	// after which the scorecard API is reported as unreachable
+	healthCheckFailureThreshold = 3
+)
+
+// Ping checks that the scorecard API is reachable. Any response other than a
+// server error counts as reachable, so no scorecard data needs to be fetched.
+func (c *Client) Ping(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, c.apiEndpoint, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("scorecard API unreachable: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("scorecard API returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// HealthChecker reports whether the scorecard API is reachable. The result of
+// a request is reused for the check interval so that frequent probes do not
+// hit the API, and the check only fails once the API has been unreachable for
+// several consecutive requests.
+type HealthChecker struct {
+	client   *Client
+	interval time.Duration
+
+	mu        sync.Mutex
+	lastCheck time.Time
+	failures  int
+	lastErr   error
+
+	// now returns the current time, replaceable in tests
+	now func() time.Time
+}
+
+// NewHealthChecker creates a health checker for the given client that
+// queries the API at most once per interval
+func NewHealthChecker(client *Client, interval time.Duration) *HealthChecker {
+	return &HealthChecker{
+		client:   client,
+		interval: interval,
+		now:      time.Now,
+	}
+}
+
+// Check implements healthz.Checker
+func (h *HealthChecker) Check(req *http.Request) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if now := h.now(); h.lastCheck.IsZero() || now.Sub(h.lastCheck) >= h.interval {
+		h.lastCheck = now
+		if err := h.client.Ping(req.Context()); err != nil {
+			h.failures++
+			h.lastErr = err
+		} else {
+			h.failures = 0
+			h.lastErr = nil
+		}
+	}
+
+	if h.failures >= healthCheckFailureThreshold {
+		return h.lastErr
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scorecard
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestHealthChecker(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    int
+		checks    int
+		advance   time.Duration
+		wantErr   bool
+		wantCalls int32
+	}{
+		{
+			name:      "reachable",
+			status:    http.StatusOK,
+			checks:    5,
+			advance:   time.Minute,
+			wantCalls: 5,
+		},
+		{
+			name:      "client errors count as reachable",
+			status:    http.StatusNotFound,
+			checks:    5,
+			advance:   time.Minute,
+			wantCalls: 5,
+		},
+		{
+			name:      "single failure tolerated",
+			status:    http.StatusServiceUnavailable,
+			checks:    1,
+			advance:   time.Minute,
+			wantCalls: 1,
+		},
+		{
+			name:      "persistent failure reported",
+			status:    http.StatusServiceUnavailable,
+			checks:    3,
+			advance:   time.Minute,
+			wantErr:   true,
+			wantCalls: 3,
+		},
+		{
+			name:      "results reused within interval",
+			status:    http.StatusServiceUnavailable,
+			checks:    5,
+			advance:   time.Second,
+			wantCalls: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls.Add(1)
+				w.WriteHeader(tt.status)
+			}))
+			t.Cleanup(server.Close)
+
+			checker := NewHealthChecker(NewClient(WithAPIEndpoint(server.URL)), time.Minute)
+			now := time.Now()
+			checker.now = func() time.Time { return now }
+
+			var err error
+			for range tt.checks {
+				err = checker.Check(httptest.NewRequest(http.MethodGet, "/readyz", nil))
+				now = now.Add(tt.advance)
+			}
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Check() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := calls.Load(); got != tt.wantCalls {
+				t.Errorf("API requests = %d, want %d", got, tt.wantCalls)
+			}
+		})
+	}
+}
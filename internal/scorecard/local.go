@@ -0,0 +1,129 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scorecard
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// DefaultBinary is the default name of the scorecard CLI binary
+const DefaultBinary = "scorecard"
+
+// commandRunner runs a command and returns its standard output
+type commandRunner func(ctx context.Context, name string, args, env []string) ([]byte, error)
+
+// LocalRunner computes scorecard data by running the scorecard CLI against a
+// repository. Unlike the public API it works for repositories that have not
+// been scanned by the OpenSSF, including private ones.
+type LocalRunner struct {
+	binary  string
+	details bool
+	run     commandRunner
+}
+
+// LocalOption configures a LocalRunner
+type LocalOption func(*LocalRunner)
+
+// WithLocalDetails keeps the details of checks the CLI reports, as
+// WithDetails does for the API
+func WithLocalDetails(enabled bool) LocalOption {
+	return func(l *LocalRunner) {
+		l.details = enabled
+	}
+}
+
+// NewLocalRunner creates a runner that invokes the given scorecard binary
+func NewLocalRunner(binary string, opts ...LocalOption) *LocalRunner {
+	if binary == "" {
+		binary = DefaultBinary
+	}
+
+	l := &LocalRunner{
+		binary: binary,
+		run:    runCommand,
+	}
+	for _, opt := range opts {
+		opt(l)
+	}
+	return l
+}
+
+// GetScorecardData runs the scorecard CLI for a specific repository
+// The vcsPath should be in the format expected by the scorecard API (e.g., "github.com/org/repo")
+// A run failing for the repository, e.g. with a non-zero exit status or
+// output that is not a report, reports it as unavailable with ErrNotFound, as
+// the API does for repositories it has not scanned. A missing binary or a
+// cancelled context fail as they are.
+func (l *LocalRunner) GetScorecardData(
+	ctx context.Context, vcsPath, token string, opts ...FetchOption,
+) (*ScorecardData, error) {
+	args := []string{"--repo=" + vcsPath, "--format=json"}
+	if o := newFetchOptions(opts); o.commit != "" {
+		args = append(args, "--commit="+o.commit)
+	}
+
+	// The CLI reads credentials from the environment; set the variables for
+	// every provider it supports so the token works regardless of host.
+	var env []string
+	if token != "" {
+		env = append(env, "GITHUB_AUTH_TOKEN="+token, "GITLAB_AUTH_TOKEN="+token)
+	}
+
+	out, err := l.run(ctx, l.binary, args, env)
+	if err != nil {
+		if ctx.Err() != nil || errors.Is(err, exec.ErrNotFound) {
+			return nil, fmt.Errorf("failed to run scorecard for %s: %w", vcsPath, err)
+		}
+		return nil, fmt.Errorf("%w for %s: scorecard failed: %v", ErrNotFound, vcsPath, err)
+	}
+
+	var result APIResponse
+	if err := json.Unmarshal(out, &result); err != nil {
+		return nil, fmt.Errorf("%w for %s: failed to decode scorecard output: %v", ErrNotFound, vcsPath, err)
+	}
+
+	data := convertAPIResponse(&result, l.details)
+	data.Source = DataSourceLocal
+	return data, nil
+}
+
+// runCommand executes a command with the given extra environment and returns
+// its standard output. Standard error is included in the returned error.
+func runCommand(ctx context.Context, name string, args, env []string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Env = append(os.Environ(), env...)
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%w: %s", err, msg)
+		}
+		return nil, err
+	}
+
+	return stdout.Bytes(), nil
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scorecard
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"slices"
+	"testing"
+)
+
+func TestLocalRunner_GetScorecardData(t *testing.T) {
+	tests := []struct {
+		name         string
+		token        string
+		opts         []FetchOption
+		details      bool
+		output       string
+		runErr       error
+		wantArgs     []string
+		wantEnv      []string
+		wantScore    float64
+		wantDetails  bool
+		wantErr      bool
+		wantNotFound bool
+	}{
+		{
+			name:      "parses CLI output",
+			token:     "secret",
+			output:    testResponse,
+			wantEnv:   []string{"GITHUB_AUTH_TOKEN=secret", "GITLAB_AUTH_TOKEN=secret"},
+			wantScore: 7.5,
+		},
+		{
+			name:      "no token",
+			output:    testResponse,
+			wantScore: 7.5,
+		},
+		{
+			name:      "specific commit",
+			opts:      []FetchOption{AtCommit("abc123")},
+			output:    testResponse,
+			wantArgs:  []string{"--commit=abc123"},
+			wantScore: 7.5,
+		},
+		{
+			name:        "details",
+			details:     true,
+			output:      testResponse,
+			wantScore:   7.5,
+			wantDetails: true,
+		},
+		{
+			name:         "command fails",
+			runErr:       errors.New("exit status 1"),
+			wantErr:      true,
+			wantNotFound: true,
+		},
+		{
+			name:         "invalid output",
+			output:       "not json",
+			wantErr:      true,
+			wantNotFound: true,
+		},
+		{
+			name:    "binary missing",
+			runErr:  &exec.Error{Name: DefaultBinary, Err: exec.ErrNotFound},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotName string
+			var gotArgs, gotEnv []string
+			runner := NewLocalRunner("", WithLocalDetails(tt.details))
+			runner.run = func(_ context.Context, name string, args, env []string) ([]byte, error) {
+				gotName, gotArgs, gotEnv = name, args, env
+				return []byte(tt.output), tt.runErr
+			}
+
+			data, err := runner.GetScorecardData(context.Background(), "github.com/org/repo", tt.token, tt.opts...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetScorecardData() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if errors.Is(err, ErrNotFound) != tt.wantNotFound {
+				t.Errorf("GetScorecardData() error = %v, want ErrNotFound %v", err, tt.wantNotFound)
+			}
+
+			if gotName != DefaultBinary {
+				t.Errorf("ran %q, want %q", gotName, DefaultBinary)
+			}
+			wantArgs := append([]string{"--repo=github.com/org/repo", "--format=json"}, tt.wantArgs...)
+			if !slices.Equal(gotArgs, wantArgs) {
+				t.Errorf("args = %v, want %v", gotArgs, wantArgs)
+			}
+			if !slices.Equal(gotEnv, tt.wantEnv) {
+				t.Errorf("env = %v, want %v", gotEnv, tt.wantEnv)
+			}
+			if err != nil {
+				return
+			}
+
+			if data.Score != tt.wantScore || data.Commit != "abc123" || len(data.Checks) != 3 || data.Source != DataSourceLocal {
+				t.Errorf("GetScorecardData() = %+v, unexpected data", data)
+			}
+			if hasDetails := len(data.Checks[1].Details) > 0; hasDetails != tt.wantDetails {
+				t.Errorf("GetScorecardData() details = %+v, want details %v", data.Checks[1].Details, tt.wantDetails)
+			}
+		})
+	}
+}
+
+func TestLocalRunner_GetScorecardData_Cancelled(t *testing.T) {
+	runner := NewLocalRunner("")
+	runner.run = func(context.Context, string, []string, []string) ([]byte, error) {
+		return nil, errors.New("signal: killed")
+	}
+
+	// A run cut short by the caller says nothing about the repository
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := runner.GetScorecardData(ctx, "github.com/org/repo", ""); err == nil || errors.Is(err, ErrNotFound) {
+		t.Errorf("GetScorecardData() error = %v, want an error other than ErrNotFound", err)
+	}
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scorecard
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// DefaultRateLimitDelay is the time to wait before retrying a request
+// rejected by rate limiting when the API does not tell when to retry
+const DefaultRateLimitDelay = 5 * time.Minute
+
+// RateLimitError is returned when the scorecard API rejects a request because
+// of rate limiting, with the rate limit information of the response headers
+type RateLimitError struct {
+	// StatusCode is the HTTP status code returned by the API
+	StatusCode int
+
+	// Message is the error message from the API
+	Message string
+
+	// RetryAfter is the duration to wait before retrying, from the
+	// Retry-After header (if known)
+	RetryAfter time.Duration
+
+	// Limit is the rate limit (requests per period), from the
+	// X-RateLimit-Limit header (if known)
+	Limit int
+
+	// Remaining is the number of requests remaining, from the
+	// X-RateLimit-Remaining header
+	Remaining int
+
+	// ResetTime is when the rate limit resets, from the X-RateLimit-Reset
+	// header (if known)
+	ResetTime time.Time
+}
+
+// Error implements the error interface
+func (e *RateLimitError) Error() string {
+	if e.RetryAfter > 0 {
+		return fmt.Sprintf("scorecard API rate limit exceeded with status %d: %s (retry after %v)",
+			e.StatusCode, e.Message, e.RetryAfter)
+	}
+	if !e.ResetTime.IsZero() {
+		return fmt.Sprintf("scorecard API rate limit exceeded with status %d: %s (resets at %v)",
+			e.StatusCode, e.Message, e.ResetTime.Format(time.RFC3339))
+	}
+	return fmt.Sprintf("scorecard API rate limit exceeded with status %d: %s", e.StatusCode, e.Message)
+}
+
+// Delay returns the duration to wait before retrying: the Retry-After
+// duration, else the time until the rate limit resets, else
+// DefaultRateLimitDelay
+func (e *RateLimitError) Delay() time.Duration {
+	if e.RetryAfter > 0 {
+		return e.RetryAfter
+	}
+	if !e.ResetTime.IsZero() {
+		if duration := time.Until(e.ResetTime); duration > 0 {
+			return duration
+		}
+	}
+	return DefaultRateLimitDelay
+}
+
+// rateLimitError returns a RateLimitError if resp was rejected because of rate
+// limiting, i.e. with status 429, or with status 403 and no requests
+// remaining, and nil otherwise. It consumes the body of rejected responses.
+func rateLimitError(resp *http.Response) error {
+	remaining, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Remaining"))
+	exhausted := err == nil && remaining == 0
+	if resp.StatusCode != http.StatusTooManyRequests &&
+		(resp.StatusCode != http.StatusForbidden || !exhausted) {
+		return nil
+	}
+
+	body, _ := io.ReadAll(resp.Body)
+	rlErr := &RateLimitError{
+		StatusCode: resp.StatusCode,
+		Message:    string(body),
+		Remaining:  remaining,
+	}
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+		rlErr.RetryAfter = time.Duration(seconds) * time.Second
+	}
+	if limit, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Limit")); err == nil {
+		rlErr.Limit = limit
+	}
+	if reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		rlErr.ResetTime = time.Unix(reset, 0)
+	}
+	return rlErr
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scorecard
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestGetScorecardData_RateLimit(t *testing.T) {
+	reset := time.Now().Add(time.Hour).Truncate(time.Second)
+
+	tests := []struct {
+		name          string
+		status        int
+		headers       map[string]string
+		expectLimited bool
+		expectRetry   time.Duration
+		expectReset   time.Time
+		expectDelay   time.Duration
+	}{
+		{
+			name:          "too many requests with retry after",
+			status:        http.StatusTooManyRequests,
+			headers:       map[string]string{"Retry-After": "90"},
+			expectLimited: true,
+			expectRetry:   90 * time.Second,
+			expectDelay:   90 * time.Second,
+		},
+		{
+			name:   "forbidden with exhausted rate limit",
+			status: http.StatusForbidden,
+			headers: map[string]string{
+				"X-RateLimit-Limit":     "60",
+				"X-RateLimit-Remaining": "0",
+				"X-RateLimit-Reset":     strconv.FormatInt(reset.Unix(), 10),
+			},
+			expectLimited: true,
+			expectReset:   reset,
+		},
+		{
+			name:          "too many requests without headers",
+			status:        http.StatusTooManyRequests,
+			expectLimited: true,
+			expectDelay:   DefaultRateLimitDelay,
+		},
+		{
+			name:    "forbidden with requests remaining",
+			status:  http.StatusForbidden,
+			headers: map[string]string{"X-RateLimit-Remaining": "10"},
+		},
+		{
+			name:    "server error",
+			status:  http.StatusInternalServerError,
+			headers: map[string]string{"X-RateLimit-Remaining": "0"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				for key, value := range tt.headers {
+					w.Header().Set(key, value)
+				}
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte("slow down"))
+			}))
+			defer server.Close()
+
+			client := NewClient(WithAPIEndpoint(server.URL))
+			_, err := client.GetScorecardData(context.Background(), "github.com/org/repo", "")
+			if err == nil {
+				t.Fatal("GetScorecardData() expected an error")
+			}
+
+			var rateLimitErr *RateLimitError
+			if limited := errors.As(err, &rateLimitErr); limited != tt.expectLimited {
+				t.Fatalf("GetScorecardData() error = %v, rate limited %v, want %v", err, limited, tt.expectLimited)
+			}
+			if !tt.expectLimited {
+				return
+			}
+			if rateLimitErr.StatusCode != tt.status || rateLimitErr.Message != "slow down" {
+				t.Errorf("RateLimitError = %+v, want status %d and the response body", rateLimitErr, tt.status)
+			}
+			if rateLimitErr.RetryAfter != tt.expectRetry {
+				t.Errorf("RetryAfter = %v, want %v", rateLimitErr.RetryAfter, tt.expectRetry)
+			}
+			if !rateLimitErr.ResetTime.Equal(tt.expectReset) {
+				t.Errorf("ResetTime = %v, want %v", rateLimitErr.ResetTime, tt.expectReset)
+			}
+			if tt.expectDelay > 0 && rateLimitErr.Delay() != tt.expectDelay {
+				t.Errorf("Delay() = %v, want %v", rateLimitErr.Delay(), tt.expectDelay)
+			}
+			if !tt.expectReset.IsZero() {
+				if delay := rateLimitErr.Delay(); delay <= 0 || delay > time.Hour {
+					t.Errorf("Delay() = %v, want the time until %v", delay, tt.expectReset)
+				}
+			}
+		})
+	}
+}
+
+func TestGetScorecardDataBatch_RateLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIEndpoint(server.URL))
+	_, err := client.GetScorecardDataBatch(context.Background(), []string{"github.com/org/repo"}, "")
+
+	var rateLimitErr *RateLimitError
+	if !errors.As(err, &rateLimitErr) || rateLimitErr.RetryAfter != 30*time.Second {
+		t.Fatalf("GetScorecardDataBatch() error = %v, want a rate limit error retrying after 30s", err)
+	}
+}
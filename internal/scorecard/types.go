@@ -1,6 +1,9 @@
 package scorecard
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 // ScorecardData represents the scorecard data for a repository
 type ScorecardData struct {
@@ -16,6 +19,59 @@ type ScorecardData struct {
 	// Repository metadata
 	Repository string
 	Commit     string
+
+	// Source identifies where the data came from, one of the DataSource
+	// constants
+	Source string
+}
+
+const (
+	// DataSourceAPI marks data from the public OpenSSF Scorecard API
+	DataSourceAPI = "api"
+
+	// DataSourceMirror marks data from another scorecard API endpoint
+	DataSourceMirror = "mirror"
+
+	// DataSourceLocal marks data computed by the scorecard CLI
+	DataSourceLocal = "local"
+)
+
+// EndpointDataSource returns the DataSource constant for data fetched from a
+// scorecard API endpoint, the public API if empty
+func EndpointDataSource(endpoint string) string {
+	if endpoint == "" || strings.TrimSuffix(endpoint, "/") == DefaultAPIEndpoint {
+		return DataSourceAPI
+	}
+	return DataSourceMirror
+}
+
+// DefaultPassThreshold is the lowest score of a check that passes
+const DefaultPassThreshold = 5
+
+const (
+	// CheckStatusPass marks checks scoring at least the pass threshold
+	CheckStatusPass = "Pass"
+
+	// CheckStatusFail marks checks scoring below the pass threshold
+	CheckStatusFail = "Fail"
+
+	// CheckStatusInconclusive marks checks that scorecard could not run,
+	// which it reports with a score of -1
+	CheckStatusInconclusive = "Inconclusive"
+)
+
+// CheckStatus classifies a check score as CheckStatusPass from passThreshold
+// on, CheckStatusFail below it, and CheckStatusInconclusive for negative
+// scores of checks that could not run
+func CheckStatus(score, passThreshold int) string {
+	switch {
+	case score < 0:
+		return CheckStatusInconclusive
+	case score < passThreshold:
+		return CheckStatusFail
+	default:
+		return CheckStatusPass
+	}
 }
 
 // Check represents an individual scorecard check result
@@ -24,6 +80,42 @@ type Check struct {
 	Score  int
 	Status string
 	Reason string
+
+	// DocumentationURL links to the check documentation and remediation
+	DocumentationURL string
+
+	// Details are the findings behind the score, only set by clients
+	// created WithDetails
+	Details []CheckDetail
+}
+
+// Levels of check details
+const (
+	CheckDetailInfo  = "Info"
+	CheckDetailWarn  = "Warn"
+	CheckDetailDebug = "Debug"
+)
+
+// CheckDetail is a finding behind a check score, such as a warning about a
+// branch without protection
+type CheckDetail struct {
+	// Level is one of the CheckDetail constants, or empty if scorecard did
+	// not prefix the finding with a level
+	Level   string
+	Message string
+}
+
+// ParseCheckDetail splits a detail of the scorecard JSON format, such as
+// "Warn: no SAST tool detected", into its level and message
+func ParseCheckDetail(detail string) CheckDetail {
+	level, message, ok := strings.Cut(detail, ":")
+	switch level {
+	case CheckDetailInfo, CheckDetailWarn, CheckDetailDebug:
+		if ok {
+			return CheckDetail{Level: level, Message: strings.TrimSpace(message)}
+		}
+	}
+	return CheckDetail{Message: detail}
 }
 
 // APIResponse represents the raw response from the OpenSSF Scorecard API
@@ -51,6 +143,7 @@ type APICheck struct {
 	Name          string           `json:"name"`
 	Score         int              `json:"score"`
 	Reason        string           `json:"reason"`
+	Details       []string         `json:"details"`
 	Documentation APIDocumentation `json:"documentation"`
 }
 
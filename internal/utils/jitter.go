@@ -28,28 +28,41 @@ func JitterRequeue(defaultDuration time.Duration, maxJitterPercent int, log logr
 	}
 }
 
+// ClampJitterPercent limits a jitter percentage to the range 0-100, so that
+// jittered durations are never negative.
+func ClampJitterPercent(maxJitterPercent int) int {
+	return min(max(maxJitterPercent, 0), 100)
+}
+
 // Jitter accepts a Duration and maximum percentage to jitter (as an int),
 // and returns a random Duration in the range t +/- maxJitterPercent.
+// The percentage is clamped to 0-100, and 0 returns t unchanged.
 func Jitter(t time.Duration, maxJitterPercent int) (time.Duration, error) {
-	// Get the maximum jitter length as a duration.
-	// Max = t * maxJitterPercent / 100.
-
-	// Decimal representation of the maximum jitter. E.g. 25% --> 0.25.
-	jitterMultiplier := float64(maxJitterPercent) / 100.00
+	return JitterWithRand(t, maxJitterPercent, nil)
+}
 
-	// Maximum length of time, in milliseconds, which we can add or subtract from our target time.
-	jitterDuration := int64(
-		float64(t.Milliseconds()) * jitterMultiplier)
+// JitterWithRand is Jitter drawing from the given random source, which makes
+// the result deterministic for a seeded source. A nil source uses the global one.
+func JitterWithRand(t time.Duration, maxJitterPercent int, rnd *rand.Rand) (time.Duration, error) {
+	if t < 0 {
+		return t, fmt.Errorf("cannot jitter negative duration %v", t)
+	}
 
-	// Maximum length of jitter time as a Go Duration.
-	maxJitter, err := time.ParseDuration(fmt.Sprintf("%dms", jitterDuration))
-	if err != nil {
-		return t, err
+	// Maximum length of time which we can add or subtract from our target time.
+	// Max = t * maxJitterPercent / 100.
+	maxJitter := time.Duration(float64(t) * float64(ClampJitterPercent(maxJitterPercent)) / 100.00)
+	if maxJitter <= 0 {
+		return t, nil
 	}
 
-	// Calcluate the minimum time we have to wait.
-	minDuration := t - maxJitter
+	// Pick a random offset in [-maxJitter, +maxJitter].
+	n := int64(2*maxJitter) + 1
+	var offset int64
+	if rnd != nil {
+		offset = rnd.Int63n(n)
+	} else {
+		offset = rand.Int63n(n) // nolint:gosec // rand not used for crypto.
+	}
 
-	// Set the final duration to the min + a random duration between 0 and our max jitter.
-	return minDuration + time.Duration(rand.Int63n(int64(maxJitter))), nil // nolint:gosec // rand not used for crypto.
+	return t - maxJitter + time.Duration(offset), nil
 }
@@ -0,0 +1,116 @@
+package utils
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+func TestJitter(t *testing.T) {
+	tests := []struct {
+		name             string
+		duration         time.Duration
+		maxJitterPercent int
+		expectedMin      time.Duration
+		expectedMax      time.Duration
+		expectErr        bool
+	}{
+		{
+			name:             "0% returns the exact interval",
+			duration:         time.Hour,
+			maxJitterPercent: 0,
+			expectedMin:      time.Hour,
+			expectedMax:      time.Hour,
+		},
+		{
+			name:             "50%",
+			duration:         time.Hour,
+			maxJitterPercent: 50,
+			expectedMin:      30 * time.Minute,
+			expectedMax:      90 * time.Minute,
+		},
+		{
+			name:             "100%",
+			duration:         time.Hour,
+			maxJitterPercent: 100,
+			expectedMin:      0,
+			expectedMax:      2 * time.Hour,
+		},
+		{
+			name:             "negative percentage is clamped to 0%",
+			duration:         time.Hour,
+			maxJitterPercent: -20,
+			expectedMin:      time.Hour,
+			expectedMax:      time.Hour,
+		},
+		{
+			name:             "percentage above 100 is clamped to 100%",
+			duration:         time.Hour,
+			maxJitterPercent: 250,
+			expectedMin:      0,
+			expectedMax:      2 * time.Hour,
+		},
+		{
+			name:             "zero duration",
+			duration:         0,
+			maxJitterPercent: 10,
+			expectedMin:      0,
+			expectedMax:      0,
+		},
+		{
+			name:             "negative duration",
+			duration:         -time.Hour,
+			maxJitterPercent: 10,
+			expectErr:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for range 1000 {
+				got, err := Jitter(tt.duration, tt.maxJitterPercent)
+				if (err != nil) != tt.expectErr {
+					t.Fatalf("Jitter() error = %v, expectErr %v", err, tt.expectErr)
+				}
+				if tt.expectErr {
+					return
+				}
+				if got < tt.expectedMin || got > tt.expectedMax {
+					t.Fatalf("Jitter() = %v, want within [%v, %v]", got, tt.expectedMin, tt.expectedMax)
+				}
+			}
+		})
+	}
+}
+
+func TestJitterWithRand_Deterministic(t *testing.T) {
+	first, err := JitterWithRand(time.Hour, 10, rand.New(rand.NewSource(42)))
+	if err != nil {
+		t.Fatalf("JitterWithRand() unexpected error: %v", err)
+	}
+	second, err := JitterWithRand(time.Hour, 10, rand.New(rand.NewSource(42)))
+	if err != nil {
+		t.Fatalf("JitterWithRand() unexpected error: %v", err)
+	}
+	if first != second {
+		t.Errorf("JitterWithRand() = %v and %v, want the same value for the same seed", first, second)
+	}
+}
+
+func TestClampJitterPercent(t *testing.T) {
+	tests := map[int]int{-5: 0, 0: 0, 50: 50, 100: 100, 101: 100}
+	for in, expected := range tests {
+		if got := ClampJitterPercent(in); got != expected {
+			t.Errorf("ClampJitterPercent(%d) = %d, want %d", in, got, expected)
+		}
+	}
+}
+
+func TestJitterRequeue(t *testing.T) {
+	result := JitterRequeue(time.Hour, 0, logr.Discard())
+	if result.RequeueAfter != time.Hour {
+		t.Errorf("JitterRequeue() RequeueAfter = %v, want %v", result.RequeueAfter, time.Hour)
+	}
+}
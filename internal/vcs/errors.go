@@ -19,10 +19,15 @@ package vcs
 import (
 	"errors"
 	"fmt"
+	"io"
+	"net"
 	"strings"
 	"time"
 )
 
+// ErrNotFound indicates that a requested VCS resource does not exist
+var ErrNotFound = errors.New("not found")
+
 // RateLimitError represents an error due to VCS API rate limiting
 type RateLimitError struct {
 	// Provider is the VCS provider that returned the rate limit
@@ -42,6 +47,11 @@ type RateLimitError struct {
 
 	// ResetTime is when the rate limit resets
 	ResetTime time.Time
+
+	// Secondary is set for secondary rate limits, which GitHub imposes on
+	// bursts of requests rather than on the request quota, and which last
+	// longer when hit repeatedly
+	Secondary bool
 }
 
 // Error implements the error interface
@@ -90,6 +100,13 @@ func IsRateLimitError(err error) bool {
 	return false
 }
 
+// IsSecondaryRateLimitError checks if an error is caused by a secondary rate
+// limit
+func IsSecondaryRateLimitError(err error) bool {
+	var rateLimitErr *RateLimitError
+	return errors.As(err, &rateLimitErr) && rateLimitErr.Secondary
+}
+
 // GetRetryAfter extracts the retry duration from a rate limit error
 // Returns a default duration if none is specified
 func GetRetryAfter(err error) time.Duration {
@@ -140,3 +157,113 @@ func (e *RateLimitError) WithRateLimitInfo(limit, remaining int) *RateLimitError
 	e.Remaining = remaining
 	return e
 }
+
+// TransientError represents a VCS API failure that is likely to go away on
+// retry, such as a server error
+type TransientError struct {
+	// Provider is the VCS provider that returned the error
+	Provider ProviderType
+
+	// StatusCode is the HTTP status code returned by the API
+	StatusCode int
+
+	// Message is the error message from the API
+	Message string
+}
+
+// Error implements the error interface
+func (e *TransientError) Error() string {
+	return fmt.Sprintf("%s API returned status %d: %s", e.Provider, e.StatusCode, e.Message)
+}
+
+// NewTransientError creates a new transient error
+func NewTransientError(provider ProviderType, statusCode int, message string) *TransientError {
+	return &TransientError{
+		Provider:   provider,
+		StatusCode: statusCode,
+		Message:    message,
+	}
+}
+
+// IsTransientError checks if an error is a temporary VCS failure, such as a
+// server error, a timeout or a network failure. Rate limit errors are not
+// considered transient, as they are retried after the rate limit resets.
+func IsTransientError(err error) bool {
+	if err == nil || IsRateLimitError(err) {
+		return false
+	}
+
+	var transientErr *TransientError
+	if errors.As(err, &transientErr) {
+		return true
+	}
+
+	// Network failures: connection errors, DNS lookups and timeouts
+	var opErr *net.OpError
+	var dnsErr *net.DNSError
+	var netErr net.Error
+	if errors.As(err, &opErr) || errors.As(err, &dnsErr) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return true
+	}
+
+	return errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// AuthError represents a VCS API request rejected because the credentials
+// are missing, invalid, expired or lack the required permissions
+type AuthError struct {
+	// Provider is the VCS provider that rejected the credentials
+	Provider ProviderType
+
+	// StatusCode is the HTTP status code returned by the API
+	StatusCode int
+
+	// Message is the error message from the API
+	Message string
+}
+
+// Error implements the error interface
+func (e *AuthError) Error() string {
+	return fmt.Sprintf("%s API authentication failed with status %d: %s", e.Provider, e.StatusCode, e.Message)
+}
+
+// NewAuthError creates a new authentication error
+func NewAuthError(provider ProviderType, statusCode int, message string) *AuthError {
+	return &AuthError{
+		Provider:   provider,
+		StatusCode: statusCode,
+		Message:    message,
+	}
+}
+
+// IsAuthError checks if an error is an authentication error
+func IsAuthError(err error) bool {
+	var authErr *AuthError
+	return errors.As(err, &authErr)
+}
+
+// ScopeError represents a VCS token that is accepted by the API but lacks
+// the scope required to list repositories, which the API reports as an empty
+// list rather than an error
+type ScopeError struct {
+	// Provider is the VCS provider that issued the token
+	Provider ProviderType
+
+	// Scope is the missing scope
+	Scope string
+
+	// Granted are the scopes granted to the token
+	Granted []string
+}
+
+// Error implements the error interface
+func (e *ScopeError) Error() string {
+	return fmt.Sprintf("%s token lacks the %s scope (granted scopes: %q), repositories cannot be listed",
+		e.Provider, e.Scope, strings.Join(e.Granted, ", "))
+}
+
+// IsScopeError checks if an error is caused by a token missing a scope
+func IsScopeError(err error) bool {
+	var scopeErr *ScopeError
+	return errors.As(err, &scopeErr)
+}
@@ -17,8 +17,11 @@ limitations under the License.
 package vcs
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"net"
+	"net/url"
 	"testing"
 	"time"
 )
@@ -212,3 +215,102 @@ func TestRateLimitError_Chaining(t *testing.T) {
 		t.Errorf("Remaining = %v, want %v", err.Remaining, 0)
 	}
 }
+
+func TestIsTransientError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{
+			name:     "nil error",
+			err:      nil,
+			expected: false,
+		},
+		{
+			name:     "TransientError type",
+			err:      NewTransientError(ProviderTypeGitHub, 502, "bad gateway"),
+			expected: true,
+		},
+		{
+			name:     "wrapped TransientError",
+			err:      fmt.Errorf("failed to list repositories: %w", NewTransientError(ProviderTypeGitea, 503, "unavailable")),
+			expected: true,
+		},
+		{
+			name:     "connection refused",
+			err:      &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")},
+			expected: true,
+		},
+		{
+			name:     "DNS failure",
+			err:      fmt.Errorf("request failed: %w", &net.DNSError{Err: "no such host", Name: "github.example.com"}),
+			expected: true,
+		},
+		{
+			name:     "timeout",
+			err:      &url.Error{Op: "Get", URL: "https://api.github.com", Err: context.DeadlineExceeded},
+			expected: true,
+		},
+		{
+			name:     "rate limit error",
+			err:      NewRateLimitError(ProviderTypeGitHub, "rate limit exceeded"),
+			expected: false,
+		},
+		{
+			name:     "generic error",
+			err:      errors.New("401 Bad credentials"),
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsTransientError(tt.err); got != tt.expected {
+				t.Errorf("IsTransientError() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestIsAuthError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{
+			name:     "nil error",
+			err:      nil,
+			expected: false,
+		},
+		{
+			name:     "AuthError type",
+			err:      NewAuthError(ProviderTypeGitHub, 401, "Bad credentials"),
+			expected: true,
+		},
+		{
+			name:     "wrapped AuthError",
+			err:      fmt.Errorf("failed to list repositories: %w", NewAuthError(ProviderTypeGitea, 403, "forbidden")),
+			expected: true,
+		},
+		{
+			name:     "rate limit error",
+			err:      NewRateLimitError(ProviderTypeGitHub, "rate limit exceeded"),
+			expected: false,
+		},
+		{
+			name:     "transient error",
+			err:      NewTransientError(ProviderTypeGitHub, 500, "internal error"),
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsAuthError(tt.err); got != tt.expected {
+				t.Errorf("IsAuthError() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vcs
+
+import (
+	"context"
+	"time"
+)
+
+// Reasons a repository is excluded from the results of GetRepositories
+const (
+	ExclusionReasonPrivate  = "private"
+	ExclusionReasonPublic   = "public"
+	ExclusionReasonArchived = "archived"
+	ExclusionReasonDisabled = "disabled"
+	ExclusionReasonFork     = "fork"
+	ExclusionReasonMirror   = "mirror"
+	ExclusionReasonEmpty    = "empty"
+	ExclusionReasonInactive = "inactive"
+)
+
+// ExclusionHandler is called for every repository a provider leaves out of
+// the results of GetRepositories, with the reason it was excluded
+type ExclusionHandler func(repository, reason string)
+
+// exclusionHandlerKey is the context key for the ExclusionHandler
+type exclusionHandlerKey struct{}
+
+// WithExclusionHandler returns a context that reports repositories excluded
+// by providers to handler
+func WithExclusionHandler(ctx context.Context, handler ExclusionHandler) context.Context {
+	return context.WithValue(ctx, exclusionHandlerKey{}, handler)
+}
+
+// limitExceeded reports whether listing has collected more repositories than
+// the configured maximum, zero meaning no limit
+func limitExceeded(count, maxRepositories int) bool {
+	return maxRepositories > 0 && count > maxRepositories
+}
+
+// inactive reports whether a repository last active at lastActivity has been
+// inactive for longer than maxAge, zero meaning no limit. Repositories without
+// a known activity time are kept.
+func inactive(lastActivity time.Time, maxAge time.Duration) bool {
+	return maxAge > 0 && !lastActivity.IsZero() && time.Since(lastActivity) > maxAge
+}
+
+// visibilityExclusionReason returns why a repository is left out of the
+// results for its visibility, or an empty string if it is included
+func visibilityExclusionReason(visibility Visibility, private bool) string {
+	switch {
+	case private && (visibility == "" || visibility == VisibilityPublic):
+		return ExclusionReasonPrivate
+	case !private && visibility == VisibilityPrivate:
+		return ExclusionReasonPublic
+	}
+	return ""
+}
+
+// reportExcluded passes an excluded repository to the handler in ctx, if any
+func reportExcluded(ctx context.Context, repository, reason string) {
+	if handler, ok := ctx.Value(exclusionHandlerKey{}).(ExclusionHandler); ok {
+		handler(repository, reason)
+	}
+}
@@ -0,0 +1,277 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vcs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultGiteaURL is the default Gitea instance
+	DefaultGiteaURL = "https://gitea.com"
+
+	// giteaPageSize is the number of repositories requested per page
+	giteaPageSize = 50
+)
+
+// GiteaProvider implements the Provider interface for Gitea
+type GiteaProvider struct {
+	client       *http.Client
+	baseURL      string
+	token        string
+	username     string
+	scorecardURL string
+	ownerType    OwnerType
+	visibility   Visibility
+
+	// maxRepositories stops pagination once exceeded, zero meaning no limit
+	maxRepositories int
+
+	// maxAge leaves out repositories not updated for longer, zero meaning no
+	// limit. Gitea does not report pushes separately.
+	maxAge time.Duration
+}
+
+// giteaRepository is the subset of the Gitea repository API object we use
+type giteaRepository struct {
+	Name          string    `json:"name"`
+	FullName      string    `json:"full_name"`
+	HTMLURL       string    `json:"html_url"`
+	DefaultBranch string    `json:"default_branch"`
+	Private       bool      `json:"private"`
+	Archived      bool      `json:"archived"`
+	Fork          bool      `json:"fork"`
+	Mirror        bool      `json:"mirror"`
+	Empty         bool      `json:"empty"`
+	Updated       time.Time `json:"updated_at"`
+}
+
+// NewGiteaProvider creates a new Gitea provider
+func NewGiteaProvider(config *Config) (Provider, error) {
+	baseURL, u, err := parseInstanceURL(config.BaseURL, DefaultGiteaURL, "/api/v1")
+	if err != nil {
+		return nil, err
+	}
+
+	transport, err := baseTransport(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GiteaProvider{
+		client:       &http.Client{Transport: transport},
+		baseURL:      baseURL,
+		token:        config.AccessToken(),
+		username:     config.Username,
+		scorecardURL: u.Host,
+		ownerType:    config.OwnerType,
+		visibility:   config.Visibility,
+
+		maxRepositories: config.MaxRepositories,
+		maxAge:          config.MaxRepositoryAge,
+	}, nil
+}
+
+// GetRepositories fetches all repositories of the configured visibility for a
+// Gitea organization, or a user account if the provider is configured for users
+func (p *GiteaProvider) GetRepositories(ctx context.Context, organization string) ([]string, error) {
+	repos, _, err := p.ResumeRepositories(ctx, organization, nil)
+	return repos, err
+}
+
+// ResumeRepositories lists repositories like GetRepositories, starting at the
+// page of cursor unless it is nil
+func (p *GiteaProvider) ResumeRepositories(
+	ctx context.Context, organization string, cursor *ListCursor,
+) ([]string, *ListCursor, error) {
+	first := 1
+	var allRepos []string
+	if cursor != nil {
+		first, allRepos = cursor.Page, slices.Clone(cursor.Repositories)
+	}
+
+	owners := "orgs"
+	if p.ownerType == OwnerTypeUser {
+		owners = "users"
+	}
+
+	for page := first; ; page++ {
+		var repos []giteaRepository
+		path := fmt.Sprintf("/%s/%s/repos?page=%d&limit=%d", owners, url.PathEscape(organization), page, giteaPageSize)
+		if err := p.get(ctx, path, &repos); err != nil {
+			if errors.Is(err, ErrNotFound) && p.ownerType != OwnerTypeUser {
+				return nil, nil, fmt.Errorf("%w, set the owner type to %q for personal accounts", err, OwnerTypeUser)
+			}
+			return interrupted(err, &ListCursor{Page: page, Repositories: allRepos})
+		}
+
+		// Filter and collect repository names
+		for i := range repos {
+			if reason := p.exclusionReason(&repos[i]); reason != "" {
+				reportExcluded(ctx, repos[i].Name, reason)
+				continue
+			}
+			allRepos = append(allRepos, repos[i].Name)
+		}
+
+		if len(repos) < giteaPageSize || limitExceeded(len(allRepos), p.maxRepositories) {
+			break
+		}
+	}
+
+	return allRepos, nil, nil
+}
+
+// GetRepositoryDetails fetches detailed information about a specific repository
+func (p *GiteaProvider) GetRepositoryDetails(ctx context.Context, organization, repository string) (*Repository, error) {
+	var repo giteaRepository
+	path := fmt.Sprintf("/repos/%s/%s", url.PathEscape(organization), url.PathEscape(repository))
+	if err := p.get(ctx, path, &repo); err != nil {
+		return nil, err
+	}
+
+	return p.convertToRepository(&repo), nil
+}
+
+// ResolveCommit returns the SHA of the commit at the head of a branch
+func (p *GiteaProvider) ResolveCommit(ctx context.Context, organization, repository, branch string) (string, error) {
+	var b struct {
+		Commit struct {
+			ID string `json:"id"`
+		} `json:"commit"`
+	}
+	path := fmt.Sprintf("/repos/%s/%s/branches/%s",
+		url.PathEscape(organization), url.PathEscape(repository), url.PathEscape(branch))
+	if err := p.get(ctx, path, &b); err != nil {
+		return "", err
+	}
+
+	return b.Commit.ID, nil
+}
+
+// GetProviderType returns the provider type
+func (p *GiteaProvider) GetProviderType() ProviderType {
+	return ProviderTypeGitea
+}
+
+// GetScorecardURL returns the OpenSSF Scorecard URL for a Gitea repository,
+// addressed by the host of the configured instance
+func (p *GiteaProvider) GetScorecardURL(organization, repository string) string {
+	return fmt.Sprintf("%s/%s/%s", p.scorecardURL, organization, repository)
+}
+
+// get performs an API request and decodes the JSON response into v
+func (p *GiteaProvider) get(ctx context.Context, path string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.baseURL+"/api/v1"+path, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	switch {
+	case p.username != "":
+		req.SetBasicAuth(p.username, p.token)
+	case p.token != "":
+		req.Header.Set("Authorization", "token "+p.token)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to query Gitea API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return p.handleError(resp)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("failed to decode Gitea API response: %w", err)
+	}
+	return nil
+}
+
+// handleError maps Gitea API error responses to internal error types
+func (p *GiteaProvider) handleError(resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	message := strings.TrimSpace(string(body))
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		rlErr := NewRateLimitError(ProviderTypeGitea, message)
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+			rlErr.WithRetryAfter(time.Duration(seconds) * time.Second)
+		}
+		return rlErr
+	}
+
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		return NewAuthError(ProviderTypeGitea, resp.StatusCode, message)
+	}
+
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return NewTransientError(ProviderTypeGitea, resp.StatusCode, message)
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("gitea API returned status %d: %s: %w", resp.StatusCode, message, ErrNotFound)
+	}
+
+	return fmt.Errorf("gitea API returned status %d: %s", resp.StatusCode, message)
+}
+
+// exclusionReason returns why a repository is left out of the results, or an
+// empty string if it is included
+func (p *GiteaProvider) exclusionReason(repo *giteaRepository) string {
+	if reason := visibilityExclusionReason(p.visibility, repo.Private); reason != "" {
+		return reason
+	}
+	switch {
+	case repo.Archived:
+		return ExclusionReasonArchived
+	case repo.Fork:
+		return ExclusionReasonFork
+	case repo.Mirror:
+		return ExclusionReasonMirror
+	case repo.Empty:
+		return ExclusionReasonEmpty
+	case inactive(repo.Updated, p.maxAge):
+		return ExclusionReasonInactive
+	}
+	return ""
+}
+
+// convertToRepository converts a Gitea repository to the generic Repository type
+func (p *GiteaProvider) convertToRepository(repo *giteaRepository) *Repository {
+	return &Repository{
+		Name:          repo.Name,
+		FullName:      repo.FullName,
+		URL:           repo.HTMLURL,
+		DefaultBranch: repo.DefaultBranch,
+		IsPrivate:     repo.Private,
+		IsArchived:    repo.Archived,
+		IsFork:        repo.Fork,
+	}
+}
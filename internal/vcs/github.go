@@ -18,13 +18,21 @@ package vcs
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
+	"slices"
 	"strings"
+	"time"
 
+	"github.com/bradleyfalzon/ghinstallation/v2"
 	"github.com/google/go-github/v80/github"
 	"golang.org/x/oauth2"
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/time/rate"
+
+	"github.com/giantswarm/openssf-scorecard-exporter/internal/httpclient"
 )
 
 const (
@@ -33,78 +41,431 @@ const (
 
 	// DefaultGitHubScorecardURL is the base URL for GitHub repositories in OpenSSF Scorecard
 	DefaultGitHubScorecardURL = "github.com"
+
+	// gitHubPageConcurrency bounds the number of repository pages fetched concurrently
+	gitHubPageConcurrency = 4
 )
 
+// gitHubRetryBackoff is the delay before retrying a request that failed with
+// a server error, replaceable in tests
+var gitHubRetryBackoff = httpclient.DefaultRetryBackoff
+
 // GitHubProvider implements the Provider interface for GitHub
 type GitHubProvider struct {
 	client       *github.Client
 	scorecardURL string
+	ownerType    OwnerType
+	visibility   Visibility
+
+	// affiliations lists repositories through the authenticated user when
+	// set, see Config.Affiliations
+	affiliations []Affiliation
+
+	// team lists the repositories of a team of the organization when set,
+	// see Config.Team
+	team string
+
+	// graphQLURL is the GraphQL API endpoint, empty when the REST API is used
+	graphQLURL string
+
+	// maxRepositories stops pagination once exceeded, zero meaning no limit
+	maxRepositories int
+
+	// maxAge leaves out repositories not pushed to for longer, zero meaning
+	// no limit
+	maxAge time.Duration
+
+	// tokenKind is the kind of credentials the provider authenticates with,
+	// empty when only a response can tell, see ProbeToken
+	tokenKind string
+
+	// searchLimiter paces requests to the search API, nil meaning no limit
+	searchLimiter *rate.Limiter
 }
 
 // NewGitHubProvider creates a new GitHub provider
 func NewGitHubProvider(config *Config) (Provider, error) {
-	var tc *http.Client
-	if config.Token != "" {
-		ctx := context.Background()
-		ts := oauth2.StaticTokenSource(
-			&oauth2.Token{AccessToken: config.Token},
-		)
-		tc = oauth2.NewClient(ctx, ts)
+	var baseURL *url.URL
+	if config.BaseURL != "" {
+		var err error
+		if baseURL, err = parseGitHubBaseURL(config.BaseURL); err != nil {
+			return nil, err
+		}
+	}
+
+	tc, err := newGitHubHTTPClient(config)
+	if err != nil {
+		return nil, err
 	}
 
 	client := github.NewClient(tc)
+	scorecardURL := DefaultGitHubScorecardURL
+	graphQLURL := DefaultGitHubAPIURL + "graphql"
 
-	if config.BaseURL != "" {
-		baseURL := config.BaseURL
-		// Ensure base URL ends with a slash for go-github
-		if !strings.HasSuffix(baseURL, "/") {
-			baseURL += "/"
+	if baseURL != nil {
+		client.BaseURL = baseURL
+		scorecardURL = gitHubScorecardHost(baseURL)
+		graphQLURL = gitHubGraphQLURL(baseURL)
+	}
+
+	provider := &GitHubProvider{
+		client:       client,
+		scorecardURL: scorecardURL,
+		ownerType:    config.OwnerType,
+		visibility:   config.Visibility,
+		affiliations: config.Affiliations,
+		team:         config.Team,
+
+		maxRepositories: config.MaxRepositories,
+		maxAge:          config.MaxRepositoryAge,
+		tokenKind:       gitHubTokenKind(config),
+		searchLimiter:   config.SearchRateLimiter,
+	}
+	if config.GraphQL {
+		provider.graphQLURL = graphQLURL
+	}
+	return provider, nil
+}
+
+// parseGitHubBaseURL validates a GitHub API base URL and normalizes it for
+// go-github: an absolute http(s) URL whose path ends with a single slash, e.g.
+// https://github.mycorp.com/api/v3/ for GitHub Enterprise Server. As with
+// github.NewEnterpriseClient, an instance URL without a path gets the
+// /api/v3/ suffix unless its host is an "api." host like GHE.com.
+func parseGitHubBaseURL(baseURL string) (*url.URL, error) {
+	u, err := url.Parse(strings.TrimSpace(baseURL))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse base URL: %w", err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("invalid base URL %q: expected an absolute http(s) URL, "+
+			"e.g. https://github.mycorp.com/api/v3/", baseURL)
+	}
+	if u.RawQuery != "" || u.Fragment != "" {
+		return nil, fmt.Errorf("invalid base URL %q: unexpected query or fragment", baseURL)
+	}
+
+	u.Path = strings.TrimRight(u.Path, "/") + "/"
+	u.RawPath = ""
+	if u.Path == "/" && !strings.HasPrefix(u.Hostname(), "api.") && !strings.Contains(u.Hostname(), ".api.") {
+		u.Path = "/api/v3/"
+	}
+	return u, nil
+}
+
+// gitHubScorecardHost returns the host repositories are addressed by for a
+// GitHub API URL. GitHub Enterprise Server serves the API under /api/v3 on the
+// instance host, while GitHub.com and GHE.com serve it on an "api." subdomain.
+func gitHubScorecardHost(apiURL *url.URL) string {
+	return strings.TrimPrefix(apiURL.Hostname(), "api.")
+}
+
+// newGitHubHTTPClient builds the HTTP client used to talk to the GitHub API.
+// GitHub App credentials take precedence over a personal access token; without
+// either, requests are unauthenticated. Requests are paced by the configured
+// rate limiter and retried on transient server errors.
+func newGitHubHTTPClient(config *Config) (*http.Client, error) {
+	base, err := baseTransport(config)
+	if err != nil {
+		return nil, err
+	}
+	base = httpclient.NewRetryTransport(
+		httpclient.NewRateLimitedTransport(base, config.RateLimiter),
+		httpclient.DefaultMaxRetries,
+		gitHubRetryBackoff,
+	)
+
+	if config.AppID != 0 || config.InstallationID != 0 || len(config.AppPrivateKey) > 0 {
+		if config.AppID == 0 || config.InstallationID == 0 || len(config.AppPrivateKey) == 0 {
+			return nil, fmt.Errorf("GitHub App authentication requires an app ID, installation ID and private key")
 		}
-		u, err := url.Parse(baseURL)
+
+		tr, err := ghinstallation.New(base, config.AppID, config.InstallationID, config.AppPrivateKey)
 		if err != nil {
-			return nil, fmt.Errorf("failed to parse base URL: %w", err)
+			return nil, fmt.Errorf("failed to create GitHub App transport: %w", err)
 		}
-		client.BaseURL = u
+		if config.BaseURL != "" {
+			// Installation tokens are requested from the same API as everything else
+			baseURL, err := parseGitHubBaseURL(config.BaseURL)
+			if err != nil {
+				return nil, err
+			}
+			tr.BaseURL = strings.TrimRight(baseURL.String(), "/")
+		}
+		return &http.Client{Transport: tr}, nil
 	}
 
-	return &GitHubProvider{
-		client:       client,
-		scorecardURL: DefaultGitHubScorecardURL,
-	}, nil
+	if token := config.AccessToken(); token != "" {
+		ts := oauth2.StaticTokenSource(
+			&oauth2.Token{AccessToken: token},
+		)
+		return &http.Client{Transport: &oauth2.Transport{Source: ts, Base: base}}, nil
+	}
+
+	return &http.Client{Transport: base}, nil
 }
 
-// GetRepositories fetches all public repositories for a GitHub organization
+// GetRepositories fetches all repositories of the configured visibility for a
+// GitHub organization, or a user account if the provider is configured for
+// users, or only those of a team of the organization if one is configured. The first page
+// reveals the number of pages, which are then fetched concurrently, unless a
+// repository limit makes fetching them one by one worthwhile. With GraphQL
+// enabled, repositories are listed through the GraphQL API instead.
 func (p *GitHubProvider) GetRepositories(ctx context.Context, organization string) ([]string, error) {
+	repos, _, err := p.ResumeRepositories(ctx, organization, nil)
+	return repos, err
+}
+
+// ResumeRepositories lists repositories like GetRepositories, starting at the
+// page of cursor unless it is nil. When pages are fetched concurrently, a
+// rate limit interrupts the listing at the first page not fetched.
+func (p *GitHubProvider) ResumeRepositories(
+	ctx context.Context, organization string, cursor *ListCursor,
+) ([]string, *ListCursor, error) {
+	if p.graphQLURL != "" {
+		return p.getRepositoriesGraphQL(ctx, organization, cursor)
+	}
+
+	first := 1
 	var allRepos []string
-	opts := &github.RepositoryListByOrgOptions{
-		Type:        "public",
-		ListOptions: github.ListOptions{PerPage: 100},
+	if cursor != nil {
+		first, allRepos = cursor.Page, slices.Clone(cursor.Repositories)
 	}
 
-	for {
-		repos, resp, err := p.client.Repositories.ListByOrg(ctx, organization, opts)
-		if err != nil {
-			return nil, p.handleError(err)
+	repos, resp, err := p.listPage(ctx, organization, first)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound && p.team != "" {
+			// GitHub hides secret teams from tokens without access to them
+			return nil, nil, fmt.Errorf("team %s of organization %s %w, or the token cannot see it",
+				p.team, organization, ErrNotFound)
 		}
+		if resp != nil && resp.StatusCode == http.StatusNotFound && p.ownerType != OwnerTypeUser {
+			return nil, nil, fmt.Errorf("organization %s %w, set the owner type to %q for personal accounts",
+				organization, ErrNotFound, OwnerTypeUser)
+		}
+		return interrupted(p.handleError(err), &ListCursor{Page: first, Repositories: allRepos})
+	}
+
+	// A token without org scope sees no repositories instead of an error
+	if first == 1 && p.ownerType != OwnerTypeUser && len(p.affiliations) == 0 && len(repos) == 0 {
+		if err := missingOrgScope(resp.Header); err != nil {
+			return nil, nil, err
+		}
+	}
+	allRepos = p.filterRepositories(ctx, allRepos, repos)
 
-		// Filter and collect repository names
-		for _, repo := range repos {
-			if p.shouldIncludeRepository(repo) {
-				allRepos = append(allRepos, repo.GetName())
+	switch {
+	case resp.LastPage > first && p.maxRepositories == 0:
+		rest := make([][]*github.Repository, resp.LastPage-first)
+		fetched := make([]bool, len(rest))
+		g, gctx := errgroup.WithContext(ctx)
+		g.SetLimit(gitHubPageConcurrency)
+		for page := first + 1; page <= resp.LastPage; page++ {
+			g.Go(func() error {
+				repos, _, err := p.listPage(gctx, organization, page)
+				if err != nil {
+					return p.handleError(err)
+				}
+				rest[page-first-1] = repos
+				fetched[page-first-1] = true
+				return nil
+			})
+		}
+		err := g.Wait()
+		if err != nil && !IsRateLimitError(err) {
+			return nil, nil, err
+		}
+		for i, repos := range rest {
+			if !fetched[i] {
+				return interrupted(err, &ListCursor{Page: first + 1 + i, Repositories: allRepos})
+			}
+			allRepos = p.filterRepositories(ctx, allRepos, repos)
+		}
+	default:
+		// Without a last page link, or with a limit to stop at, follow the
+		// next page links one by one
+		for page := resp.NextPage; page != 0 && !limitExceeded(len(allRepos), p.maxRepositories); page = resp.NextPage {
+			if repos, resp, err = p.listPage(ctx, organization, page); err != nil {
+				return interrupted(p.handleError(err), &ListCursor{Page: page, Repositories: allRepos})
 			}
+			allRepos = p.filterRepositories(ctx, allRepos, repos)
+		}
+	}
+
+	return allRepos, nil, nil
+}
+
+// filterRepositories appends the names of the repositories of a page that are
+// not excluded to names, reporting the excluded ones
+func (p *GitHubProvider) filterRepositories(ctx context.Context, names []string, repos []*github.Repository) []string {
+	for _, repo := range repos {
+		if repo == nil {
+			continue
+		}
+		if reason := p.exclusionReason(repo); reason != "" {
+			reportExcluded(ctx, repo.GetName(), reason)
+			continue
+		}
+		names = append(names, repo.GetName())
+	}
+	return names
+}
+
+// gitHubOrgScopes are the classic token scopes granting read access to
+// organizations
+var gitHubOrgScopes = []string{"read:org", "write:org", "admin:org"}
+
+// missingOrgScope returns a ScopeError when the X-OAuth-Scopes header of a
+// response shows a classic token without org scope. Fine-grained tokens and
+// GitHub App installations do not report scopes and are not checked.
+func missingOrgScope(header http.Header) error {
+	if _, ok := header["X-Oauth-Scopes"]; !ok {
+		return nil
+	}
+	granted := grantedScopes(header)
+	for _, scope := range granted {
+		if slices.Contains(gitHubOrgScopes, scope) {
+			return nil
+		}
+	}
+	return &ScopeError{Provider: ProviderTypeGitHub, Scope: "read:org", Granted: granted}
+}
+
+// grantedScopes returns the scopes listed in the X-OAuth-Scopes header of a
+// response
+func grantedScopes(header http.Header) []string {
+	var granted []string
+	for scope := range strings.SplitSeq(header.Get("X-OAuth-Scopes"), ",") {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			granted = append(granted, scope)
+		}
+	}
+	return granted
+}
+
+// gitHubFineGrainedTokenPrefix is the prefix of fine-grained personal access
+// tokens
+const gitHubFineGrainedTokenPrefix = "github_pat_"
+
+// gitHubTokenKind returns the kind of credentials of a configuration, or an
+// empty string for tokens whose kind only a response can tell
+func gitHubTokenKind(config *Config) string {
+	switch {
+	case config.AppID != 0:
+		return TokenKindApp
+	case config.AccessToken() == "":
+		return TokenKindAnonymous
+	case strings.HasPrefix(config.AccessToken(), gitHubFineGrainedTokenPrefix):
+		return TokenKindFineGrained
+	default:
+		return ""
+	}
+}
+
+// ProbeToken reports the kind and scopes of the token the provider
+// authenticates with. Classic tokens without an org scope are reported
+// without organization access. Whether fine-grained tokens and GitHub App
+// installations were granted access to the organization only shows once they
+// list none of its repositories, so the organization is fetched for the
+// number of public repositories it reports. The organization is not checked
+// for user accounts and affiliations.
+func (p *GitHubProvider) ProbeToken(ctx context.Context, organization string) (*TokenInfo, error) {
+	info := &TokenInfo{Kind: p.tokenKind, OrganizationAccess: true}
+	if p.tokenKind == TokenKindAnonymous {
+		return info, nil
+	}
+
+	var resp *github.Response
+	var err error
+	checkOrganization := p.ownerType != OwnerTypeUser && len(p.affiliations) == 0
+	if !checkOrganization {
+		_, resp, err = p.client.RateLimit.Get(ctx)
+	} else {
+		var org *github.Organization
+		org, resp, err = p.client.Organizations.Get(ctx, organization)
+		if err == nil {
+			info.PublicRepositories = org.GetPublicRepos()
 		}
+	}
+	if err != nil {
+		return nil, p.handleError(err)
+	}
 
-		if resp.NextPage == 0 {
-			break
+	// Only classic tokens report their scopes
+	if _, ok := resp.Header["X-Oauth-Scopes"]; ok {
+		info.Kind = TokenKindClassic
+		info.Scopes = grantedScopes(resp.Header)
+		if checkOrganization && missingOrgScope(resp.Header) != nil {
+			info.OrganizationAccess = false
 		}
-		opts.Page = resp.NextPage
 	}
+	if info.Kind == "" {
+		info.Kind = TokenKindUnknown
+	}
+	return info, nil
+}
 
-	return allRepos, nil
+// listPage fetches a page of the repositories of an organization or user. The
+// repositories of organizations are filtered by visibility by the API, those
+// of users and teams are filtered by exclusionReason. With affiliations, the
+// pages are those of the repositories the token has access to, left with the
+// ones of the owner.
+func (p *GitHubProvider) listPage(ctx context.Context, owner string, page int) ([]*github.Repository, *github.Response, error) {
+	listOpts := github.ListOptions{Page: page, PerPage: 100}
+	if len(p.affiliations) > 0 {
+		return p.listAffiliatedPage(ctx, owner, listOpts)
+	}
+	if p.team != "" {
+		return p.client.Teams.ListTeamReposBySlug(ctx, owner, p.team, &listOpts)
+	}
+	if p.ownerType == OwnerTypeUser {
+		return p.client.Repositories.ListByUser(ctx, owner, &github.RepositoryListByUserOptions{
+			Type:        "owner",
+			ListOptions: listOpts,
+		})
+	}
+	listType := string(p.visibility)
+	if listType == "" {
+		listType = string(VisibilityPublic)
+	}
+	return p.client.Repositories.ListByOrg(ctx, owner, &github.RepositoryListByOrgOptions{
+		Type:        listType,
+		ListOptions: listOpts,
+	})
+}
+
+// listAffiliatedPage fetches a page of the repositories the token has access
+// to through the configured affiliations, filtered by visibility by the API,
+// and keeps those of the owner
+func (p *GitHubProvider) listAffiliatedPage(
+	ctx context.Context, owner string, listOpts github.ListOptions,
+) ([]*github.Repository, *github.Response, error) {
+	affiliations := make([]string, 0, len(p.affiliations))
+	for _, affiliation := range p.affiliations {
+		affiliations = append(affiliations, string(affiliation))
+	}
+	visibility := string(p.visibility)
+	if visibility == "" {
+		visibility = string(VisibilityPublic)
+	}
+
+	repos, resp, err := p.client.Repositories.ListByAuthenticatedUser(ctx, &github.RepositoryListByAuthenticatedUserOptions{
+		Visibility:  visibility,
+		Affiliation: strings.Join(affiliations, ","),
+		ListOptions: listOpts,
+	})
+	repos = slices.DeleteFunc(repos, func(repo *github.Repository) bool {
+		return !strings.EqualFold(repo.GetOwner().GetLogin(), owner)
+	})
+	return repos, resp, err
 }
 
 // GetRepositoryDetails fetches detailed information about a specific repository
 func (p *GitHubProvider) GetRepositoryDetails(ctx context.Context, organization, repository string) (*Repository, error) {
+	if p.graphQLURL != "" {
+		return p.getRepositoryDetailsGraphQL(ctx, organization, repository)
+	}
+
 	repo, _, err := p.client.Repositories.Get(ctx, organization, repository)
 	if err != nil {
 		return nil, p.handleError(err)
@@ -113,6 +474,19 @@ func (p *GitHubProvider) GetRepositoryDetails(ctx context.Context, organization,
 	return p.convertToRepository(repo), nil
 }
 
+// ResolveCommit returns the SHA of the commit at the head of a branch
+func (p *GitHubProvider) ResolveCommit(ctx context.Context, organization, repository, branch string) (string, error) {
+	b, resp, err := p.client.Repositories.GetBranch(ctx, organization, repository, branch, 1)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return "", fmt.Errorf("branch %s of %s/%s %w", branch, organization, repository, ErrNotFound)
+		}
+		return "", p.handleError(err)
+	}
+
+	return b.GetCommit().GetSHA(), nil
+}
+
 // GetProviderType returns the provider type
 func (p *GitHubProvider) GetProviderType() ProviderType {
 	return ProviderTypeGitHub
@@ -140,21 +514,45 @@ func (p *GitHubProvider) handleError(err error) error {
 	// Handle secondary rate limit (abuse) errors
 	if ale, ok := err.(*github.AbuseRateLimitError); ok {
 		rlErr := NewRateLimitError(ProviderTypeGitHub, err.Error())
+		rlErr.Secondary = true
 		if ale.RetryAfter != nil {
 			rlErr.WithRetryAfter(*ale.RetryAfter)
 		}
 		return rlErr
 	}
 
+	var errResp *github.ErrorResponse
+	if errors.As(err, &errResp) && errResp.Response != nil {
+		switch statusCode := errResp.Response.StatusCode; {
+		case statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden:
+			// Rate limited 403 responses are reported as rate limit errors above
+			return NewAuthError(ProviderTypeGitHub, statusCode, errResp.Message)
+		case statusCode >= http.StatusInternalServerError:
+			// Server errors that persisted through retries
+			return NewTransientError(ProviderTypeGitHub, statusCode, errResp.Message)
+		}
+	}
+
 	return err
 }
 
-// shouldIncludeRepository determines if a repository should be included in results
-func (p *GitHubProvider) shouldIncludeRepository(repo *github.Repository) bool {
-	if repo == nil {
-		return false
+// exclusionReason returns why a repository is left out of the results, or an
+// empty string if it is included
+func (p *GitHubProvider) exclusionReason(repo *github.Repository) string {
+	if reason := visibilityExclusionReason(p.visibility, repo.GetPrivate()); reason != "" {
+		return reason
+	}
+	switch {
+	case repo.GetArchived():
+		return ExclusionReasonArchived
+	case repo.GetDisabled():
+		return ExclusionReasonDisabled
+	case repo.GetFork():
+		return ExclusionReasonFork
+	case inactive(repo.GetPushedAt().Time, p.maxAge):
+		return ExclusionReasonInactive
 	}
-	return !repo.GetPrivate() && !repo.GetArchived() && !repo.GetDisabled() && !repo.GetFork()
+	return ""
 }
 
 // convertToRepository converts a GitHub repository to the generic Repository type
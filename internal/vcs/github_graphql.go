@@ -0,0 +1,279 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vcs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v80/github"
+)
+
+// gitHubGraphQLPageSize is the number of repositories requested per GraphQL page
+const gitHubGraphQLPageSize = 100
+
+// gitHubRepositoriesQuery lists a page of the repositories owned by an
+// organization or user, with the fields needed to filter them. A null privacy
+// lists repositories regardless of their visibility.
+const gitHubRepositoriesQuery = `query($owner: String!, $first: Int!, $cursor: String, $privacy: RepositoryPrivacy) {
+  repositoryOwner(login: $owner) {
+    repositories(first: $first, after: $cursor, privacy: $privacy, ownerAffiliations: [OWNER]) {
+      pageInfo { hasNextPage endCursor }
+      nodes { name isPrivate isArchived isDisabled isFork pushedAt }
+    }
+  }
+}`
+
+// gitHubRepositoryQuery fetches the details of a repository
+const gitHubRepositoryQuery = `query($owner: String!, $name: String!) {
+  repository(owner: $owner, name: $name) {
+    name nameWithOwner url isPrivate isArchived isDisabled isFork
+    defaultBranchRef { name }
+  }
+}`
+
+// gitHubGraphQLRepository is the subset of the GraphQL repository object we use
+type gitHubGraphQLRepository struct {
+	Name             string    `json:"name"`
+	NameWithOwner    string    `json:"nameWithOwner"`
+	URL              string    `json:"url"`
+	IsPrivate        bool      `json:"isPrivate"`
+	IsArchived       bool      `json:"isArchived"`
+	IsDisabled       bool      `json:"isDisabled"`
+	IsFork           bool      `json:"isFork"`
+	PushedAt         time.Time `json:"pushedAt"`
+	DefaultBranchRef *struct {
+		Name string `json:"name"`
+	} `json:"defaultBranchRef"`
+}
+
+// gitHubGraphQLError is an error reported in a GraphQL response
+type gitHubGraphQLError struct {
+	Type    string `json:"type"`
+	Message string `json:"message"`
+}
+
+// gitHubGraphQLURL returns the GraphQL endpoint for a GitHub API URL. GitHub
+// Enterprise Server serves it at /api/graphql next to the /api/v3 REST API.
+func gitHubGraphQLURL(apiURL *url.URL) string {
+	u := *apiURL
+	if strings.HasSuffix(u.Path, "/api/v3/") {
+		u.Path = strings.TrimSuffix(u.Path, "v3/") + "graphql"
+	} else {
+		u.Path += "graphql"
+	}
+	return u.String()
+}
+
+// getRepositoriesGraphQL lists the repositories of the configured visibility of
+// an organization or user through the GraphQL API, following the page cursors
+// from the one of cursor unless it is nil
+func (p *GitHubProvider) getRepositoriesGraphQL(
+	ctx context.Context, owner string, cursor *ListCursor,
+) ([]string, *ListCursor, error) {
+	var allRepos []string
+	var after *string
+	if cursor != nil {
+		allRepos = slices.Clone(cursor.Repositories)
+		if cursor.After != "" {
+			after = &cursor.After
+		}
+	}
+
+	var privacy *string
+	switch p.visibility {
+	case "", VisibilityPublic:
+		privacy = github.Ptr("PUBLIC")
+	case VisibilityPrivate:
+		privacy = github.Ptr("PRIVATE")
+	}
+
+	for {
+		var data struct {
+			RepositoryOwner *struct {
+				Repositories struct {
+					PageInfo struct {
+						HasNextPage bool   `json:"hasNextPage"`
+						EndCursor   string `json:"endCursor"`
+					} `json:"pageInfo"`
+					Nodes []gitHubGraphQLRepository `json:"nodes"`
+				} `json:"repositories"`
+			} `json:"repositoryOwner"`
+		}
+		variables := map[string]any{"owner": owner, "first": gitHubGraphQLPageSize, "cursor": after, "privacy": privacy}
+		if err := p.graphQL(ctx, gitHubRepositoriesQuery, variables, &data); err != nil {
+			resume := &ListCursor{Repositories: allRepos}
+			if after != nil {
+				resume.After = *after
+			}
+			return interrupted(err, resume)
+		}
+		if data.RepositoryOwner == nil {
+			return nil, nil, fmt.Errorf("owner %s %w", owner, ErrNotFound)
+		}
+
+		repos := data.RepositoryOwner.Repositories
+		for i := range repos.Nodes {
+			repo := &repos.Nodes[i]
+			if reason := p.exclusionReason(repo.toGitHub()); reason != "" {
+				reportExcluded(ctx, repo.Name, reason)
+				continue
+			}
+			allRepos = append(allRepos, repo.Name)
+		}
+
+		if !repos.PageInfo.HasNextPage || limitExceeded(len(allRepos), p.maxRepositories) {
+			break
+		}
+		after = &repos.PageInfo.EndCursor
+	}
+
+	return allRepos, nil, nil
+}
+
+// getRepositoryDetailsGraphQL fetches the details of a repository through the
+// GraphQL API
+func (p *GitHubProvider) getRepositoryDetailsGraphQL(ctx context.Context, owner, name string) (*Repository, error) {
+	var data struct {
+		Repository *gitHubGraphQLRepository `json:"repository"`
+	}
+	if err := p.graphQL(ctx, gitHubRepositoryQuery, map[string]any{"owner": owner, "name": name}, &data); err != nil {
+		return nil, err
+	}
+	if data.Repository == nil {
+		return nil, fmt.Errorf("repository %s/%s %w", owner, name, ErrNotFound)
+	}
+
+	return p.convertToRepository(data.Repository.toGitHub()), nil
+}
+
+// graphQL performs a GraphQL query and decodes the response data into v
+func (p *GitHubProvider) graphQL(ctx context.Context, query string, variables map[string]any, v any) error {
+	body, err := json.Marshal(map[string]any{"query": query, "variables": variables})
+	if err != nil {
+		return fmt.Errorf("failed to encode GraphQL query: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.graphQLURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.client.Client().Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to query GitHub GraphQL API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return p.handleGraphQLStatus(resp)
+	}
+
+	var result struct {
+		Data   json.RawMessage      `json:"data"`
+		Errors []gitHubGraphQLError `json:"errors"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("failed to decode GitHub GraphQL API response: %w", err)
+	}
+	if len(result.Errors) > 0 {
+		return p.handleGraphQLErrors(resp, result.Errors)
+	}
+
+	if err := json.Unmarshal(result.Data, v); err != nil {
+		return fmt.Errorf("failed to decode GitHub GraphQL API response: %w", err)
+	}
+	return nil
+}
+
+// handleGraphQLStatus maps GraphQL API error responses to internal error types
+func (p *GitHubProvider) handleGraphQLStatus(resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	message := strings.TrimSpace(string(body))
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests ||
+		(resp.StatusCode == http.StatusForbidden &&
+			(resp.Header.Get("Retry-After") != "" || resp.Header.Get("X-RateLimit-Remaining") == "0")):
+		return gitHubGraphQLRateLimitError(resp, message)
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return NewAuthError(ProviderTypeGitHub, resp.StatusCode, message)
+	case resp.StatusCode >= http.StatusInternalServerError:
+		return NewTransientError(ProviderTypeGitHub, resp.StatusCode, message)
+	}
+	return fmt.Errorf("GitHub GraphQL API returned status %d: %s", resp.StatusCode, message)
+}
+
+// handleGraphQLErrors maps errors reported in a GraphQL response to internal
+// error types, including the NOT_FOUND errors reported for missing owners
+// and repositories
+func (p *GitHubProvider) handleGraphQLErrors(resp *http.Response, errs []gitHubGraphQLError) error {
+	messages := make([]string, 0, len(errs))
+	for _, e := range errs {
+		switch e.Type {
+		case "RATE_LIMITED":
+			return gitHubGraphQLRateLimitError(resp, e.Message)
+		case "NOT_FOUND":
+			return fmt.Errorf("GitHub GraphQL API: %s: %w", e.Message, ErrNotFound)
+		}
+		messages = append(messages, e.Message)
+	}
+	return fmt.Errorf("GitHub GraphQL API returned errors: %s", strings.Join(messages, "; "))
+}
+
+// gitHubGraphQLRateLimitError builds a rate limit error from the rate limit
+// headers of a GraphQL response
+func gitHubGraphQLRateLimitError(resp *http.Response, message string) error {
+	rlErr := NewRateLimitError(ProviderTypeGitHub, message)
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+		rlErr.WithRetryAfter(time.Duration(seconds) * time.Second)
+	}
+	if reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		rlErr.WithResetTime(time.Unix(reset, 0))
+	}
+	return rlErr
+}
+
+// toGitHub converts a GraphQL repository to the REST representation shared
+// with the REST code path
+func (r *gitHubGraphQLRepository) toGitHub() *github.Repository {
+	repo := &github.Repository{
+		Name:     &r.Name,
+		FullName: &r.NameWithOwner,
+		HTMLURL:  &r.URL,
+		Private:  &r.IsPrivate,
+		Archived: &r.IsArchived,
+		Disabled: &r.IsDisabled,
+		Fork:     &r.IsFork,
+		PushedAt: &github.Timestamp{Time: r.PushedAt},
+	}
+	if r.DefaultBranchRef != nil {
+		repo.DefaultBranch = &r.DefaultBranchRef.Name
+	}
+	return repo
+}
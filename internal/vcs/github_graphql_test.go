@@ -0,0 +1,308 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vcs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// graphQLRequest is a GraphQL request as received by the stub server
+type graphQLRequest struct {
+	Query     string         `json:"query"`
+	Variables map[string]any `json:"variables"`
+}
+
+// newGitHubGraphQLServer starts a GitHub GraphQL API stub at the GitHub
+// Enterprise Server path, serving the given repositories for the "org" owner
+// with cursor pagination. It records the cursors it was queried with.
+func newGitHubGraphQLServer(t *testing.T, repos []gitHubGraphQLRepository, cursors *[]string) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/graphql", func(w http.ResponseWriter, r *http.Request) {
+		var req graphQLRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		owner, _ := req.Variables["owner"].(string)
+
+		switch {
+		case owner == "limited":
+			w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10))
+			_, _ = w.Write([]byte(`{"data": null, "errors": [{"type": "RATE_LIMITED", "message": "API rate limit exceeded"}]}`))
+		case owner != "org":
+			_, _ = fmt.Fprintf(w, `{"data": {"repositoryOwner": null, "repository": null},
+				"errors": [{"type": "NOT_FOUND", "message": "Could not resolve to a RepositoryOwner with the login of '%s'."}]}`, owner)
+		case strings.Contains(req.Query, "repositoryOwner"):
+			cursor, _ := req.Variables["cursor"].(string)
+			*cursors = append(*cursors, cursor)
+			first := int(req.Variables["first"].(float64))
+
+			start := 0
+			if cursor != "" {
+				start, _ = strconv.Atoi(strings.TrimPrefix(cursor, "cursor-"))
+			}
+			end := min(start+first, len(repos))
+
+			var resp struct {
+				Data struct {
+					RepositoryOwner struct {
+						Repositories struct {
+							PageInfo struct {
+								HasNextPage bool   `json:"hasNextPage"`
+								EndCursor   string `json:"endCursor"`
+							} `json:"pageInfo"`
+							Nodes []gitHubGraphQLRepository `json:"nodes"`
+						} `json:"repositories"`
+					} `json:"repositoryOwner"`
+				} `json:"data"`
+			}
+			page := &resp.Data.RepositoryOwner.Repositories
+			page.Nodes = repos[start:end]
+			page.PageInfo.HasNextPage = end < len(repos)
+			page.PageInfo.EndCursor = fmt.Sprintf("cursor-%d", end)
+			_ = json.NewEncoder(w).Encode(resp)
+		default:
+			name, _ := req.Variables["name"].(string)
+			for _, repo := range repos {
+				if repo.Name == name {
+					_ = json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{"repository": repo}})
+					return
+				}
+			}
+			_, _ = w.Write([]byte(`{"data": {"repository": null},
+				"errors": [{"type": "NOT_FOUND", "message": "Could not resolve to a Repository."}]}`))
+		}
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestGitHubGraphQLURL(t *testing.T) {
+	tests := []struct {
+		apiURL   string
+		expected string
+	}{
+		{apiURL: DefaultGitHubAPIURL, expected: "https://api.github.com/graphql"},
+		{apiURL: "https://github.example.com/api/v3/", expected: "https://github.example.com/api/graphql"},
+		{apiURL: "https://api.example.ghe.com/", expected: "https://api.example.ghe.com/graphql"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.apiURL, func(t *testing.T) {
+			u, err := url.Parse(tt.apiURL)
+			if err != nil {
+				t.Fatalf("failed to parse URL: %v", err)
+			}
+			if got := gitHubGraphQLURL(u); got != tt.expected {
+				t.Errorf("gitHubGraphQLURL() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGitHubProvider_GetRepositories_GraphQL(t *testing.T) {
+	repos := []gitHubGraphQLRepository{
+		{Name: "archived", IsArchived: true},
+		{Name: "disabled", IsDisabled: true},
+		{Name: "fork", IsFork: true},
+	}
+	var expected []string
+	for i := range 2*gitHubGraphQLPageSize + 10 {
+		name := fmt.Sprintf("repo-%d", i)
+		repos = append(repos, gitHubGraphQLRepository{Name: name})
+		expected = append(expected, name)
+	}
+
+	var cursors []string
+	server := newGitHubGraphQLServer(t, repos, &cursors)
+
+	provider, err := NewGitHubProvider(&Config{BaseURL: server.URL + "/api/v3", GraphQL: true})
+	if err != nil {
+		t.Fatalf("NewGitHubProvider() unexpected error: %v", err)
+	}
+
+	excluded := map[string]string{}
+	ctx := WithExclusionHandler(context.Background(), func(repository, reason string) {
+		excluded[repository] = reason
+	})
+	got, err := provider.GetRepositories(ctx, "org")
+	if err != nil {
+		t.Fatalf("GetRepositories() unexpected error: %v", err)
+	}
+
+	if !slices.Equal(got, expected) {
+		t.Errorf("GetRepositories() returned %d repositories, want %d", len(got), len(expected))
+	}
+	if wantCursors := []string{"", "cursor-100", "cursor-200"}; !slices.Equal(cursors, wantCursors) {
+		t.Errorf("queried cursors = %v, want %v", cursors, wantCursors)
+	}
+	wantExcluded := map[string]string{
+		"archived": ExclusionReasonArchived,
+		"disabled": ExclusionReasonDisabled,
+		"fork":     ExclusionReasonFork,
+	}
+	if !maps.Equal(excluded, wantExcluded) {
+		t.Errorf("excluded = %v, want %v", excluded, wantExcluded)
+	}
+}
+
+func TestGitHubProvider_GetRepositories_GraphQLMaxRepositoryAge(t *testing.T) {
+	var cursors []string
+	server := newGitHubGraphQLServer(t, []gitHubGraphQLRepository{
+		{Name: "recent", PushedAt: time.Now().Add(-24 * time.Hour)},
+		{Name: "old", PushedAt: time.Now().Add(-100 * 24 * time.Hour)},
+		{Name: "never-pushed"},
+	}, &cursors)
+
+	provider, err := NewGitHubProvider(&Config{
+		BaseURL:          server.URL + "/api/v3",
+		GraphQL:          true,
+		MaxRepositoryAge: 30 * 24 * time.Hour,
+	})
+	if err != nil {
+		t.Fatalf("NewGitHubProvider() unexpected error: %v", err)
+	}
+
+	excluded := map[string]string{}
+	ctx := WithExclusionHandler(context.Background(), func(repository, reason string) {
+		excluded[repository] = reason
+	})
+	got, err := provider.GetRepositories(ctx, "org")
+	if err != nil {
+		t.Fatalf("GetRepositories() unexpected error: %v", err)
+	}
+
+	if expected := []string{"recent", "never-pushed"}; !slices.Equal(got, expected) {
+		t.Errorf("GetRepositories() = %v, want %v", got, expected)
+	}
+	if expected := map[string]string{"old": ExclusionReasonInactive}; !maps.Equal(excluded, expected) {
+		t.Errorf("excluded = %v, want %v", excluded, expected)
+	}
+}
+
+func TestGitHubProvider_GetRepositories_GraphQLVisibility(t *testing.T) {
+	tests := []struct {
+		visibility Visibility
+		expected   any
+	}{
+		{visibility: "", expected: "PUBLIC"},
+		{visibility: VisibilityPublic, expected: "PUBLIC"},
+		{visibility: VisibilityPrivate, expected: "PRIVATE"},
+		{visibility: VisibilityAll, expected: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.visibility), func(t *testing.T) {
+			var privacy any
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var req graphQLRequest
+				_ = json.NewDecoder(r.Body).Decode(&req)
+				privacy = req.Variables["privacy"]
+				_, _ = w.Write([]byte(`{"data": {"repositoryOwner": {"repositories": {"nodes": []}}}}`))
+			}))
+			t.Cleanup(server.Close)
+
+			provider, err := NewGitHubProvider(&Config{BaseURL: server.URL, GraphQL: true, Visibility: tt.visibility})
+			if err != nil {
+				t.Fatalf("NewGitHubProvider() unexpected error: %v", err)
+			}
+			if _, err := provider.GetRepositories(context.Background(), "org"); err != nil {
+				t.Fatalf("GetRepositories() unexpected error: %v", err)
+			}
+			if privacy != tt.expected {
+				t.Errorf("privacy = %v, want %v", privacy, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGitHubProvider_GetRepositories_GraphQLErrors(t *testing.T) {
+	server := newGitHubGraphQLServer(t, nil, new([]string))
+
+	provider, err := NewGitHubProvider(&Config{BaseURL: server.URL + "/api/v3", GraphQL: true})
+	if err != nil {
+		t.Fatalf("NewGitHubProvider() unexpected error: %v", err)
+	}
+
+	if _, err := provider.GetRepositories(context.Background(), "missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetRepositories() error = %v, want ErrNotFound", err)
+	}
+	_, err = provider.GetRepositories(context.Background(), "limited")
+	if !IsRateLimitError(err) || GetRetryAfter(err) <= 55*time.Minute {
+		t.Errorf("GetRepositories() error = %v, want rate limit error resetting in an hour", err)
+	}
+}
+
+func TestGitHubProvider_GetRepositories_GraphQLAuthError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message": "Bad credentials"}`, http.StatusUnauthorized)
+	}))
+	t.Cleanup(server.Close)
+
+	provider, err := NewGitHubProvider(&Config{BaseURL: server.URL, Token: "expired-token", GraphQL: true})
+	if err != nil {
+		t.Fatalf("NewGitHubProvider() unexpected error: %v", err)
+	}
+
+	if _, err := provider.GetRepositories(context.Background(), "org"); !IsAuthError(err) {
+		t.Errorf("GetRepositories() error = %v, want auth error", err)
+	}
+}
+
+func TestGitHubProvider_GetRepositoryDetails_GraphQL(t *testing.T) {
+	server := newGitHubGraphQLServer(t, []gitHubGraphQLRepository{{
+		Name:          "repo",
+		NameWithOwner: "org/repo",
+		IsFork:        true,
+		DefaultBranchRef: &struct {
+			Name string `json:"name"`
+		}{Name: "main"},
+	}}, new([]string))
+
+	provider, err := NewGitHubProvider(&Config{BaseURL: server.URL + "/api/v3", GraphQL: true})
+	if err != nil {
+		t.Fatalf("NewGitHubProvider() unexpected error: %v", err)
+	}
+
+	repo, err := provider.GetRepositoryDetails(context.Background(), "org", "repo")
+	if err != nil {
+		t.Fatalf("GetRepositoryDetails() unexpected error: %v", err)
+	}
+	if repo.FullName != "org/repo" || repo.DefaultBranch != "main" || !repo.IsFork {
+		t.Errorf("GetRepositoryDetails() = %+v, unexpected repository", repo)
+	}
+
+	if _, err := provider.GetRepositoryDetails(context.Background(), "org", "missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetRepositoryDetails() error = %v, want ErrNotFound", err)
+	}
+}
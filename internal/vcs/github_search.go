@@ -0,0 +1,70 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vcs
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/google/go-github/v80/github"
+)
+
+// gitHubSearchResultLimit is the number of results the GitHub search API
+// returns at most for a query, whatever the number of matches
+const gitHubSearchResultLimit = 1000
+
+// SearchRepositories returns the full names of the repositories matching a
+// GitHub search query, e.g. "topic:tier1 org:giantswarm". The search API
+// allows far fewer requests per minute than the rest of the REST API, so
+// pages are fetched one by one, paced by Config.SearchRateLimiter and
+// stopping at the repository limit, and a query matching more than
+// gitHubSearchResultLimit repositories only returns the first ones.
+func (p *GitHubProvider) SearchRepositories(ctx context.Context, query string) ([]string, error) {
+	opts := &github.SearchOptions{ListOptions: github.ListOptions{PerPage: 100}}
+
+	var names []string
+	for seen := 0; ; {
+		if p.searchLimiter != nil {
+			if err := p.searchLimiter.Wait(ctx); err != nil {
+				return nil, err
+			}
+		}
+
+		result, resp, err := p.client.Search.Repositories(ctx, query, opts)
+		if err != nil {
+			if resp != nil && resp.StatusCode == http.StatusUnprocessableEntity {
+				return nil, fmt.Errorf("invalid search query %q: %w", query, err)
+			}
+			return nil, p.handleError(err)
+		}
+
+		for _, repo := range result.Repositories {
+			if reason := p.exclusionReason(repo); reason != "" {
+				reportExcluded(ctx, repo.GetName(), reason)
+				continue
+			}
+			names = append(names, repo.GetFullName())
+		}
+		seen += len(result.Repositories)
+
+		if resp.NextPage == 0 || seen >= gitHubSearchResultLimit || limitExceeded(len(names), p.maxRepositories) {
+			return names, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
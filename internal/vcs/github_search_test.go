@@ -0,0 +1,204 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vcs
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// searchResults serves the given number of pages of search results, each
+// holding a repository and a fork, and counts the requests
+func searchResults(t *testing.T, pages int, requests *atomic.Int32) http.HandlerFunc {
+	t.Helper()
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if q := r.URL.Query().Get("q"); q != "topic:tier1 org:org" {
+			t.Errorf("search query = %q, want %q", q, "topic:tier1 org:org")
+		}
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if page == 0 {
+			page = 1
+		}
+		if page < pages {
+			w.Header().Set("Link", fmt.Sprintf(`<http://%s/search/repositories?q=x&page=%d>; rel="next"`, r.Host, page+1))
+		}
+		_, _ = fmt.Fprintf(w, `{"total_count": %d, "items": [
+			{"name": "repo-%d", "full_name": "org/repo-%d"},
+			{"name": "fork-%d", "full_name": "org/fork-%d", "fork": true}
+		]}`, 2*pages, page, page, page, page)
+	}
+}
+
+func TestGitHubProvider_SearchRepositories(t *testing.T) {
+	var requests atomic.Int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /search/repositories", searchResults(t, 3, &requests))
+	server := newGitHubServer(mux)
+	t.Cleanup(server.Close)
+
+	provider, err := NewGitHubProvider(&Config{BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("NewGitHubProvider() unexpected error: %v", err)
+	}
+
+	excluded := map[string]string{}
+	ctx := WithExclusionHandler(context.Background(), func(repository, reason string) {
+		excluded[repository] = reason
+	})
+	repos, err := provider.(RepositorySearcher).SearchRepositories(ctx, "topic:tier1 org:org")
+	if err != nil {
+		t.Fatalf("SearchRepositories() unexpected error: %v", err)
+	}
+
+	if expected := []string{"org/repo-1", "org/repo-2", "org/repo-3"}; !slices.Equal(repos, expected) {
+		t.Errorf("SearchRepositories() = %v, want %v", repos, expected)
+	}
+	expectedExcluded := map[string]string{
+		"fork-1": ExclusionReasonFork,
+		"fork-2": ExclusionReasonFork,
+		"fork-3": ExclusionReasonFork,
+	}
+	if !maps.Equal(excluded, expectedExcluded) {
+		t.Errorf("excluded = %v, want %v", excluded, expectedExcluded)
+	}
+	if got := requests.Load(); got != 3 {
+		t.Errorf("requests = %d, want 3", got)
+	}
+}
+
+func TestGitHubProvider_SearchRepositories_MaxRepositories(t *testing.T) {
+	var requests atomic.Int32
+	server := newGitHubServer(searchResults(t, 7, &requests))
+	t.Cleanup(server.Close)
+
+	provider, err := NewGitHubProvider(&Config{BaseURL: server.URL, MaxRepositories: 1})
+	if err != nil {
+		t.Fatalf("NewGitHubProvider() unexpected error: %v", err)
+	}
+	repos, err := provider.(RepositorySearcher).SearchRepositories(context.Background(), "topic:tier1 org:org")
+	if err != nil {
+		t.Fatalf("SearchRepositories() unexpected error: %v", err)
+	}
+
+	// Searching stops at the page that exceeds the limit after filtering
+	if expected := []string{"org/repo-1", "org/repo-2"}; !slices.Equal(repos, expected) {
+		t.Errorf("SearchRepositories() = %v, want %v", repos, expected)
+	}
+	if got := requests.Load(); got != 2 {
+		t.Errorf("requests = %d, want 2", got)
+	}
+}
+
+func TestGitHubProvider_SearchRepositories_Errors(t *testing.T) {
+	reset := time.Now().Add(30 * time.Second)
+
+	tests := []struct {
+		name          string
+		handler       http.HandlerFunc
+		wantRateLimit bool
+		wantMessage   string
+	}{
+		{
+			name: "search rate limit",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("X-RateLimit-Limit", "30")
+				w.Header().Set("X-RateLimit-Remaining", "0")
+				w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+				w.Header().Set("X-RateLimit-Resource", "search")
+				http.Error(w, `{"message": "API rate limit exceeded"}`, http.StatusForbidden)
+			},
+			wantRateLimit: true,
+		},
+		{
+			name: "invalid query",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusUnprocessableEntity)
+				_, _ = w.Write([]byte(`{"message": "Validation Failed"}`))
+			},
+			wantMessage: `invalid search query "stars:>>1"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newGitHubServer(tt.handler)
+			t.Cleanup(server.Close)
+
+			provider, err := NewGitHubProvider(&Config{BaseURL: server.URL})
+			if err != nil {
+				t.Fatalf("NewGitHubProvider() unexpected error: %v", err)
+			}
+
+			_, err = provider.(RepositorySearcher).SearchRepositories(context.Background(), "stars:>>1")
+			if err == nil {
+				t.Fatal("SearchRepositories() expected error")
+			}
+			if got := IsRateLimitError(err); got != tt.wantRateLimit {
+				t.Errorf("IsRateLimitError(%v) = %v, want %v", err, got, tt.wantRateLimit)
+			}
+			// The search rate limit resets within a minute
+			if tt.wantRateLimit {
+				if retryAfter := GetRetryAfter(err); retryAfter > time.Minute {
+					t.Errorf("GetRetryAfter() = %v, want at most a minute", retryAfter)
+				}
+			}
+			if !strings.Contains(err.Error(), tt.wantMessage) {
+				t.Errorf("SearchRepositories() error = %v, want it to contain %q", err, tt.wantMessage)
+			}
+		})
+	}
+}
+
+func TestGitHubProvider_SearchRepositories_RateLimit(t *testing.T) {
+	var requests atomic.Int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /search/repositories", searchResults(t, 3, &requests))
+	server := newGitHubServer(mux)
+	t.Cleanup(server.Close)
+
+	// The limiter lets the first page through and holds back the others for
+	// longer than the listing may take
+	provider, err := NewGitHubProvider(&Config{
+		BaseURL:           server.URL,
+		SearchRateLimiter: rate.NewLimiter(rate.Every(time.Hour), 1),
+	})
+	if err != nil {
+		t.Fatalf("NewGitHubProvider() unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if _, err := provider.(RepositorySearcher).SearchRepositories(ctx, "topic:tier1 org:org"); err == nil {
+		t.Fatal("SearchRepositories() expected error waiting for the search rate limit")
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("search requests = %d, want 1", got)
+	}
+}
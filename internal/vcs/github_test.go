@@ -0,0 +1,1294 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vcs
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bradleyfalzon/ghinstallation/v2"
+	"golang.org/x/oauth2"
+
+	"github.com/giantswarm/openssf-scorecard-exporter/internal/httpclient"
+)
+
+// testPrivateKey returns a PEM-encoded RSA key for GitHub App tests
+func testPrivateKey(t *testing.T) []byte {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(key),
+	})
+}
+
+func TestNewGitHubHTTPClient(t *testing.T) {
+	privateKey := testPrivateKey(t)
+
+	tests := []struct {
+		name      string
+		config    *Config
+		transport string
+		expectErr bool
+	}{
+		{
+			name:      "anonymous",
+			config:    &Config{},
+			transport: "none",
+		},
+		{
+			name:      "personal access token",
+			config:    &Config{Token: "ghp_token"},
+			transport: "oauth2",
+		},
+		{
+			name:      "GitHub App",
+			config:    &Config{AppID: 1, InstallationID: 2, AppPrivateKey: privateKey},
+			transport: "app",
+		},
+		{
+			name:      "GitHub App takes precedence over token",
+			config:    &Config{Token: "ghp_token", AppID: 1, InstallationID: 2, AppPrivateKey: privateKey},
+			transport: "app",
+		},
+		{
+			name:      "incomplete GitHub App config",
+			config:    &Config{AppID: 1, AppPrivateKey: privateKey},
+			expectErr: true,
+		},
+		{
+			name:      "invalid private key",
+			config:    &Config{AppID: 1, InstallationID: 2, AppPrivateKey: []byte("not a key")},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := newGitHubHTTPClient(tt.config)
+			if tt.expectErr {
+				if err == nil {
+					t.Fatal("newGitHubHTTPClient() expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("newGitHubHTTPClient() unexpected error: %v", err)
+			}
+
+			var transport string
+			switch client.Transport.(type) {
+			case *oauth2.Transport:
+				transport = "oauth2"
+			case *ghinstallation.Transport:
+				transport = "app"
+			default:
+				transport = "none"
+			}
+			if transport != tt.transport {
+				t.Errorf("newGitHubHTTPClient() transport = %s, want %s", transport, tt.transport)
+			}
+		})
+	}
+}
+
+// newGitHubServer starts a test server for a GitHub Enterprise Server instance,
+// serving the handler under the /api/v3 path its API is addressed by
+func newGitHubServer(handler http.Handler) *httptest.Server {
+	return httptest.NewServer(http.StripPrefix("/api/v3", handler))
+}
+
+func TestNewGitHubHTTPClient_AppBaseURL(t *testing.T) {
+	privateKey := testPrivateKey(t)
+
+	for _, baseURL := range []string{"https://github.example.com/api/v3/", "https://github.example.com"} {
+		client, err := newGitHubHTTPClient(&Config{
+			AppID:          1,
+			InstallationID: 2,
+			AppPrivateKey:  privateKey,
+			BaseURL:        baseURL,
+		})
+		if err != nil {
+			t.Fatalf("newGitHubHTTPClient(%s) unexpected error: %v", baseURL, err)
+		}
+
+		tr := client.Transport.(*ghinstallation.Transport)
+		if tr.BaseURL != "https://github.example.com/api/v3" {
+			t.Errorf("newGitHubHTTPClient(%s) BaseURL = %s, want https://github.example.com/api/v3", baseURL, tr.BaseURL)
+		}
+	}
+}
+
+func TestNewGitHubHTTPClient_BaseTransport(t *testing.T) {
+	// The proxy records the requests it receives instead of forwarding them
+	var proxied []*http.Request
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = append(proxied, r)
+	}))
+	t.Cleanup(proxy.Close)
+
+	base, err := httpclient.NewTransport(httpclient.Options{ProxyURL: proxy.URL})
+	if err != nil {
+		t.Fatalf("NewTransport() unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name          string
+		config        *Config
+		authorization string
+	}{
+		{
+			name:   "anonymous",
+			config: &Config{Transport: base},
+		},
+		{
+			name:          "personal access token",
+			config:        &Config{Token: "ghp_token", Transport: base},
+			authorization: "Bearer ghp_token",
+		},
+		{
+			name:          "default token",
+			config:        &Config{DefaultToken: "ghp_default", Transport: base},
+			authorization: "Bearer ghp_default",
+		},
+		{
+			name:          "username is ignored",
+			config:        &Config{Token: "ghp_token", Username: "bot", Transport: base},
+			authorization: "Bearer ghp_token",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			proxied = nil
+
+			client, err := newGitHubHTTPClient(tt.config)
+			if err != nil {
+				t.Fatalf("newGitHubHTTPClient() unexpected error: %v", err)
+			}
+
+			resp, err := client.Get("http://github.example.com/api/v3/user")
+			if err != nil {
+				t.Fatalf("Get() unexpected error: %v", err)
+			}
+			_ = resp.Body.Close()
+
+			if len(proxied) != 1 || proxied[0].Host != "github.example.com" {
+				t.Fatalf("newGitHubHTTPClient() does not use the configured base transport")
+			}
+			if got := proxied[0].Header.Get("Authorization"); got != tt.authorization {
+				t.Errorf("Authorization = %q, want %q", got, tt.authorization)
+			}
+		})
+	}
+}
+
+func TestGitHubProvider_ProxyURL(t *testing.T) {
+	// The proxies record the requests they receive instead of forwarding them
+	newProxy := func(proxied *[]*http.Request) *httptest.Server {
+		proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			*proxied = append(*proxied, r)
+		}))
+		t.Cleanup(proxy.Close)
+		return proxy
+	}
+	var sharedRequests, vcsRequests []*http.Request
+	sharedProxy := newProxy(&sharedRequests)
+	vcsProxy := newProxy(&vcsRequests)
+
+	// The shared transport routes requests to the scorecard API through its own proxy
+	shared, err := httpclient.NewTransport(httpclient.Options{ProxyURL: sharedProxy.URL})
+	if err != nil {
+		t.Fatalf("NewTransport() unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		transport http.RoundTripper
+	}{
+		{name: "shared transport", transport: shared},
+		{name: "default transport"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sharedRequests, vcsRequests = nil, nil
+
+			provider, err := NewGitHubProvider(&Config{
+				BaseURL:   "http://github.example.com/api/v3/",
+				Token:     "ghp_token",
+				Transport: tt.transport,
+				ProxyURL:  vcsProxy.URL,
+			})
+			if err != nil {
+				t.Fatalf("NewGitHubProvider() unexpected error: %v", err)
+			}
+			if _, err := provider.GetRepositories(context.Background(), "org"); err != nil {
+				t.Fatalf("GetRepositories() unexpected error: %v", err)
+			}
+
+			if len(vcsRequests) != 1 || vcsRequests[0].Host != "github.example.com" {
+				t.Fatalf("VCS proxy requests = %d, want the repository listing", len(vcsRequests))
+			}
+			if got := vcsRequests[0].Header.Get("Authorization"); got != "Bearer ghp_token" {
+				t.Errorf("Authorization = %q, want %q", got, "Bearer ghp_token")
+			}
+			if len(sharedRequests) != 0 {
+				t.Errorf("shared proxy requests = %d, want 0", len(sharedRequests))
+			}
+		})
+	}
+
+	if _, err := NewGitHubProvider(&Config{ProxyURL: "proxy.example.com"}); err == nil {
+		t.Error("NewGitHubProvider() expected error for a proxy URL without scheme")
+	}
+}
+
+func TestNewGitHubHTTPClient_RateLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	t.Cleanup(server.Close)
+
+	client, err := newGitHubHTTPClient(&Config{RateLimiter: httpclient.NewLimiter(10)})
+	if err != nil {
+		t.Fatalf("newGitHubHTTPClient() unexpected error: %v", err)
+	}
+
+	// The first 10 requests use the burst, the next 5 are paced at 10/s
+	start := time.Now()
+	for range 15 {
+		resp, err := client.Get(server.URL)
+		if err != nil {
+			t.Fatalf("Get() unexpected error: %v", err)
+		}
+		_ = resp.Body.Close()
+	}
+	if elapsed := time.Since(start); elapsed < 450*time.Millisecond {
+		t.Errorf("15 requests took %v, want them paced to at least 450ms", elapsed)
+	}
+}
+
+func TestGitHubProvider_ResolveCommit(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /repos/org/repo/branches/main", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"name": "main", "commit": {"sha": "abc123"}}`))
+	})
+	server := newGitHubServer(mux)
+	t.Cleanup(server.Close)
+
+	provider, err := NewGitHubProvider(&Config{BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("NewGitHubProvider() unexpected error: %v", err)
+	}
+	resolver := provider.(CommitResolver)
+
+	commit, err := resolver.ResolveCommit(context.Background(), "org", "repo", "main")
+	if err != nil || commit != "abc123" {
+		t.Errorf("ResolveCommit() = %q, %v, want abc123", commit, err)
+	}
+
+	if _, err := resolver.ResolveCommit(context.Background(), "org", "repo", "missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("ResolveCommit() error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestGitHubProvider_GetScorecardURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		baseURL  string
+		expected string
+	}{
+		{
+			name:     "github.com by default",
+			expected: "github.com/org/repo",
+		},
+		{
+			name:     "github.com API URL",
+			baseURL:  DefaultGitHubAPIURL,
+			expected: "github.com/org/repo",
+		},
+		{
+			name:     "GitHub Enterprise Server",
+			baseURL:  "https://github.mycorp.com/api/v3/",
+			expected: "github.mycorp.com/org/repo",
+		},
+		{
+			name:     "GitHub Enterprise Server with port",
+			baseURL:  "https://github.mycorp.com:8443/api/v3",
+			expected: "github.mycorp.com/org/repo",
+		},
+		{
+			name:     "GitHub Enterprise Cloud with data residency",
+			baseURL:  "https://api.mycorp.ghe.com/",
+			expected: "mycorp.ghe.com/org/repo",
+		},
+		{
+			name:     "GitHub Enterprise Server with extra slashes",
+			baseURL:  " https://github.mycorp.com/api/v3// ",
+			expected: "github.mycorp.com/org/repo",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider, err := NewGitHubProvider(&Config{BaseURL: tt.baseURL})
+			if err != nil {
+				t.Fatalf("NewGitHubProvider() unexpected error: %v", err)
+			}
+			if got := provider.GetScorecardURL("org", "repo"); got != tt.expected {
+				t.Errorf("GetScorecardURL() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseGitHubBaseURL(t *testing.T) {
+	tests := []struct {
+		name      string
+		baseURL   string
+		expected  string
+		expectErr bool
+	}{
+		{
+			name:     "GitHub Enterprise Server API URL",
+			baseURL:  "https://github.mycorp.com/api/v3/",
+			expected: "https://github.mycorp.com/api/v3/",
+		},
+		{
+			name:     "API path without trailing slash",
+			baseURL:  "https://github.mycorp.com/api/v3",
+			expected: "https://github.mycorp.com/api/v3/",
+		},
+		{
+			name:     "API path with repeated trailing slashes",
+			baseURL:  "https://github.mycorp.com/api/v3///",
+			expected: "https://github.mycorp.com/api/v3/",
+		},
+		{
+			name:     "plain http with port",
+			baseURL:  "http://github.mycorp.com:8080/api/v3",
+			expected: "http://github.mycorp.com:8080/api/v3/",
+		},
+		{
+			name:     "instance URL without API path",
+			baseURL:  "https://github.mycorp.com",
+			expected: "https://github.mycorp.com/api/v3/",
+		},
+		{
+			name:     "instance URL with trailing slash",
+			baseURL:  "https://github.mycorp.com/",
+			expected: "https://github.mycorp.com/api/v3/",
+		},
+		{
+			name:     "GitHub Enterprise Cloud",
+			baseURL:  "https://api.mycorp.ghe.com",
+			expected: "https://api.mycorp.ghe.com/",
+		},
+		{
+			name:     "GitHub.com",
+			baseURL:  "https://api.github.com",
+			expected: "https://api.github.com/",
+		},
+		{
+			name:      "missing scheme",
+			baseURL:   "github.mycorp.com/api/v3",
+			expectErr: true,
+		},
+		{
+			name:      "unsupported scheme",
+			baseURL:   "ftp://github.mycorp.com/api/v3",
+			expectErr: true,
+		},
+		{
+			name:      "missing host",
+			baseURL:   "https:///api/v3",
+			expectErr: true,
+		},
+		{
+			name:      "query",
+			baseURL:   "https://github.mycorp.com/api/v3?per_page=100",
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, err := parseGitHubBaseURL(tt.baseURL)
+			if (err != nil) != tt.expectErr {
+				t.Fatalf("parseGitHubBaseURL() error = %v, expectErr %v", err, tt.expectErr)
+			}
+			if tt.expectErr {
+				if _, err := NewGitHubProvider(&Config{BaseURL: tt.baseURL}); err == nil {
+					t.Error("NewGitHubProvider() expected an error")
+				}
+				return
+			}
+			if got := u.String(); got != tt.expected {
+				t.Errorf("parseGitHubBaseURL() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGitHubProvider_GetRepositories(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /orgs/org/repos", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[
+			{"name": "repo"},
+			{"name": "private", "private": true},
+			{"name": "archived", "archived": true},
+			{"name": "disabled", "disabled": true},
+			{"name": "fork", "fork": true}
+		]`))
+	})
+	server := newGitHubServer(mux)
+	t.Cleanup(server.Close)
+
+	provider, err := NewGitHubProvider(&Config{BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("NewGitHubProvider() unexpected error: %v", err)
+	}
+
+	excluded := map[string]string{}
+	ctx := WithExclusionHandler(context.Background(), func(repository, reason string) {
+		excluded[repository] = reason
+	})
+	repos, err := provider.GetRepositories(ctx, "org")
+	if err != nil {
+		t.Fatalf("GetRepositories() unexpected error: %v", err)
+	}
+
+	if !slices.Equal(repos, []string{"repo"}) {
+		t.Errorf("GetRepositories() = %v, want [repo]", repos)
+	}
+	expected := map[string]string{
+		"private":  ExclusionReasonPrivate,
+		"archived": ExclusionReasonArchived,
+		"disabled": ExclusionReasonDisabled,
+		"fork":     ExclusionReasonFork,
+	}
+	if !maps.Equal(excluded, expected) {
+		t.Errorf("excluded = %v, want %v", excluded, expected)
+	}
+}
+
+func TestGitHubProvider_GetRepositories_MaxRepositoryAge(t *testing.T) {
+	recent := time.Now().Add(-24 * time.Hour).Format(time.RFC3339)
+	old := time.Now().Add(-100 * 24 * time.Hour).Format(time.RFC3339)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /orgs/org/repos", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintf(w, `[
+			{"name": "recent", "pushed_at": %q},
+			{"name": "old", "pushed_at": %q},
+			{"name": "never-pushed"}
+		]`, recent, old)
+	})
+	server := newGitHubServer(mux)
+	t.Cleanup(server.Close)
+
+	provider, err := NewGitHubProvider(&Config{BaseURL: server.URL, MaxRepositoryAge: 30 * 24 * time.Hour})
+	if err != nil {
+		t.Fatalf("NewGitHubProvider() unexpected error: %v", err)
+	}
+
+	excluded := map[string]string{}
+	ctx := WithExclusionHandler(context.Background(), func(repository, reason string) {
+		excluded[repository] = reason
+	})
+	repos, err := provider.GetRepositories(ctx, "org")
+	if err != nil {
+		t.Fatalf("GetRepositories() unexpected error: %v", err)
+	}
+
+	// Repositories without a push time are kept
+	if expected := []string{"recent", "never-pushed"}; !slices.Equal(repos, expected) {
+		t.Errorf("GetRepositories() = %v, want %v", repos, expected)
+	}
+	if expected := map[string]string{"old": ExclusionReasonInactive}; !maps.Equal(excluded, expected) {
+		t.Errorf("excluded = %v, want %v", excluded, expected)
+	}
+}
+
+func TestGitHubProvider_UserAgent(t *testing.T) {
+	var userAgent string
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /orgs/org/repos", func(w http.ResponseWriter, r *http.Request) {
+		userAgent = r.UserAgent()
+		_, _ = w.Write([]byte(`[{"name": "repo"}]`))
+	})
+	server := newGitHubServer(mux)
+	t.Cleanup(server.Close)
+
+	provider, err := NewGitHubProvider(&Config{
+		BaseURL:   server.URL,
+		Transport: httpclient.NewUserAgentTransport(http.DefaultTransport, "openssf-scorecard-exporter/v1.2.3"),
+	})
+	if err != nil {
+		t.Fatalf("NewGitHubProvider() unexpected error: %v", err)
+	}
+	if _, err := provider.GetRepositories(context.Background(), "org"); err != nil {
+		t.Fatalf("GetRepositories() unexpected error: %v", err)
+	}
+
+	// The User-Agent of go-github is replaced
+	if userAgent != "openssf-scorecard-exporter/v1.2.3" {
+		t.Errorf("User-Agent = %q, want openssf-scorecard-exporter/v1.2.3", userAgent)
+	}
+}
+
+func TestGitHubProvider_GetRepositories_Scope(t *testing.T) {
+	tests := []struct {
+		name          string
+		scopes        []string
+		repos         string
+		expectedRepos []string
+		expectScope   bool
+	}{
+		{
+			name:        "classic token without org scope",
+			scopes:      []string{"public_repo, repo:status"},
+			repos:       `[]`,
+			expectScope: true,
+		},
+		{
+			name:        "classic token without scopes",
+			scopes:      []string{""},
+			repos:       `[]`,
+			expectScope: true,
+		},
+		{
+			name:   "classic token with org scope",
+			scopes: []string{"public_repo, read:org"},
+			repos:  `[]`,
+		},
+		{
+			name:  "token without scopes header",
+			repos: `[]`,
+		},
+		{
+			name:          "repositories listed despite missing scope",
+			scopes:        []string{"public_repo"},
+			repos:         `[{"name": "repo"}]`,
+			expectedRepos: []string{"repo"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newGitHubServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				for _, scopes := range tt.scopes {
+					w.Header().Set("X-OAuth-Scopes", scopes)
+				}
+				_, _ = w.Write([]byte(tt.repos))
+			}))
+			t.Cleanup(server.Close)
+
+			provider, err := NewGitHubProvider(&Config{Token: "ghp_token", BaseURL: server.URL})
+			if err != nil {
+				t.Fatalf("NewGitHubProvider() unexpected error: %v", err)
+			}
+
+			repos, err := provider.GetRepositories(context.Background(), "org")
+			if IsScopeError(err) != tt.expectScope {
+				t.Fatalf("GetRepositories() error = %v, want scope error %v", err, tt.expectScope)
+			}
+			if !tt.expectScope && (err != nil || !slices.Equal(repos, tt.expectedRepos)) {
+				t.Errorf("GetRepositories() = %v, %v, want %v", repos, err, tt.expectedRepos)
+			}
+		})
+	}
+}
+
+func TestGitHubProvider_ProbeToken(t *testing.T) {
+	tests := []struct {
+		name         string
+		config       Config
+		scopes       []string
+		organization string
+		expected     TokenInfo
+		expectPath   string
+	}{
+		{
+			name:         "fine-grained token granted the organization",
+			config:       Config{Token: "github_pat_token"},
+			organization: `{"login": "org", "public_repos": 2, "total_private_repos": 3, "owned_private_repos": 3}`,
+			expected:     TokenInfo{Kind: TokenKindFineGrained, OrganizationAccess: true, PublicRepositories: 2},
+			expectPath:   "/orgs/org",
+		},
+		{
+			name:         "fine-grained token of a public organization",
+			config:       Config{Token: "github_pat_token"},
+			organization: `{"login": "org", "public_repos": 12}`,
+			expected:     TokenInfo{Kind: TokenKindFineGrained, OrganizationAccess: true, PublicRepositories: 12},
+			expectPath:   "/orgs/org",
+		},
+		{
+			name:         "classic token with org scope",
+			config:       Config{Token: "ghp_token"},
+			scopes:       []string{"repo, read:org"},
+			organization: `{"login": "org", "total_private_repos": 3}`,
+			expected:     TokenInfo{Kind: TokenKindClassic, Scopes: []string{"repo", "read:org"}, OrganizationAccess: true},
+			expectPath:   "/orgs/org",
+		},
+		{
+			name:         "classic token without org scope",
+			config:       Config{Token: "ghp_token"},
+			scopes:       []string{"public_repo"},
+			organization: `{"login": "org", "total_private_repos": 3}`,
+			expected:     TokenInfo{Kind: TokenKindClassic, Scopes: []string{"public_repo"}},
+			expectPath:   "/orgs/org",
+		},
+		{
+			name:         "classic token without org scope for a public organization",
+			config:       Config{Token: "ghp_token"},
+			scopes:       []string{"public_repo"},
+			organization: `{"login": "org", "public_repos": 12}`,
+			expected:     TokenInfo{Kind: TokenKindClassic, Scopes: []string{"public_repo"}, PublicRepositories: 12},
+			expectPath:   "/orgs/org",
+		},
+		{
+			name:         "token of unknown kind",
+			config:       Config{Token: "gho_token"},
+			organization: `{"login": "org", "total_private_repos": 3}`,
+			expected:     TokenInfo{Kind: TokenKindUnknown, OrganizationAccess: true},
+			expectPath:   "/orgs/org",
+		},
+		{
+			name:       "user account not checked for organization access",
+			config:     Config{Token: "ghp_token", OwnerType: OwnerTypeUser},
+			scopes:     []string{"public_repo"},
+			expected:   TokenInfo{Kind: TokenKindClassic, Scopes: []string{"public_repo"}, OrganizationAccess: true},
+			expectPath: "/rate_limit",
+		},
+		{
+			name:     "anonymous access not probed",
+			expected: TokenInfo{Kind: TokenKindAnonymous, OrganizationAccess: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var path string
+			server := newGitHubServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				path = r.URL.Path
+				for _, scopes := range tt.scopes {
+					w.Header().Set("X-OAuth-Scopes", scopes)
+				}
+				if r.URL.Path == "/rate_limit" {
+					_, _ = w.Write([]byte(`{"resources": {}}`))
+					return
+				}
+				_, _ = w.Write([]byte(tt.organization))
+			}))
+			t.Cleanup(server.Close)
+
+			config := tt.config
+			config.BaseURL = server.URL
+			provider, err := NewGitHubProvider(&config)
+			if err != nil {
+				t.Fatalf("NewGitHubProvider() unexpected error: %v", err)
+			}
+
+			info, err := provider.(TokenProber).ProbeToken(context.Background(), "org")
+			if err != nil {
+				t.Fatalf("ProbeToken() unexpected error: %v", err)
+			}
+			if info.Kind != tt.expected.Kind || !slices.Equal(info.Scopes, tt.expected.Scopes) ||
+				info.OrganizationAccess != tt.expected.OrganizationAccess {
+				t.Errorf("ProbeToken() = %+v, want %+v", info, tt.expected)
+			}
+			if path != tt.expectPath {
+				t.Errorf("ProbeToken() requested %q, want %q", path, tt.expectPath)
+			}
+		})
+	}
+}
+
+func TestGitHubProvider_GetRepositories_OwnerType(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /orgs/org/repos", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[{"name": "org-repo"}]`))
+	})
+	mux.HandleFunc("GET /orgs/someone/repos", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message": "Not Found"}`, http.StatusNotFound)
+	})
+	mux.HandleFunc("GET /users/someone/repos", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[{"name": "user-repo"}, {"name": "fork", "fork": true}]`))
+	})
+	server := newGitHubServer(mux)
+	t.Cleanup(server.Close)
+
+	tests := []struct {
+		name      string
+		ownerType OwnerType
+		owner     string
+		expected  []string
+		expectErr error
+	}{
+		{
+			name:     "organization by default",
+			owner:    "org",
+			expected: []string{"org-repo"},
+		},
+		{
+			name:      "user",
+			ownerType: OwnerTypeUser,
+			owner:     "someone",
+			expected:  []string{"user-repo"},
+		},
+		{
+			name:      "user listed as organization",
+			ownerType: OwnerTypeOrganization,
+			owner:     "someone",
+			expectErr: ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider, err := NewGitHubProvider(&Config{BaseURL: server.URL, OwnerType: tt.ownerType})
+			if err != nil {
+				t.Fatalf("NewGitHubProvider() unexpected error: %v", err)
+			}
+
+			repos, err := provider.GetRepositories(context.Background(), tt.owner)
+			if tt.expectErr != nil {
+				if !errors.Is(err, tt.expectErr) || !strings.Contains(err.Error(), string(OwnerTypeUser)) {
+					t.Errorf("GetRepositories() error = %v, want %v suggesting the user owner type", err, tt.expectErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetRepositories() unexpected error: %v", err)
+			}
+			if !slices.Equal(repos, tt.expected) {
+				t.Errorf("GetRepositories() = %v, want %v", repos, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGitHubProvider_GetRepositories_Team(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /orgs/org/teams/platform/repos", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[
+			{"name": "team-repo"},
+			{"name": "team-private", "private": true},
+			{"name": "team-fork", "fork": true}
+		]`))
+	})
+	mux.HandleFunc("GET /orgs/org/teams/secret/repos", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message": "Not Found"}`, http.StatusNotFound)
+	})
+	mux.HandleFunc("GET /orgs/org/repos", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request to %s with a team", r.URL.Path)
+	})
+	server := newGitHubServer(mux)
+	t.Cleanup(server.Close)
+
+	tests := []struct {
+		name       string
+		team       string
+		visibility Visibility
+		expected   []string
+		expectErr  error
+	}{
+		{
+			name:     "public team repositories",
+			team:     "platform",
+			expected: []string{"team-repo"},
+		},
+		{
+			name:       "all team repositories",
+			team:       "platform",
+			visibility: VisibilityAll,
+			expected:   []string{"team-repo", "team-private"},
+		},
+		{
+			name:      "team not visible to the token",
+			team:      "secret",
+			expectErr: ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider, err := NewGitHubProvider(&Config{BaseURL: server.URL, Team: tt.team, Visibility: tt.visibility})
+			if err != nil {
+				t.Fatalf("NewGitHubProvider() unexpected error: %v", err)
+			}
+
+			repos, err := provider.GetRepositories(context.Background(), "org")
+			if tt.expectErr != nil {
+				if !errors.Is(err, tt.expectErr) || !strings.Contains(err.Error(), tt.team) {
+					t.Errorf("GetRepositories() error = %v, want %v naming the team", err, tt.expectErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetRepositories() unexpected error: %v", err)
+			}
+			if !slices.Equal(repos, tt.expected) {
+				t.Errorf("GetRepositories() = %v, want %v", repos, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGitHubProvider_ErrorClassification(t *testing.T) {
+	gitHubRetryBackoff = time.Millisecond
+	t.Cleanup(func() { gitHubRetryBackoff = httpclient.DefaultRetryBackoff })
+
+	tests := []struct {
+		name          string
+		status        int
+		body          string
+		wantTransient bool
+		wantRateLimit bool
+		wantSecondary bool
+		wantAuth      bool
+	}{
+		{
+			name:          "server error",
+			status:        http.StatusInternalServerError,
+			wantTransient: true,
+		},
+		{
+			name:     "unauthorized",
+			status:   http.StatusUnauthorized,
+			wantAuth: true,
+		},
+		{
+			name:     "forbidden",
+			status:   http.StatusForbidden,
+			wantAuth: true,
+		},
+		{
+			name:          "too many requests",
+			status:        http.StatusTooManyRequests,
+			wantRateLimit: true,
+		},
+		{
+			name:   "secondary rate limit",
+			status: http.StatusForbidden,
+			body: `{"message": "You have exceeded a secondary rate limit",
+				"documentation_url": "https://docs.github.com/rest/overview/rate-limits-for-the-rest-api#about-secondary-rate-limits"}`,
+			wantRateLimit: true,
+			wantSecondary: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newGitHubServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				body := tt.body
+				if body == "" {
+					body = `{"message": "failure"}`
+				}
+				_, _ = w.Write([]byte(body))
+			}))
+			t.Cleanup(server.Close)
+
+			provider, err := NewGitHubProvider(&Config{BaseURL: server.URL})
+			if err != nil {
+				t.Fatalf("NewGitHubProvider() unexpected error: %v", err)
+			}
+
+			_, err = provider.GetRepositories(context.Background(), "org")
+			if err == nil {
+				t.Fatal("GetRepositories() expected error")
+			}
+			if got := IsTransientError(err); got != tt.wantTransient {
+				t.Errorf("IsTransientError(%v) = %v, want %v", err, got, tt.wantTransient)
+			}
+			if got := IsRateLimitError(err); got != tt.wantRateLimit {
+				t.Errorf("IsRateLimitError(%v) = %v, want %v", err, got, tt.wantRateLimit)
+			}
+			if got := IsSecondaryRateLimitError(err); got != tt.wantSecondary {
+				t.Errorf("IsSecondaryRateLimitError(%v) = %v, want %v", err, got, tt.wantSecondary)
+			}
+			if got := IsAuthError(err); got != tt.wantAuth {
+				t.Errorf("IsAuthError(%v) = %v, want %v", err, got, tt.wantAuth)
+			}
+		})
+	}
+}
+
+// paginatedRepos serves numbered repositories over the given number of pages,
+// answering later pages faster so that they complete out of order. Pages in
+// failing respond with a rate limit error.
+func paginatedRepos(t *testing.T, pages int, failing ...int) http.HandlerFunc {
+	t.Helper()
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if page == 0 {
+			page = 1
+		}
+		if slices.Contains(failing, page) {
+			w.Header().Set("X-RateLimit-Limit", "5000")
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10))
+			http.Error(w, `{"message": "API rate limit exceeded"}`, http.StatusForbidden)
+			return
+		}
+
+		time.Sleep(time.Duration(pages-page) * 5 * time.Millisecond)
+
+		u := *r.URL
+		link := func(p int, rel string) string {
+			q := u.Query()
+			q.Set("page", strconv.Itoa(p))
+			u.RawQuery = q.Encode()
+			return fmt.Sprintf(`<http://%s%s>; rel="%s"`, r.Host, u.RequestURI(), rel)
+		}
+		if page < pages {
+			w.Header().Set("Link", link(page+1, "next")+", "+link(pages, "last"))
+		}
+		_, _ = fmt.Fprintf(w, `[{"name": "repo-%d-a"}, {"name": "repo-%d-b"}]`, page, page)
+	}
+}
+
+func TestGitHubProvider_GetRepositories_Pagination(t *testing.T) {
+	const pages = 7
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /orgs/org/repos", paginatedRepos(t, pages))
+	mux.HandleFunc("GET /orgs/limited/repos", paginatedRepos(t, pages, 3))
+	server := newGitHubServer(mux)
+	t.Cleanup(server.Close)
+
+	provider, err := NewGitHubProvider(&Config{BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("NewGitHubProvider() unexpected error: %v", err)
+	}
+
+	repos, err := provider.GetRepositories(context.Background(), "org")
+	if err != nil {
+		t.Fatalf("GetRepositories() unexpected error: %v", err)
+	}
+	var expected []string
+	for page := 1; page <= pages; page++ {
+		expected = append(expected, fmt.Sprintf("repo-%d-a", page), fmt.Sprintf("repo-%d-b", page))
+	}
+	if !slices.Equal(repos, expected) {
+		t.Errorf("GetRepositories() = %v, want %v", repos, expected)
+	}
+
+	// A rate limit on any page fails the listing with a rate limit error
+	provider, err = NewGitHubProvider(&Config{BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("NewGitHubProvider() unexpected error: %v", err)
+	}
+	_, err = provider.GetRepositories(context.Background(), "limited")
+	var rlErr *RateLimitError
+	if !errors.As(err, &rlErr) {
+		t.Errorf("GetRepositories() error = %v, want RateLimitError", err)
+	}
+}
+
+func TestGitHubProvider_ResumeRepositories(t *testing.T) {
+	const pages = 5
+
+	tests := []struct {
+		name   string
+		config Config
+	}{
+		{
+			name: "concurrent pages",
+		},
+		{
+			name:   "sequential pages",
+			config: Config{MaxRepositories: 100},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Page 2 is rate limited until limited is cleared
+			var limited atomic.Bool
+			limited.Store(true)
+			var firstPages atomic.Int32
+			available, failing := paginatedRepos(t, pages), paginatedRepos(t, pages, 2)
+			server := newGitHubServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if page, _ := strconv.Atoi(r.URL.Query().Get("page")); page <= 1 {
+					firstPages.Add(1)
+				}
+				if limited.Load() {
+					failing(w, r)
+					return
+				}
+				available(w, r)
+			}))
+			t.Cleanup(server.Close)
+
+			config := tt.config
+			config.BaseURL = server.URL
+			newLister := func() ResumableLister {
+				provider, err := NewGitHubProvider(&config)
+				if err != nil {
+					t.Fatalf("NewGitHubProvider() unexpected error: %v", err)
+				}
+				return provider.(ResumableLister)
+			}
+
+			_, cursor, err := newLister().ResumeRepositories(context.Background(), "org", nil)
+			if !IsRateLimitError(err) {
+				t.Fatalf("ResumeRepositories() error = %v, want a rate limit error", err)
+			}
+			expected := &ListCursor{Page: 2, Repositories: []string{"repo-1-a", "repo-1-b"}}
+			if cursor == nil || cursor.Page != expected.Page || !slices.Equal(cursor.Repositories, expected.Repositories) {
+				t.Fatalf("ResumeRepositories() cursor = %+v, want %+v", cursor, expected)
+			}
+
+			// The next listing resumes at the rate limited page, with a new
+			// client that does not wait for the rate limit to reset
+			limited.Store(false)
+			repos, cursor, err := newLister().ResumeRepositories(context.Background(), "org", cursor)
+			if err != nil {
+				t.Fatalf("ResumeRepositories() unexpected error: %v", err)
+			}
+			if cursor != nil {
+				t.Errorf("ResumeRepositories() cursor = %+v, want nil", cursor)
+			}
+			var all []string
+			for page := 1; page <= pages; page++ {
+				all = append(all, fmt.Sprintf("repo-%d-a", page), fmt.Sprintf("repo-%d-b", page))
+			}
+			if !slices.Equal(repos, all) {
+				t.Errorf("ResumeRepositories() = %v, want %v", repos, all)
+			}
+			if got := firstPages.Load(); got != 1 {
+				t.Errorf("first page requests = %d, want 1", got)
+			}
+		})
+	}
+}
+
+func TestGitHubProvider_GetRepositories_MaxRepositories(t *testing.T) {
+	const pages = 7
+
+	// Every page holds a repository and a fork, which is filtered out
+	var requests atomic.Int32
+	server := newGitHubServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if page < pages {
+			w.Header().Set("Link", fmt.Sprintf(`<http://%s/orgs/org/repos?page=%d>; rel="next", <http://%s/orgs/org/repos?page=%d>; rel="last"`,
+				r.Host, page+1, r.Host, pages))
+		}
+		_, _ = fmt.Fprintf(w, `[{"name": "repo-%d"}, {"name": "fork-%d", "fork": true}]`, page, page)
+	}))
+	t.Cleanup(server.Close)
+
+	provider, err := NewGitHubProvider(&Config{BaseURL: server.URL, MaxRepositories: 3})
+	if err != nil {
+		t.Fatalf("NewGitHubProvider() unexpected error: %v", err)
+	}
+	repos, err := provider.GetRepositories(context.Background(), "org")
+	if err != nil {
+		t.Fatalf("GetRepositories() unexpected error: %v", err)
+	}
+
+	// Listing stops at the page that exceeds the limit after filtering
+	if expected := []string{"repo-1", "repo-2", "repo-3", "repo-4"}; !slices.Equal(repos, expected) {
+		t.Errorf("GetRepositories() = %v, want %v", repos, expected)
+	}
+	if got := requests.Load(); got != 4 {
+		t.Errorf("requests = %d, want 4", got)
+	}
+}
+
+func TestGitHubProvider_GetRepositories_Visibility(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /orgs/org/repos", func(w http.ResponseWriter, r *http.Request) {
+		// The API filters organization repositories by type
+		switch r.URL.Query().Get("type") {
+		case "public":
+			_, _ = w.Write([]byte(`[{"name": "public"}]`))
+		case "private":
+			_, _ = w.Write([]byte(`[{"name": "private", "private": true}]`))
+		case "all":
+			_, _ = w.Write([]byte(`[{"name": "public"}, {"name": "private", "private": true}]`))
+		default:
+			http.Error(w, "unexpected type", http.StatusBadRequest)
+		}
+	})
+	mux.HandleFunc("GET /users/someone/repos", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[{"name": "public"}, {"name": "private", "private": true}]`))
+	})
+	server := newGitHubServer(mux)
+	t.Cleanup(server.Close)
+
+	tests := []struct {
+		name       string
+		visibility Visibility
+		expected   []string
+	}{
+		{
+			name:     "public by default",
+			expected: []string{"public"},
+		},
+		{
+			name:       "public",
+			visibility: VisibilityPublic,
+			expected:   []string{"public"},
+		},
+		{
+			name:       "private",
+			visibility: VisibilityPrivate,
+			expected:   []string{"private"},
+		},
+		{
+			name:       "all",
+			visibility: VisibilityAll,
+			expected:   []string{"public", "private"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for owner, ownerType := range map[string]OwnerType{"org": OwnerTypeOrganization, "someone": OwnerTypeUser} {
+				provider, err := NewGitHubProvider(&Config{BaseURL: server.URL, OwnerType: ownerType, Visibility: tt.visibility})
+				if err != nil {
+					t.Fatalf("NewGitHubProvider() unexpected error: %v", err)
+				}
+
+				repos, err := provider.GetRepositories(context.Background(), owner)
+				if err != nil {
+					t.Fatalf("GetRepositories(%s) unexpected error: %v", owner, err)
+				}
+				if !slices.Equal(repos, tt.expected) {
+					t.Errorf("GetRepositories(%s) = %v, want %v", owner, repos, tt.expected)
+				}
+			}
+		})
+	}
+}
+
+func TestGitHubProvider_GetRepositories_Affiliation(t *testing.T) {
+	var query url.Values
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /user/repos", func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		_, _ = w.Write([]byte(`[
+			{"name": "owned", "owner": {"login": "someone"}},
+			{"name": "shared", "private": true, "owner": {"login": "Org"}},
+			{"name": "team", "private": true, "owner": {"login": "org"}},
+			{"name": "other", "owner": {"login": "other-org"}}
+		]`))
+	})
+	mux.HandleFunc("GET /orgs/org/repos", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request to %s with affiliations", r.URL.Path)
+	})
+	server := newGitHubServer(mux)
+	t.Cleanup(server.Close)
+
+	tests := []struct {
+		name                string
+		affiliations        []Affiliation
+		visibility          Visibility
+		owner               string
+		expectedAffiliation string
+		expectedVisibility  string
+		expected            []string
+	}{
+		{
+			name:                "owner",
+			affiliations:        []Affiliation{AffiliationOwner},
+			owner:               "someone",
+			expectedAffiliation: "owner",
+			expectedVisibility:  "public",
+			expected:            []string{"owned"},
+		},
+		{
+			name:                "collaborator",
+			affiliations:        []Affiliation{AffiliationCollaborator},
+			visibility:          VisibilityPrivate,
+			owner:               "org",
+			expectedAffiliation: "collaborator",
+			expectedVisibility:  "private",
+			expected:            []string{"shared", "team"},
+		},
+		{
+			name:                "organization member",
+			affiliations:        []Affiliation{AffiliationOrganizationMember},
+			visibility:          VisibilityAll,
+			owner:               "org",
+			expectedAffiliation: "organization_member",
+			expectedVisibility:  "all",
+			expected:            []string{"shared", "team"},
+		},
+		{
+			name:                "several affiliations",
+			affiliations:        []Affiliation{AffiliationCollaborator, AffiliationOrganizationMember},
+			visibility:          VisibilityAll,
+			owner:               "org",
+			expectedAffiliation: "collaborator,organization_member",
+			expectedVisibility:  "all",
+			expected:            []string{"shared", "team"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider, err := NewGitHubProvider(&Config{
+				BaseURL:      server.URL,
+				Visibility:   tt.visibility,
+				Affiliations: tt.affiliations,
+			})
+			if err != nil {
+				t.Fatalf("NewGitHubProvider() unexpected error: %v", err)
+			}
+
+			repos, err := provider.GetRepositories(context.Background(), tt.owner)
+			if err != nil {
+				t.Fatalf("GetRepositories() unexpected error: %v", err)
+			}
+			if !slices.Equal(repos, tt.expected) {
+				t.Errorf("GetRepositories() = %v, want %v", repos, tt.expected)
+			}
+			if got := query.Get("affiliation"); got != tt.expectedAffiliation {
+				t.Errorf("affiliation = %q, want %q", got, tt.expectedAffiliation)
+			}
+			if got := query.Get("visibility"); got != tt.expectedVisibility {
+				t.Errorf("visibility = %q, want %q", got, tt.expectedVisibility)
+			}
+		})
+	}
+}
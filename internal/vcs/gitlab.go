@@ -0,0 +1,296 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vcs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultGitLabURL is the default GitLab instance
+	DefaultGitLabURL = "https://gitlab.com"
+
+	// gitLabPageSize is the number of projects requested per page
+	gitLabPageSize = 100
+)
+
+// GitLabProvider implements the Provider interface for GitLab
+type GitLabProvider struct {
+	client           *http.Client
+	baseURL          string
+	token            string
+	scorecardURL     string
+	ownerType        OwnerType
+	visibility       Visibility
+	includeSubgroups bool
+
+	// maxRepositories stops pagination once exceeded, zero meaning no limit
+	maxRepositories int
+
+	// maxAge leaves out projects without activity for longer, zero meaning
+	// no limit
+	maxAge time.Duration
+}
+
+// gitLabProject is the subset of the GitLab project API object we use
+type gitLabProject struct {
+	Path              string `json:"path"`
+	PathWithNamespace string `json:"path_with_namespace"`
+	WebURL            string `json:"web_url"`
+	DefaultBranch     string `json:"default_branch"`
+	Visibility        string `json:"visibility"`
+	Archived          bool   `json:"archived"`
+	EmptyRepo         bool   `json:"empty_repo"`
+	Mirror            bool   `json:"mirror"`
+	ForkedFromProject *struct {
+		ID int64 `json:"id"`
+	} `json:"forked_from_project"`
+	LastActivityAt time.Time `json:"last_activity_at"`
+}
+
+// NewGitLabProvider creates a new GitLab provider
+func NewGitLabProvider(config *Config) (Provider, error) {
+	baseURL, u, err := parseInstanceURL(config.BaseURL, DefaultGitLabURL, "/api/v4")
+	if err != nil {
+		return nil, err
+	}
+
+	transport, err := baseTransport(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GitLabProvider{
+		client:           &http.Client{Transport: transport},
+		baseURL:          baseURL,
+		token:            config.AccessToken(),
+		scorecardURL:     u.Host,
+		ownerType:        config.OwnerType,
+		visibility:       config.Visibility,
+		includeSubgroups: config.IncludeSubgroups,
+		maxRepositories:  config.MaxRepositories,
+		maxAge:           config.MaxRepositoryAge,
+	}, nil
+}
+
+// GetRepositories fetches all projects of the configured visibility of a
+// GitLab group, or a user account if the provider is configured for users.
+// With subgroups included, projects of nested groups are returned by their
+// path relative to the group, e.g. "subgroup/project".
+func (p *GitLabProvider) GetRepositories(ctx context.Context, organization string) ([]string, error) {
+	repos, _, err := p.ResumeRepositories(ctx, organization, nil)
+	return repos, err
+}
+
+// ResumeRepositories lists projects like GetRepositories, starting at the page
+// of cursor unless it is nil
+func (p *GitLabProvider) ResumeRepositories(
+	ctx context.Context, organization string, cursor *ListCursor,
+) ([]string, *ListCursor, error) {
+	first := 1
+	var allRepos []string
+	if cursor != nil {
+		first, allRepos = cursor.Page, slices.Clone(cursor.Repositories)
+	}
+
+	query := url.Values{}
+	if p.visibility == "" || p.visibility == VisibilityPublic {
+		query.Set("visibility", "public")
+	}
+	query.Set("per_page", strconv.Itoa(gitLabPageSize))
+	owners := "groups"
+	if p.ownerType == OwnerTypeUser {
+		owners = "users"
+	} else if p.includeSubgroups {
+		query.Set("include_subgroups", "true")
+	}
+
+	for page := first; page != 0; {
+		query.Set("page", strconv.Itoa(page))
+
+		var projects []gitLabProject
+		path := fmt.Sprintf("/%s/%s/projects?%s", owners, url.PathEscape(organization), query.Encode())
+		resp, err := p.get(ctx, path, &projects)
+		if err != nil {
+			if errors.Is(err, ErrNotFound) && p.ownerType != OwnerTypeUser {
+				return nil, nil, fmt.Errorf("%w, set the owner type to %q for personal accounts", err, OwnerTypeUser)
+			}
+			return interrupted(err, &ListCursor{Page: page, Repositories: allRepos})
+		}
+
+		// Filter and collect project paths relative to the group
+		for i := range projects {
+			name := strings.TrimPrefix(projects[i].PathWithNamespace, organization+"/")
+			if projects[i].PathWithNamespace == "" {
+				name = projects[i].Path
+			}
+			if reason := p.exclusionReason(&projects[i]); reason != "" {
+				reportExcluded(ctx, name, reason)
+				continue
+			}
+			allRepos = append(allRepos, name)
+		}
+
+		page, _ = strconv.Atoi(resp.Header.Get("X-Next-Page"))
+		if limitExceeded(len(allRepos), p.maxRepositories) {
+			break
+		}
+	}
+
+	return allRepos, nil, nil
+}
+
+// GetRepositoryDetails fetches detailed information about a specific project
+func (p *GitLabProvider) GetRepositoryDetails(ctx context.Context, organization, repository string) (*Repository, error) {
+	var project gitLabProject
+	if _, err := p.get(ctx, "/projects/"+projectID(organization, repository), &project); err != nil {
+		return nil, err
+	}
+
+	return p.convertToRepository(&project), nil
+}
+
+// ResolveCommit returns the SHA of the commit at the head of a branch
+func (p *GitLabProvider) ResolveCommit(ctx context.Context, organization, repository, branch string) (string, error) {
+	var b struct {
+		Commit struct {
+			ID string `json:"id"`
+		} `json:"commit"`
+	}
+	path := fmt.Sprintf("/projects/%s/repository/branches/%s", projectID(organization, repository), url.PathEscape(branch))
+	if _, err := p.get(ctx, path, &b); err != nil {
+		return "", err
+	}
+
+	return b.Commit.ID, nil
+}
+
+// GetProviderType returns the provider type
+func (p *GitLabProvider) GetProviderType() ProviderType {
+	return ProviderTypeGitLab
+}
+
+// GetScorecardURL returns the OpenSSF Scorecard URL for a GitLab project,
+// addressed by the host of the configured instance and its full path
+func (p *GitLabProvider) GetScorecardURL(organization, repository string) string {
+	return fmt.Sprintf("%s/%s/%s", p.scorecardURL, organization, repository)
+}
+
+// projectID returns the URL-encoded full path GitLab accepts as a project ID
+func projectID(organization, repository string) string {
+	return url.PathEscape(organization + "/" + repository)
+}
+
+// get performs an API request and decodes the JSON response into v
+func (p *GitLabProvider) get(ctx context.Context, path string, v interface{}) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.baseURL+"/api/v4"+path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if p.token != "" {
+		req.Header.Set("PRIVATE-TOKEN", p.token)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query GitLab API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, p.handleError(resp)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return nil, fmt.Errorf("failed to decode GitLab API response: %w", err)
+	}
+	return resp, nil
+}
+
+// handleError maps GitLab API error responses to internal error types
+func (p *GitLabProvider) handleError(resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	message := strings.TrimSpace(string(body))
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		rlErr := NewRateLimitError(ProviderTypeGitLab, message)
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+			rlErr.WithRetryAfter(time.Duration(seconds) * time.Second)
+		}
+		return rlErr
+	}
+
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		return NewAuthError(ProviderTypeGitLab, resp.StatusCode, message)
+	}
+
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return NewTransientError(ProviderTypeGitLab, resp.StatusCode, message)
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("gitlab API returned status %d: %s: %w", resp.StatusCode, message, ErrNotFound)
+	}
+
+	return fmt.Errorf("gitlab API returned status %d: %s", resp.StatusCode, message)
+}
+
+// exclusionReason returns why a project is left out of the results, or an
+// empty string if it is included
+func (p *GitLabProvider) exclusionReason(project *gitLabProject) string {
+	// Internal projects are not public either
+	if reason := visibilityExclusionReason(p.visibility, project.Visibility != "" && project.Visibility != "public"); reason != "" {
+		return reason
+	}
+	switch {
+	case project.Archived:
+		return ExclusionReasonArchived
+	case project.ForkedFromProject != nil:
+		return ExclusionReasonFork
+	case project.Mirror:
+		return ExclusionReasonMirror
+	case project.EmptyRepo:
+		return ExclusionReasonEmpty
+	case inactive(project.LastActivityAt, p.maxAge):
+		return ExclusionReasonInactive
+	}
+	return ""
+}
+
+// convertToRepository converts a GitLab project to the generic Repository type
+func (p *GitLabProvider) convertToRepository(project *gitLabProject) *Repository {
+	return &Repository{
+		Name:          project.Path,
+		FullName:      project.PathWithNamespace,
+		URL:           project.WebURL,
+		DefaultBranch: project.DefaultBranch,
+		IsPrivate:     project.Visibility != "public",
+		IsArchived:    project.Archived,
+		IsFork:        project.ForkedFromProject != nil,
+	}
+}
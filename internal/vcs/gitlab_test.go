@@ -0,0 +1,376 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vcs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newGitLabServer starts a GitLab API stub serving the projects of the
+// "group" group, one per page. Projects in subgroups are only listed when
+// subgroups are included, as the real API does.
+func newGitLabServer(t *testing.T, projects []gitLabProject) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v4/groups/group/projects", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("PRIVATE-TOKEN") != "test-token" {
+			http.Error(w, `{"message": "401 Unauthorized"}`, http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Query().Get("visibility") != "public" {
+			t.Errorf("visibility = %q, want public", r.URL.Query().Get("visibility"))
+		}
+
+		var listed []gitLabProject
+		for _, project := range projects {
+			nested := project.PathWithNamespace != "group/"+project.Path
+			if !nested || r.URL.Query().Get("include_subgroups") == "true" {
+				listed = append(listed, project)
+			}
+		}
+
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if page < len(listed) {
+			w.Header().Set("X-Next-Page", strconv.Itoa(page+1))
+		}
+		_ = json.NewEncoder(w).Encode(listed[page-1 : min(page, len(listed))])
+	})
+	mux.HandleFunc("GET /api/v4/groups/someone/projects", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message": "404 Group Not Found"}`, http.StatusNotFound)
+	})
+	mux.HandleFunc("GET /api/v4/users/someone/projects", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode([]gitLabProject{{Path: "project", PathWithNamespace: "someone/project", Visibility: "public"}})
+	})
+	mux.HandleFunc("GET /api/v4/groups/limited/projects", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "30")
+		http.Error(w, "too many requests", http.StatusTooManyRequests)
+	})
+	mux.HandleFunc("GET /api/v4/projects/{id}", func(w http.ResponseWriter, r *http.Request) {
+		for _, project := range projects {
+			if project.PathWithNamespace == r.PathValue("id") {
+				_ = json.NewEncoder(w).Encode(project)
+				return
+			}
+		}
+		http.Error(w, `{"message": "404 Project Not Found"}`, http.StatusNotFound)
+	})
+	mux.HandleFunc("GET /api/v4/projects/{id}/repository/branches/main", func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("id") != "group/sub/project" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(`{"name": "main", "commit": {"id": "abc123"}}`))
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+// testGitLabProjects are projects of the "group" group and its subgroups
+var testGitLabProjects = []gitLabProject{
+	{Path: "project", PathWithNamespace: "group/project", Visibility: "public", DefaultBranch: "main"},
+	{Path: "project", PathWithNamespace: "group/sub/project", Visibility: "public"},
+	{Path: "deep", PathWithNamespace: "group/sub/nested/deep", Visibility: "public"},
+	{Path: "internal", PathWithNamespace: "group/internal", Visibility: "internal"},
+	{Path: "archived", PathWithNamespace: "group/archived", Visibility: "public", Archived: true},
+	{Path: "empty", PathWithNamespace: "group/sub/empty", Visibility: "public", EmptyRepo: true},
+}
+
+func TestGitLabProvider_GetRepositories(t *testing.T) {
+	server := newGitLabServer(t, testGitLabProjects)
+
+	tests := []struct {
+		name             string
+		baseURL          string
+		includeSubgroups bool
+		defaultToken     bool
+		expected         []string
+		expectedExcluded map[string]string
+	}{
+		{
+			name:     "group projects only",
+			baseURL:  server.URL,
+			expected: []string{"project"},
+			expectedExcluded: map[string]string{
+				"internal": ExclusionReasonPrivate,
+				"archived": ExclusionReasonArchived,
+			},
+		},
+		{
+			name:             "subgroups included",
+			baseURL:          server.URL + "/api/v4/",
+			includeSubgroups: true,
+			defaultToken:     true,
+			expected:         []string{"project", "sub/project", "sub/nested/deep"},
+			expectedExcluded: map[string]string{
+				"internal":  ExclusionReasonPrivate,
+				"archived":  ExclusionReasonArchived,
+				"sub/empty": ExclusionReasonEmpty,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &Config{
+				Type:             ProviderTypeGitLab,
+				Token:            "test-token",
+				BaseURL:          tt.baseURL,
+				IncludeSubgroups: tt.includeSubgroups,
+			}
+			// The server accepts the manager-level default token as well
+			if tt.defaultToken {
+				config.Token, config.DefaultToken = "", config.Token
+			}
+			provider, err := NewGitLabProvider(config)
+			if err != nil {
+				t.Fatalf("NewGitLabProvider() unexpected error: %v", err)
+			}
+
+			excluded := map[string]string{}
+			ctx := WithExclusionHandler(context.Background(), func(repository, reason string) {
+				excluded[repository] = reason
+			})
+			got, err := provider.GetRepositories(ctx, "group")
+			if err != nil {
+				t.Fatalf("GetRepositories() unexpected error: %v", err)
+			}
+			if !slices.Equal(got, tt.expected) {
+				t.Errorf("GetRepositories() = %v, want %v", got, tt.expected)
+			}
+			if !maps.Equal(excluded, tt.expectedExcluded) {
+				t.Errorf("excluded = %v, want %v", excluded, tt.expectedExcluded)
+			}
+
+			// Subgroup paths are kept in the scorecard URL
+			for _, repo := range got {
+				want := strings.TrimPrefix(server.URL, "http://") + "/group/" + repo
+				if got := provider.GetScorecardURL("group", repo); got != want {
+					t.Errorf("GetScorecardURL() = %q, want %q", got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestGitLabProvider_GetRepositories_MaxRepositoryAge(t *testing.T) {
+	server := newGitLabServer(t, []gitLabProject{
+		{Path: "recent", PathWithNamespace: "group/recent", Visibility: "public", LastActivityAt: time.Now().Add(-24 * time.Hour)},
+		{Path: "old", PathWithNamespace: "group/old", Visibility: "public", LastActivityAt: time.Now().Add(-100 * 24 * time.Hour)},
+	})
+
+	provider, err := NewGitLabProvider(&Config{
+		Type:             ProviderTypeGitLab,
+		Token:            "test-token",
+		BaseURL:          server.URL,
+		MaxRepositoryAge: 30 * 24 * time.Hour,
+	})
+	if err != nil {
+		t.Fatalf("NewGitLabProvider() unexpected error: %v", err)
+	}
+
+	excluded := map[string]string{}
+	ctx := WithExclusionHandler(context.Background(), func(repository, reason string) {
+		excluded[repository] = reason
+	})
+	got, err := provider.GetRepositories(ctx, "group")
+	if err != nil {
+		t.Fatalf("GetRepositories() unexpected error: %v", err)
+	}
+
+	if !slices.Equal(got, []string{"recent"}) {
+		t.Errorf("GetRepositories() = %v, want [recent]", got)
+	}
+	if expected := map[string]string{"old": ExclusionReasonInactive}; !maps.Equal(excluded, expected) {
+		t.Errorf("excluded = %v, want %v", excluded, expected)
+	}
+}
+
+func TestGitLabProvider_GetRepositories_Errors(t *testing.T) {
+	server := newGitLabServer(t, testGitLabProjects)
+
+	tests := []struct {
+		name      string
+		token     string
+		ownerType OwnerType
+		owner     string
+		check     func(error) bool
+	}{
+		{
+			name:  "invalid token",
+			token: "expired-token",
+			owner: "group",
+			check: IsAuthError,
+		},
+		{
+			name:  "rate limited",
+			owner: "limited",
+			check: func(err error) bool { return IsRateLimitError(err) && GetRetryAfter(err) == 30*time.Second },
+		},
+		{
+			name:  "user listed as group",
+			owner: "someone",
+			check: func(err error) bool { return errors.Is(err, ErrNotFound) },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider, err := NewGitLabProvider(&Config{Type: ProviderTypeGitLab, Token: tt.token, BaseURL: server.URL})
+			if err != nil {
+				t.Fatalf("NewGitLabProvider() unexpected error: %v", err)
+			}
+			if _, err := provider.GetRepositories(context.Background(), tt.owner); !tt.check(err) {
+				t.Errorf("GetRepositories() error = %v, unexpected classification", err)
+			}
+		})
+	}
+}
+
+func TestGitLabProvider_GetRepositories_MaxRepositories(t *testing.T) {
+	const pages = 7
+
+	// Every page holds a project and an archived project, which is filtered out
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if page < pages {
+			w.Header().Set("X-Next-Page", strconv.Itoa(page+1))
+		}
+		_ = json.NewEncoder(w).Encode([]gitLabProject{
+			{Path: "project-" + strconv.Itoa(page), Visibility: "public"},
+			{Path: "archived-" + strconv.Itoa(page), Visibility: "public", Archived: true},
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	provider, err := NewGitLabProvider(&Config{BaseURL: server.URL, MaxRepositories: 2})
+	if err != nil {
+		t.Fatalf("NewGitLabProvider() unexpected error: %v", err)
+	}
+	repos, err := provider.GetRepositories(context.Background(), "group")
+	if err != nil {
+		t.Fatalf("GetRepositories() unexpected error: %v", err)
+	}
+
+	// Listing stops at the page that exceeds the limit after filtering
+	if expected := []string{"project-1", "project-2", "project-3"}; !slices.Equal(repos, expected) {
+		t.Errorf("GetRepositories() = %v, want %v", repos, expected)
+	}
+	if requests != 3 {
+		t.Errorf("requests = %d, want 3", requests)
+	}
+}
+
+func TestGitLabProvider_GetRepositories_User(t *testing.T) {
+	server := newGitLabServer(t, nil)
+
+	provider, err := NewGitLabProvider(&Config{Type: ProviderTypeGitLab, BaseURL: server.URL, OwnerType: OwnerTypeUser})
+	if err != nil {
+		t.Fatalf("NewGitLabProvider() unexpected error: %v", err)
+	}
+	repos, err := provider.GetRepositories(context.Background(), "someone")
+	if err != nil || !slices.Equal(repos, []string{"project"}) {
+		t.Errorf("GetRepositories() = %v, %v, want [project]", repos, err)
+	}
+}
+
+func TestGitLabProvider_GetRepositoryDetails(t *testing.T) {
+	server := newGitLabServer(t, testGitLabProjects)
+
+	provider, err := NewGitLabProvider(&Config{Type: ProviderTypeGitLab, BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("NewGitLabProvider() unexpected error: %v", err)
+	}
+
+	repo, err := provider.GetRepositoryDetails(context.Background(), "group", "project")
+	if err != nil {
+		t.Fatalf("GetRepositoryDetails() unexpected error: %v", err)
+	}
+	if repo.FullName != "group/project" || repo.DefaultBranch != "main" || repo.IsPrivate {
+		t.Errorf("GetRepositoryDetails() = %+v, unexpected repository", repo)
+	}
+
+	if _, err := provider.GetRepositoryDetails(context.Background(), "group", "missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetRepositoryDetails() error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestGitLabProvider_GetScorecardURL(t *testing.T) {
+	tests := []struct {
+		name       string
+		baseURL    string
+		repository string
+		expected   string
+	}{
+		{
+			name:       "default instance",
+			repository: "project",
+			expected:   "gitlab.com/group/project",
+		},
+		{
+			name:       "subgroup project on a self-hosted instance",
+			baseURL:    "https://gitlab.example.com/api/v4",
+			repository: "sub/project",
+			expected:   "gitlab.example.com/group/sub/project",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider, err := NewGitLabProvider(&Config{Type: ProviderTypeGitLab, BaseURL: tt.baseURL})
+			if err != nil {
+				t.Fatalf("NewGitLabProvider() unexpected error: %v", err)
+			}
+			if got := provider.GetScorecardURL("group", tt.repository); got != tt.expected {
+				t.Errorf("GetScorecardURL() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGitLabProvider_ResolveCommit(t *testing.T) {
+	server := newGitLabServer(t, testGitLabProjects)
+
+	provider, err := NewGitLabProvider(&Config{Type: ProviderTypeGitLab, BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("NewGitLabProvider() unexpected error: %v", err)
+	}
+	resolver := provider.(CommitResolver)
+
+	commit, err := resolver.ResolveCommit(context.Background(), "group", "sub/project", "main")
+	if err != nil || commit != "abc123" {
+		t.Errorf("ResolveCommit() = %q, %v, want abc123", commit, err)
+	}
+
+	if _, err := resolver.ResolveCommit(context.Background(), "group", "project", "missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("ResolveCommit() error = %v, want ErrNotFound", err)
+	}
+}
@@ -18,7 +18,18 @@ package vcs
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/giantswarm/openssf-scorecard-exporter/internal/httpclient"
 )
 
 // ProviderType represents the type of version control system
@@ -27,6 +38,54 @@ type ProviderType string
 const (
 	// ProviderTypeGitHub represents GitHub as the VCS provider
 	ProviderTypeGitHub ProviderType = "github"
+
+	// ProviderTypeGitea represents Gitea as the VCS provider
+	ProviderTypeGitea ProviderType = "gitea"
+
+	// ProviderTypeGitLab represents GitLab as the VCS provider
+	ProviderTypeGitLab ProviderType = "gitlab"
+)
+
+// OwnerType is the kind of account owning the monitored repositories
+type OwnerType string
+
+const (
+	// OwnerTypeOrganization lists the repositories of an organization
+	OwnerTypeOrganization OwnerType = "org"
+
+	// OwnerTypeUser lists the repositories of a personal account
+	OwnerTypeUser OwnerType = "user"
+)
+
+// Visibility selects repositories by whether they are public
+type Visibility string
+
+const (
+	// VisibilityPublic lists public repositories only
+	VisibilityPublic Visibility = "public"
+
+	// VisibilityPrivate lists repositories that are not public only
+	VisibilityPrivate Visibility = "private"
+
+	// VisibilityAll lists repositories regardless of their visibility
+	VisibilityAll Visibility = "all"
+)
+
+// Affiliation is a relationship through which the token has access to a
+// repository
+type Affiliation string
+
+const (
+	// AffiliationOwner lists repositories owned by the token owner
+	AffiliationOwner Affiliation = "owner"
+
+	// AffiliationCollaborator lists repositories the token owner was added to
+	// as a collaborator
+	AffiliationCollaborator Affiliation = "collaborator"
+
+	// AffiliationOrganizationMember lists repositories the token owner can
+	// access as a member of the organization, including through teams
+	AffiliationOrganizationMember Affiliation = "organization_member"
 )
 
 // Repository represents a version control repository
@@ -74,6 +133,91 @@ type Provider interface {
 	GetScorecardURL(organization, repository string) string
 }
 
+// CommitResolver is implemented by providers that can resolve a branch to the
+// commit at its head
+type CommitResolver interface {
+	// ResolveCommit returns the SHA of the commit at the head of a branch.
+	// It returns an error wrapping ErrNotFound when the branch does not exist.
+	ResolveCommit(ctx context.Context, organization, repository, branch string) (string, error)
+}
+
+// RepositorySearcher is implemented by providers that can discover
+// repositories through a search query instead of listing those of an owner
+type RepositorySearcher interface {
+	// SearchRepositories returns the full names, e.g. "org/repo", of the
+	// repositories matching a query in the search syntax of the provider,
+	// leaving out the same repositories as GetRepositories.
+	SearchRepositories(ctx context.Context, query string) ([]string, error)
+}
+
+// ResumableLister is implemented by providers that can resume listing the
+// repositories of an owner where a rate limit interrupted a previous listing,
+// instead of starting over from the first page
+type ResumableLister interface {
+	// ResumeRepositories lists repositories like GetRepositories, starting at
+	// cursor unless it is nil. When a rate limit interrupts the listing, it
+	// returns the cursor to resume it from along with the error.
+	ResumeRepositories(ctx context.Context, organization string, cursor *ListCursor) ([]string, *ListCursor, error)
+}
+
+// ListCursor records how far a listing of repositories interrupted by a rate
+// limit got
+type ListCursor struct {
+	// Page is the next page to fetch, for APIs paginating by page number
+	Page int
+
+	// After is the cursor of the next page, for APIs paginating by cursor
+	After string
+
+	// Repositories are the repositories listed from the previous pages
+	Repositories []string
+}
+
+// interrupted returns the result of a listing that failed with err, with the
+// cursor to resume it from if err is a rate limit
+func interrupted(err error, cursor *ListCursor) ([]string, *ListCursor, error) {
+	if !IsRateLimitError(err) {
+		return nil, nil, err
+	}
+	return nil, cursor, err
+}
+
+// TokenProber is implemented by providers that can tell what the token they
+// authenticate with has access to
+type TokenProber interface {
+	// ProbeToken reports the kind and scopes of the token, and whether it
+	// appears to be able to read the organization, with a single request.
+	ProbeToken(ctx context.Context, organization string) (*TokenInfo, error)
+}
+
+// Kinds of tokens reported by TokenProber
+const (
+	TokenKindAnonymous   = "anonymous"
+	TokenKindClassic     = "classic"
+	TokenKindFineGrained = "fine-grained"
+	TokenKindApp         = "app"
+	TokenKindUnknown     = "unknown"
+)
+
+// TokenInfo describes what a token has access to, as far as a probe can tell
+type TokenInfo struct {
+	// Kind is the kind of token, e.g. TokenKindFineGrained
+	Kind string
+
+	// Scopes are the scopes granted to the token, for tokens that report them
+	Scopes []string
+
+	// OrganizationAccess is false when the token appears unable to read the
+	// organization, in which case it may list none of its repositories
+	// instead of failing
+	OrganizationAccess bool
+
+	// PublicRepositories is the number of public repositories the
+	// organization reports, 0 if unknown. A token listing none of them
+	// instead of failing lacks access to the organization.
+	PublicRepositories int
+}
+
 // Config represents configuration for a VCS provider
 type Config struct {
 	// Type is the provider type (github, gitlab, etc.)
@@ -82,11 +226,160 @@ type Config struct {
 	// Token is the authentication token (optional)
 	Token string
 
+	// DefaultToken is the manager-level token used when Token is empty
+	// (optional). It authenticates the provider only, and is not meant to
+	// be passed on elsewhere.
+	DefaultToken string
+
+	// Username is sent along with Token as HTTP basic auth credentials by
+	// providers that support it (optional). Providers authenticating with
+	// bearer tokens ignore it.
+	Username string
+
+	// AppID is the GitHub App ID. When set together with InstallationID and
+	// AppPrivateKey, the provider authenticates as a GitHub App installation
+	// instead of using Token.
+	AppID int64
+
+	// InstallationID is the GitHub App installation ID
+	InstallationID int64
+
+	// AppPrivateKey is the PEM-encoded GitHub App private key
+	AppPrivateKey []byte
+
 	// BaseURL is the base URL for the VCS API (for self-hosted instances)
 	BaseURL string
 
 	// Organization is the organization/group to monitor
 	Organization string
+
+	// OwnerType is the kind of account owning the repositories, an
+	// organization if empty
+	OwnerType OwnerType
+
+	// Visibility selects repositories by visibility, public ones if empty.
+	// Listing repositories that are not public requires a token with access
+	// to them.
+	Visibility Visibility
+
+	// Affiliations lists the repositories the token has access to through
+	// these relationships, keeping those of the owner, instead of listing the
+	// repositories of the owner directly. For providers supporting it.
+	Affiliations []Affiliation
+
+	// Team lists the repositories of the team of the organization with this
+	// slug instead of all repositories of the organization, for providers
+	// supporting it. Listing the repositories of teams that are not visible
+	// to everyone requires a token with access to the team.
+	Team string
+
+	// IncludeSubgroups lists the repositories of nested groups as well, for
+	// providers supporting them
+	IncludeSubgroups bool
+
+	// GraphQL lists repositories through the GraphQL API instead of the REST
+	// API, for providers supporting it
+	GraphQL bool
+
+	// MaxRepositories stops listing repositories at the first page that takes
+	// the number of repositories left after filtering past it, so that no more
+	// pages are fetched than needed while callers can still tell that the
+	// limit was exceeded. Zero means no limit.
+	MaxRepositories int
+
+	// MaxRepositoryAge leaves out repositories that were last pushed to, or
+	// for providers not reporting pushes last updated, longer ago. Zero
+	// means no limit.
+	MaxRepositoryAge time.Duration
+
+	// Transport is the base HTTP transport for API requests (optional).
+	// Providers add authentication on top of it.
+	Transport http.RoundTripper
+
+	// ProxyURL routes API requests through this proxy instead of the one of
+	// Transport (optional), so that VCS requests can use another proxy than
+	// those to the scorecard API
+	ProxyURL string
+
+	// RateLimiter paces requests to the GitHub API (optional). It is shared
+	// between GitHub providers so that their combined request rate is limited.
+	// GitLab and Gitea providers ignore it, as its rate is that of GitHub.
+	RateLimiter *rate.Limiter
+
+	// SearchRateLimiter additionally paces requests to the search API of
+	// providers that have one (optional), which allows far fewer requests
+	// than the rest of the API. It is shared between providers as well.
+	SearchRateLimiter *rate.Limiter
+}
+
+// baseTransport returns the transport of API requests, routing them through
+// the proxy of the config if one is set
+func baseTransport(config *Config) (http.RoundTripper, error) {
+	if config.ProxyURL == "" {
+		if config.Transport == nil {
+			return http.DefaultTransport, nil
+		}
+		return config.Transport, nil
+	}
+
+	proxyURL, err := url.Parse(config.ProxyURL)
+	if err != nil || proxyURL.Host == "" {
+		return nil, fmt.Errorf("invalid proxy URL %q", config.ProxyURL)
+	}
+	if config.Transport == nil {
+		return httpclient.NewTransport(httpclient.Options{ProxyURL: config.ProxyURL})
+	}
+	return httpclient.NewProxyTransport(config.Transport, proxyURL), nil
+}
+
+// AccessToken returns the token provider requests are authenticated with,
+// Token or else DefaultToken. An empty token means anonymous access.
+func (c *Config) AccessToken() string {
+	if c.Token != "" {
+		return c.Token
+	}
+	return c.DefaultToken
+}
+
+// parseInstanceURL normalizes the base URL of a GitLab or Gitea instance,
+// which defaults to defaultURL, accepting its API URL ending in apiPath as well.
+// It returns the instance URL without trailing slash along with its parsed form.
+func parseInstanceURL(baseURL, defaultURL, apiPath string) (string, *url.URL, error) {
+	instanceURL := baseURL
+	if instanceURL == "" {
+		instanceURL = defaultURL
+	}
+	instanceURL = strings.TrimSuffix(instanceURL, "/")
+	instanceURL = strings.TrimSuffix(instanceURL, apiPath)
+
+	u, err := url.Parse(instanceURL)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to parse base URL: %w", err)
+	}
+	if u.Host == "" {
+		return "", nil, fmt.Errorf("invalid base URL %q: missing host", baseURL)
+	}
+	return instanceURL, u, nil
+}
+
+// ValidateBaseURL checks a base URL the way the built-in provider of the given
+// type does when it is created, without creating it. Base URLs of other
+// provider types are not checked.
+func ValidateBaseURL(providerType ProviderType, baseURL string) error {
+	if baseURL == "" {
+		return nil
+	}
+
+	var err error
+	switch providerType {
+	case ProviderTypeGitHub:
+		_, err = parseGitHubBaseURL(baseURL)
+	case ProviderTypeGitea:
+		_, _, err = parseInstanceURL(baseURL, DefaultGiteaURL, "/api/v1")
+	case ProviderTypeGitLab:
+		_, _, err = parseInstanceURL(baseURL, DefaultGitLabURL, "/api/v4")
+	}
+	return err
 }
 
 // ProviderFactory creates VCS providers based on configuration
@@ -102,6 +395,8 @@ func NewProviderFactory() *ProviderFactory {
 
 	// Register built-in providers
 	factory.Register(ProviderTypeGitHub, NewGitHubProvider)
+	factory.Register(ProviderTypeGitea, NewGiteaProvider)
+	factory.Register(ProviderTypeGitLab, NewGitLabProvider)
 
 	return factory
 }
@@ -115,17 +410,33 @@ func (f *ProviderFactory) Register(providerType ProviderType, constructor func(*
 func (f *ProviderFactory) CreateProvider(config *Config) (Provider, error) {
 	constructor, exists := f.providers[config.Type]
 	if !exists {
-		return nil, fmt.Errorf("unsupported provider type: %s", config.Type)
+		return nil, fmt.Errorf("unsupported provider type: %s (supported: %v)", config.Type, f.GetSupportedProviders())
 	}
 
 	return constructor(config)
 }
 
-// GetSupportedProviders returns a list of supported provider types
+// GetSupportedProviders returns the registered provider types in sorted order
 func (f *ProviderFactory) GetSupportedProviders() []ProviderType {
-	types := make([]ProviderType, 0, len(f.providers))
-	for t := range f.providers {
-		types = append(types, t)
+	return slices.Sorted(maps.Keys(f.providers))
+}
+
+// Validate checks that at least one provider is registered, along with each
+// of the required provider types
+func (f *ProviderFactory) Validate(required ...ProviderType) error {
+	if len(f.providers) == 0 {
+		return errors.New("no VCS providers registered")
+	}
+
+	var missing []string
+	for _, providerType := range required {
+		if _, exists := f.providers[providerType]; !exists {
+			missing = append(missing, string(providerType))
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("required VCS providers not registered: %s (supported: %v)",
+			strings.Join(missing, ", "), f.GetSupportedProviders())
 	}
-	return types
+	return nil
 }
@@ -0,0 +1,104 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vcs
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestProviderFactory_GetSupportedProviders(t *testing.T) {
+	factory := NewProviderFactory()
+
+	expected := []ProviderType{ProviderTypeGitea, ProviderTypeGitHub, ProviderTypeGitLab}
+	if got := factory.GetSupportedProviders(); !slices.Equal(got, expected) {
+		t.Errorf("GetSupportedProviders() = %v, want %v", got, expected)
+	}
+}
+
+func TestProviderFactory_Validate(t *testing.T) {
+	tests := []struct {
+		name      string
+		factory   *ProviderFactory
+		required  []ProviderType
+		expectErr string
+	}{
+		{
+			name:    "built-in providers",
+			factory: NewProviderFactory(),
+		},
+		{
+			name:     "required providers registered",
+			factory:  NewProviderFactory(),
+			required: []ProviderType{ProviderTypeGitHub, ProviderTypeGitLab},
+		},
+		{
+			name:      "required provider missing",
+			factory:   NewProviderFactory(),
+			required:  []ProviderType{ProviderTypeGitHub, "bitbucket"},
+			expectErr: "required VCS providers not registered: bitbucket",
+		},
+		{
+			name:      "no providers",
+			factory:   &ProviderFactory{providers: map[ProviderType]func(*Config) (Provider, error){}},
+			expectErr: "no VCS providers registered",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.factory.Validate(tt.required...)
+			if tt.expectErr == "" {
+				if err != nil {
+					t.Errorf("Validate() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.expectErr) {
+				t.Errorf("Validate() error = %v, want it to contain %q", err, tt.expectErr)
+			}
+		})
+	}
+}
+
+func TestValidateBaseURL(t *testing.T) {
+	tests := []struct {
+		name         string
+		providerType ProviderType
+		baseURL      string
+		expectErr    bool
+	}{
+		{name: "default instance", providerType: ProviderTypeGitHub},
+		{name: "GitHub Enterprise Server", providerType: ProviderTypeGitHub, baseURL: "https://github.mycorp.com/api/v3/"},
+		{name: "GitHub URL without scheme", providerType: ProviderTypeGitHub, baseURL: "github.mycorp.com", expectErr: true},
+		{name: "GitLab instance", providerType: ProviderTypeGitLab, baseURL: "https://gitlab.mycorp.com/api/v4"},
+		{name: "GitLab URL without host", providerType: ProviderTypeGitLab, baseURL: "gitlab.mycorp.com", expectErr: true},
+		{name: "Gitea instance", providerType: ProviderTypeGitea, baseURL: "https://git.mycorp.com"},
+		{name: "Gitea URL without host", providerType: ProviderTypeGitea, baseURL: "/api/v1", expectErr: true},
+		{name: "other provider", providerType: "bitbucket", baseURL: "bitbucket.mycorp.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateBaseURL(tt.providerType, tt.baseURL)
+			if (err != nil) != tt.expectErr {
+				t.Errorf("ValidateBaseURL() error = %v, expectErr %v", err, tt.expectErr)
+			}
+		})
+	}
+}
@@ -20,8 +20,12 @@ import (
 	"crypto/tls"
 	"errors"
 	"flag"
+	"fmt"
+	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
@@ -36,12 +40,18 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/certwatcher"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
+	scorecardv1alpha1 "github.com/giantswarm/openssf-scorecard-exporter/api/v1alpha1"
 	"github.com/giantswarm/openssf-scorecard-exporter/internal/controller"
+	"github.com/giantswarm/openssf-scorecard-exporter/internal/debug"
+	"github.com/giantswarm/openssf-scorecard-exporter/internal/httpclient"
 	"github.com/giantswarm/openssf-scorecard-exporter/internal/metrics"
+	"github.com/giantswarm/openssf-scorecard-exporter/internal/report"
 	"github.com/giantswarm/openssf-scorecard-exporter/internal/scorecard"
 	"github.com/giantswarm/openssf-scorecard-exporter/internal/utils"
 	"github.com/giantswarm/openssf-scorecard-exporter/internal/vcs"
@@ -55,25 +65,75 @@ var (
 
 func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(scorecardv1alpha1.AddToScheme(scheme))
 
 	// +kubebuilder:scaffold:scheme
 }
 
 // nolint:gocyclo
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == fetchCommand {
+		os.Exit(runFetch(os.Args[2:], os.Stdout, os.Stderr))
+	}
+
 	var metricsAddr string
 	var metricsCertPath, metricsCertName, metricsCertKey string
 	var webhookCertPath, webhookCertName, webhookCertKey string
 	var enableLeaderElection bool
 	var probeAddr string
+	var reportAddr string
+	var debugAddr string
 	var secureMetrics bool
 	var enableHTTP2 bool
 	var maxJitterPercent int
-	var requeueInterval time.Duration
+	var maxConcurrentReconciles int
+	var failOnMissingOrganization bool
+	var denyOrganizations string
+	var excludeChecks string
+	var initialSyncWindow time.Duration
+	var reconcileBudget time.Duration
+	var requeueInterval, unavailableRequeueInterval time.Duration
+	var defaultTokenFile string
+	var scorecardCacheTTL, scorecardUnavailableCacheTTL, scorecardFreshnessWindow time.Duration
+	var scorecardTimeout time.Duration
+	var scorecardPathTemplate string
+	var proxyURL, vcsProxyURL, caBundleFile string
+	var outboundTLSMinVersion, outboundTLSCipherSuites string
+	var userAgent string
+	var scorecardBinary string
+	var scorecardHealthCheckInterval time.Duration
+	var disallowDefaultScorecardEndpoint bool
+	var githubRequestsPerSecond float64
+	var githubSearchRequestsPerMinute float64
+	var outboundRequestsPerSecond float64
+	var maxRepositories int
+	var unavailableValue string
+	var passThreshold int
+	var normalizeLabels bool
+	var namespaceLabel bool
+	var extraLabels string
+	var normalizeScores bool
+	var serveOpenMetrics bool
+	var pushgatewayURL, pushgatewayJob string
+	var shutdownFlushTimeout time.Duration
+	var enableScorecardTargets bool
+	var enableConfigMapWebhook bool
+	var requiredProviders string
+	var repositoryListTimeout, scorecardFetchTimeout time.Duration
+	var scorecardDeduplicate bool
+	var scorecardDetails bool
+	var scorecardBatchSize int
+	var providerCacheSize int
+	var scorecardCircuitBreakerThreshold int
+	var scorecardCircuitBreakerCooldown time.Duration
 	var tlsOpts []func(*tls.Config)
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metrics endpoint binds to. "+
 		"Use :8443 for HTTPS or :8080 for HTTP, or leave as 0 to disable the metrics service.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
+	flag.StringVar(&reportAddr, "report-bind-address", "0", "The address the endpoint serving the latest "+
+		"scorecard data of each repository as JSON binds to. Leave as 0 to disable it.")
+	flag.StringVar(&debugAddr, "debug-bind-address", "0", "The address the debug endpoint serving the "+
+		"reconcile state of each config as JSON binds to. Leave as 0 to disable it.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
@@ -89,9 +149,128 @@ func main() {
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
 	flag.IntVar(&maxJitterPercent, "max-jitter-percent", 10,
-		"The maximum percentage by which to jitter re-reconciliation.")
+		"The maximum percentage by which to jitter re-reconciliation, between 0 and 100. 0 disables jitter.")
+	flag.IntVar(&maxConcurrentReconciles, "max-concurrent-reconciles", 1,
+		"The number of configs reconciled in parallel. Each config is still reconciled serially.")
+	flag.BoolVar(&failOnMissingOrganization, "fail-on-missing-organization", false,
+		"If set, reconciles of configs without an organization fail with a terminal error instead of being skipped.")
+	flag.StringVar(&denyOrganizations, "deny-organizations", "",
+		"Comma-separated organizations that are never scanned. Configs targeting them are skipped with a warning.")
+	flag.StringVar(&excludeChecks, "exclude-checks", "",
+		"Comma-separated checks that are never exported, even if the checks of a config allow them.")
+	flag.DurationVar(&initialSyncWindow, "initial-sync-window", 0,
+		"Window over which the first reconciles of the configs existing on startup are randomly spread. 0 disables it.")
+	flag.DurationVar(&reconcileBudget, "reconcile-budget", 0,
+		"Time budget of a reconcile. Reconciles running out of it export the repositories done so far and "+
+			"continue with the remaining ones shortly after. 0 disables the limit.")
 	flag.DurationVar(&requeueInterval, "requeue-interval", utils.DefaultRequeueDuration,
 		"The interval for requeuing ConfigMap reconciliation to refresh scorecard data. Defaults to 1 hour +/- jitter.")
+	flag.DurationVar(&unavailableRequeueInterval, "unavailable-requeue-interval", 0,
+		"Shorter requeue interval for ConfigMaps with repositories without scorecard data. 0 disables it.")
+	flag.StringVar(&defaultTokenFile, "github-token-file", "",
+		"Path to a file containing the default VCS token, used when a ConfigMap does not reference a token secret. "+
+			"Takes precedence over the GITHUB_TOKEN environment variable.")
+	flag.DurationVar(&scorecardCacheTTL, "scorecard-cache-ttl", 0,
+		"How long to reuse fetched scorecard data before querying the API again. 0 disables caching.")
+	flag.DurationVar(&scorecardUnavailableCacheTTL, "scorecard-unavailable-cache-ttl", 0,
+		"How long to remember that scorecard data is not available for a repository. "+
+			"Keep shorter than --scorecard-cache-ttl so new reports are picked up sooner. 0 disables caching.")
+	flag.DurationVar(&scorecardFreshnessWindow, "scorecard-freshness-window", 0,
+		"How long after its report date scorecard data is reused instead of fetched again. "+
+			"Older reports are revalidated with a conditional request when the API supports it. 0 always fetches.")
+	flag.DurationVar(&scorecardTimeout, "scorecard-timeout", scorecard.DefaultTimeout,
+		"The timeout for requests to the OpenSSF Scorecard API.")
+	flag.StringVar(&scorecardPathTemplate, "scorecard-path-template", scorecard.DefaultPathTemplate,
+		"Path of the scorecard data of a project relative to the scorecard API endpoint, with %s standing for "+
+			"the project, e.g. \"/v2/projects/%s\" for mirrors with a different routing.")
+	flag.StringVar(&proxyURL, "proxy-url", "",
+		"Proxy for outbound requests to the scorecard API and VCS providers. "+
+			"Defaults to the HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment variables.")
+	flag.StringVar(&vcsProxyURL, "vcs-proxy-url", "",
+		"Proxy for outbound requests to VCS providers, overriding --proxy-url and the environment for them. "+
+			"Requests to the scorecard API keep using the other proxy.")
+	flag.StringVar(&userAgent, "user-agent", httpclient.DefaultUserAgent(),
+		"User-Agent of requests to the VCS and scorecard APIs. Empty keeps the User-Agent of the client libraries.")
+	flag.StringVar(&caBundleFile, "ca-bundle-file", "",
+		"Path to a PEM bundle of additional CA certificates to trust for outbound requests.")
+	flag.StringVar(&outboundTLSMinVersion, "outbound-tls-min-version", "1.2",
+		"Lowest TLS version of outbound requests to the scorecard API and VCS providers: 1.2 or 1.3.")
+	flag.StringVar(&outboundTLSCipherSuites, "outbound-tls-cipher-suites", "",
+		"Comma-separated cipher suites offered for outbound TLS 1.2 connections, "+
+			"e.g. \"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256\". Empty keeps Go's defaults.")
+	flag.StringVar(&scorecardBinary, "scorecard-binary", "",
+		"Path to the scorecard CLI used by ConfigMaps with source \"local\". "+
+			"Empty disables the local source.")
+	flag.DurationVar(&scorecardHealthCheckInterval, "scorecard-health-check-interval", scorecard.DefaultHealthCheckInterval,
+		"How often the readiness probe checks that the scorecard API is reachable. 0 disables the check.")
+	flag.BoolVar(&disallowDefaultScorecardEndpoint, "disallow-default-scorecard-endpoint", false,
+		"Refuse to fetch from the default scorecard API. ConfigMaps reading from the API must set scorecardAPIEndpoint.")
+	flag.Float64Var(&githubRequestsPerSecond, "github-requests-per-second", 10,
+		"Maximum rate of requests to the GitHub API across all ConfigMaps. 0 disables rate limiting. "+
+			"Requests to GitLab and Gitea are not paced by it.")
+	flag.Float64Var(&githubSearchRequestsPerMinute, "github-search-requests-per-minute", 30,
+		"Maximum rate of requests to the GitHub search API across all ConfigMaps, on top of "+
+			"--github-requests-per-second. 0 disables rate limiting.")
+	flag.Float64Var(&outboundRequestsPerSecond, "outbound-requests-per-second", 0,
+		"Maximum rate of all outbound requests, to the scorecard API and every VCS API, across all ConfigMaps. "+
+			"0 disables the global limit.")
+	flag.BoolVar(&scorecardDeduplicate, "scorecard-deduplicate", true,
+		"Share concurrent scorecard requests for the same repository between ConfigMaps.")
+	flag.BoolVar(&scorecardDetails, "scorecard-details", false,
+		"If set, keep the findings behind check scores returned by the scorecard API, "+
+			"exported as openssf_scorecard_check_details and in JSON reports.")
+	flag.IntVar(&scorecardBatchSize, "scorecard-batch-size", 0,
+		"Fetch the scorecard data of up to this many repositories per request from scorecard APIs serving "+
+			"the batch route, falling back to single requests otherwise. 0 fetches repositories one by one.")
+	flag.IntVar(&scorecardCircuitBreakerThreshold, "scorecard-circuit-breaker-threshold",
+		scorecard.DefaultCircuitBreakerThreshold,
+		"Number of consecutive scorecard API failures after which requests are suspended. 0 disables the circuit breaker.")
+	flag.DurationVar(&scorecardCircuitBreakerCooldown, "scorecard-circuit-breaker-cooldown",
+		scorecard.DefaultCircuitBreakerCooldown,
+		"How long requests to the scorecard API are suspended once the circuit breaker opens.")
+	flag.IntVar(&providerCacheSize, "provider-cache-size", controller.DefaultProviderCacheSize,
+		"Number of VCS API clients kept for reuse between reconciles, keyed by provider, base URL and token. "+
+			"0 creates a new client on every reconcile.")
+	flag.IntVar(&maxRepositories, "max-repositories", 0,
+		"Maximum number of repositories processed per reconcile for ConfigMaps that do not set maxRepositories. "+
+			"0 means no limit.")
+	flag.StringVar(&unavailableValue, "unavailable-value", string(controller.UnavailableValueNegativeOne),
+		"How repositories without scorecard data are exported for ConfigMaps that do not set unavailableValue: "+
+			"\"negative_one\" for a score of -1, \"nan\" for NaN, or \"absent\" for no series.")
+	flag.IntVar(&passThreshold, "pass-threshold", scorecard.DefaultPassThreshold,
+		"Lowest score of a passing check, between 1 and 10, for ConfigMaps that do not set passThreshold.")
+	flag.BoolVar(&normalizeScores, "normalize-scores", false,
+		"Export overall, check and average scores on a 0-1 scale instead of 0-10. Unavailable values are kept.")
+	flag.BoolVar(&serveOpenMetrics, "openmetrics", false,
+		"Also serve the metrics in the OpenMetrics format, with units and exemplars, at "+metrics.OpenMetricsPath+
+			" on the metrics endpoint.")
+	flag.StringVar(&pushgatewayURL, "pushgateway-url", "",
+		"URL of a Prometheus Pushgateway to push the metrics to at the end of each reconcile, "+
+			"in addition to serving them for scrape. Empty disables pushing.")
+	flag.StringVar(&pushgatewayJob, "pushgateway-job", "openssf-scorecard-exporter",
+		"Job label under which metrics are pushed to the Pushgateway.")
+	flag.DurationVar(&shutdownFlushTimeout, "shutdown-flush-timeout", controller.DefaultShutdownFlushTimeout,
+		"Time budget on shutdown for in-flight reconciles to return before the final push to the Pushgateway.")
+	flag.BoolVar(&normalizeLabels, "normalize-labels", false,
+		"Replace characters other than ASCII letters, digits, '-' and '_' in organization and repository labels with '_'.")
+	flag.BoolVar(&namespaceLabel, "namespace-label", false,
+		"Add a namespace label, the namespace of the ConfigMap or ScorecardTarget, to the metrics of each config.")
+	flag.StringVar(&extraLabels, "extra-labels", "",
+		"Comma-separated key=value labels added to all exported metrics, e.g. \"cluster=prod\", "+
+			"to tell apart the metrics of several clusters in one Prometheus.")
+	flag.StringVar(&requiredProviders, "required-providers", "",
+		"Comma-separated VCS provider types that must be registered, e.g. \"github,gitlab\". "+
+			"The manager fails to start if any of them is missing.")
+	flag.DurationVar(&repositoryListTimeout, "repository-list-timeout", controller.DefaultRepositoryListTimeout,
+		"Time budget for listing the repositories of a VCS instance, including retries. 0 disables the limit.")
+	flag.DurationVar(&scorecardFetchTimeout, "scorecard-fetch-timeout", controller.DefaultScorecardFetchTimeout,
+		"Time budget for fetching the scorecard data of a repository, including local scorecard runs. "+
+			"0 disables the limit.")
+	flag.BoolVar(&enableScorecardTargets, "enable-scorecard-targets", false,
+		"Reconcile ScorecardTarget resources in addition to ConfigMaps. Requires the ScorecardTarget CRD to be installed.")
+	flag.BoolVar(&enableConfigMapWebhook, "enable-configmap-webhook", false,
+		"Serve a validating webhook rejecting invalid scorecard ConfigMaps at "+controller.ConfigMapWebhookPath+". "+
+			"Requires a ValidatingWebhookConfiguration and a serving certificate, see --webhook-cert-path.")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -100,6 +279,32 @@ func main() {
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
+	if clamped := utils.ClampJitterPercent(maxJitterPercent); clamped != maxJitterPercent {
+		setupLog.Info("max-jitter-percent out of range, clamping", "value", maxJitterPercent, "clamped", clamped)
+		maxJitterPercent = clamped
+	}
+
+	defaultUnavailableValue, err := controller.ParseUnavailableValue(unavailableValue)
+	if err != nil {
+		setupLog.Error(err, "invalid unavailable-value")
+		os.Exit(1)
+	}
+
+	if passThreshold < 1 || passThreshold > 10 {
+		setupLog.Error(fmt.Errorf("%d is not between 1 and 10", passThreshold), "invalid pass-threshold")
+		os.Exit(1)
+	}
+
+	if err := checkScorecardEndpointFlags(disallowDefaultScorecardEndpoint, scorecardHealthCheckInterval); err != nil {
+		setupLog.Error(err, "invalid scorecard endpoint flags")
+		os.Exit(1)
+	}
+
+	if err := scorecard.ValidatePathTemplate(scorecardPathTemplate); err != nil {
+		setupLog.Error(err, "invalid scorecard-path-template")
+		os.Exit(1)
+	}
+
 	// if the enable-http2 flag is false (the default), http/2 should be disabled
 	// due to its vulnerabilities. More specifically, disabling http/2 will
 	// prevent from being vulnerable to the HTTP/2 Stream Cancellation and
@@ -162,6 +367,12 @@ func main() {
 		metricsServerOptions.FilterProvider = filters.WithAuthenticationAndAuthorization
 	}
 
+	if serveOpenMetrics {
+		metricsServerOptions.ExtraHandlers = map[string]http.Handler{
+			metrics.OpenMetricsPath: metrics.NewOpenMetricsHandler(ctrlmetrics.Registry),
+		}
+	}
+
 	// If the certificate is not specified, controller-runtime will automatically
 	// generate self-signed certificates for the metrics server. While convenient for development and testing,
 	// this setup is not recommended for production.
@@ -231,29 +442,213 @@ func main() {
 		os.Exit(1)
 	}
 
-	// Initialize OpenSSF Scorecard client
-	scorecardClient := scorecard.NewClient()
+	// Initialize the transport shared by outbound API clients
+	minTLSVersion, err := httpclient.ParseTLSVersion(outboundTLSMinVersion)
+	if err != nil {
+		setupLog.Error(err, "invalid outbound-tls-min-version")
+		os.Exit(1)
+	}
+	cipherSuites, err := httpclient.ParseCipherSuites(outboundTLSCipherSuites)
+	if err != nil {
+		setupLog.Error(err, "invalid outbound-tls-cipher-suites")
+		os.Exit(1)
+	}
+	baseTransport, err := httpclient.NewTransport(httpclient.Options{
+		ProxyURL:      proxyURL,
+		CAFile:        caBundleFile,
+		MinTLSVersion: minTLSVersion,
+		CipherSuites:  cipherSuites,
+	})
+	if err != nil {
+		setupLog.Error(err, "unable to create HTTP transport")
+		os.Exit(1)
+	}
+	transport := httpclient.NewUserAgentTransport(baseTransport, userAgent)
+	if vcsProxyURL != "" {
+		if u, err := url.Parse(vcsProxyURL); err != nil || u.Host == "" {
+			setupLog.Error(fmt.Errorf("invalid proxy URL %q", vcsProxyURL), "invalid vcs-proxy-url")
+			os.Exit(1)
+		}
+	}
+
+	// Pace all outbound requests together, on top of the per-API limits
+	transport = httpclient.NewRateLimitedTransport(transport, httpclient.NewLimiter(outboundRequestsPerSecond))
 
 	// Initialize Prometheus metrics collector
-	metricsCollector := metrics.NewCollector()
+	constLabels, err := metrics.ParseConstLabels(extraLabels)
+	if err != nil {
+		setupLog.Error(err, "invalid extra-labels")
+		os.Exit(1)
+	}
+	if _, ok := constLabels[metrics.NamespaceLabel]; ok && namespaceLabel {
+		setupLog.Error(fmt.Errorf("label name %q is already added by --namespace-label", metrics.NamespaceLabel),
+			"invalid extra-labels")
+		os.Exit(1)
+	}
+	metricsCollector := metrics.NewCollector(
+		metrics.WithConstLabels(constLabels),
+		metrics.WithLabelNormalization(normalizeLabels),
+		metrics.WithNamespaceLabel(namespaceLabel),
+		metrics.WithScoreNormalization(normalizeScores),
+	)
+
+	// Push the metrics to a Pushgateway as well when configured
+	var metricsPusher *metrics.Pusher
+	if pushgatewayURL != "" {
+		metricsPusher = metrics.NewPusher(metricsCollector, pushgatewayURL, pushgatewayJob)
+	}
+
+	// Initialize OpenSSF Scorecard client
+	scorecardClient := scorecard.NewClient(
+		scorecard.WithTimeout(scorecardTimeout),
+		scorecard.WithPathTemplate(scorecardPathTemplate),
+		scorecard.WithTransport(transport),
+		scorecard.WithCache(scorecardCacheTTL, scorecardUnavailableCacheTTL),
+		scorecard.WithFreshnessWindow(scorecardFreshnessWindow),
+		scorecard.WithCircuitBreaker(scorecardCircuitBreakerThreshold, scorecardCircuitBreakerCooldown),
+		scorecard.WithRequestObserver(metricsCollector.ObserveScorecardAPIRequest),
+		scorecard.WithDetails(scorecardDetails),
+	)
+
+	// Serve the latest scorecard data of each repository as JSON when enabled
+	var reportStore *report.Store
+	if reportAddr != "0" {
+		reportStore = report.NewStore()
+		if err := mgr.Add(&manager.Server{
+			Name: "report",
+			Server: &http.Server{
+				Addr:              reportAddr,
+				Handler:           report.NewHandler(reportStore),
+				ReadHeaderTimeout: 10 * time.Second,
+			},
+		}); err != nil {
+			setupLog.Error(err, "unable to set up report server")
+			os.Exit(1)
+		}
+	}
+
+	// Serve the reconcile state of each config for troubleshooting when enabled
+	var debugState *debug.Store
+	if debugAddr != "0" {
+		debugState = debug.NewStore()
+		if err := mgr.Add(&manager.Server{
+			Name: "debug",
+			Server: &http.Server{
+				Addr:              debugAddr,
+				Handler:           debug.NewHandler(debugState),
+				ReadHeaderTimeout: 10 * time.Second,
+			},
+		}); err != nil {
+			setupLog.Error(err, "unable to set up debug server")
+			os.Exit(1)
+		}
+	}
+
+	// Initialize the local scorecard runner when a binary is configured
+	var localScorecardSource scorecard.Source
+	if scorecardBinary != "" {
+		localScorecardSource = scorecard.NewLocalRunner(scorecardBinary, scorecard.WithLocalDetails(scorecardDetails))
+	}
+
+	// Share concurrent requests for the same repository between ConfigMaps
+	var apiScorecardSource scorecard.Source = scorecardClient
+	if scorecardDeduplicate {
+		apiScorecardSource = scorecard.Deduplicate(apiScorecardSource, scorecardFetchTimeout)
+		if localScorecardSource != nil {
+			localScorecardSource = scorecard.Deduplicate(localScorecardSource, scorecardFetchTimeout)
+		}
+	}
 
 	// Initialize VCS provider factory
 	providerFactory := vcs.NewProviderFactory()
+	var required []vcs.ProviderType
+	for providerType := range strings.SplitSeq(requiredProviders, ",") {
+		if providerType = strings.TrimSpace(providerType); providerType != "" {
+			required = append(required, vcs.ProviderType(providerType))
+		}
+	}
+	if err := providerFactory.Validate(required...); err != nil {
+		setupLog.Error(err, "invalid VCS provider registration")
+		os.Exit(1)
+	}
+	setupLog.Info("Registered VCS providers", "providers", providerFactory.GetSupportedProviders())
+
+	var deniedOrganizations []string
+	for organization := range strings.SplitSeq(denyOrganizations, ",") {
+		if organization = strings.TrimSpace(organization); organization != "" {
+			deniedOrganizations = append(deniedOrganizations, organization)
+		}
+	}
+	var excludedChecks []string
+	for check := range strings.SplitSeq(excludeChecks, ",") {
+		if check = strings.TrimSpace(check); check != "" {
+			excludedChecks = append(excludedChecks, check)
+		}
+	}
 
 	// Set up ConfigMap controller
-	if err = (&controller.ConfigMapReconciler{
-		Client:           mgr.GetClient(),
-		Scheme:           mgr.GetScheme(),
-		ScorecardClient:  scorecardClient,
-		MetricsCollector: metricsCollector,
-		ProviderFactory:  providerFactory,
-		MaxJitterPercent: maxJitterPercent,
-		RequeueInterval:  requeueInterval,
-	}).SetupWithManager(mgr); err != nil {
+	configMapReconciler := &controller.ConfigMapReconciler{
+		Client:                mgr.GetClient(),
+		Scheme:                mgr.GetScheme(),
+		Recorder:              mgr.GetEventRecorderFor("openssf-scorecard-exporter"),
+		ScorecardSource:       apiScorecardSource,
+		LocalScorecardSource:  localScorecardSource,
+		MetricsCollector:      metricsCollector,
+		Reports:               reportStore,
+		DebugState:            debugState,
+		ProviderFactory:       providerFactory,
+		MaxJitterPercent:      maxJitterPercent,
+		RequeueInterval:       requeueInterval,
+		DefaultToken:          os.Getenv("GITHUB_TOKEN"),
+		DefaultTokenFile:      defaultTokenFile,
+		VCSTransport:          transport,
+		VCSProxyURL:           vcsProxyURL,
+		VCSRateLimiter:        httpclient.NewLimiter(githubRequestsPerSecond),
+		VCSSearchRateLimiter:  httpclient.NewLimiter(githubSearchRequestsPerMinute / 60),
+		MaxRepositories:       maxRepositories,
+		UnavailableValue:      defaultUnavailableValue,
+		PassThreshold:         passThreshold,
+		RepositoryListTimeout: repositoryListTimeout,
+		ScorecardFetchTimeout: scorecardFetchTimeout,
+		ScorecardBatchSize:    scorecardBatchSize,
+		ProviderCacheSize:     providerCacheSize,
+		DeniedOrganizations:   deniedOrganizations,
+		ExcludedChecks:        excludedChecks,
+		InitialSyncWindow:     initialSyncWindow,
+		ReconcileBudget:       reconcileBudget,
+		MetricsPusher:         metricsPusher,
+		ShutdownFlushTimeout:  shutdownFlushTimeout,
+
+		MaxConcurrentReconciles:          maxConcurrentReconciles,
+		UnavailableRequeueInterval:       unavailableRequeueInterval,
+		DisallowDefaultScorecardEndpoint: disallowDefaultScorecardEndpoint,
+		FailOnMissingOrganization:        failOnMissingOrganization,
+	}
+	if err = configMapReconciler.SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ConfigMap")
 		os.Exit(1)
 	}
 
+	// Set up the ScorecardTarget controller, sharing the ConfigMap controller's clients
+	if enableScorecardTargets {
+		if err = (&controller.ScorecardTargetReconciler{
+			Client:     mgr.GetClient(),
+			Scheme:     mgr.GetScheme(),
+			ConfigMaps: configMapReconciler,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "ScorecardTarget")
+			os.Exit(1)
+		}
+	}
+
+	// Validate scorecard ConfigMaps against the ConfigMap controller's settings
+	if enableConfigMapWebhook {
+		if err = (&controller.ConfigMapValidator{Reconciler: configMapReconciler}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "ConfigMap")
+			os.Exit(1)
+		}
+	}
+
 	// +kubebuilder:scaffold:builder
 
 	if metricsCertWatcher != nil {
@@ -280,6 +675,13 @@ func main() {
 		setupLog.Error(err, "unable to set up ready check")
 		os.Exit(1)
 	}
+	if scorecardHealthCheckInterval > 0 {
+		healthChecker := scorecard.NewHealthChecker(scorecardClient, scorecardHealthCheckInterval)
+		if err := mgr.AddReadyzCheck("scorecard-api", healthChecker.Check); err != nil {
+			setupLog.Error(err, "unable to set up scorecard API ready check")
+			os.Exit(1)
+		}
+	}
 
 	setupLog.Info("starting manager")
 	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
@@ -287,3 +689,13 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+// checkScorecardEndpointFlags rejects flag combinations that would query the
+// default scorecard API although it is disallowed
+func checkScorecardEndpointFlags(disallowDefault bool, healthCheckInterval time.Duration) error {
+	if disallowDefault && healthCheckInterval > 0 {
+		return errors.New("the scorecard API health check queries the default endpoint, " +
+			"set --scorecard-health-check-interval=0 with --disallow-default-scorecard-endpoint")
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCheckScorecardEndpointFlags(t *testing.T) {
+	tests := []struct {
+		name                string
+		disallowDefault     bool
+		healthCheckInterval time.Duration
+		expectErr           bool
+	}{
+		{
+			name:                "default endpoint allowed",
+			healthCheckInterval: time.Minute,
+		},
+		{
+			name:            "default endpoint disallowed without health check",
+			disallowDefault: true,
+		},
+		{
+			name:                "default endpoint disallowed with health check",
+			disallowDefault:     true,
+			healthCheckInterval: time.Minute,
+			expectErr:           true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkScorecardEndpointFlags(tt.disallowDefault, tt.healthCheckInterval)
+			if (err != nil) != tt.expectErr {
+				t.Errorf("checkScorecardEndpointFlags() error = %v, expectErr %v", err, tt.expectErr)
+			}
+		})
+	}
+}